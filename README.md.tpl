@@ -24,6 +24,13 @@ Here's the same example using `dst`:
 
 {{ "ExampleDstFixed" | example }}
 
+## Requirements
+
+Go 1.25 or later is required. The `dst` tree supports type parameters, which need Go 1.18, but the 
+`decorator.Load` function and the `gopackages` resolver use `golang.org/x/tools/go/packages`, and 
+`golang.org/x/tools` v0.44.0 is the oldest release that reads the export data written by the 
+current Go toolchains. That release requires Go 1.25.
+
 ## Usage
 
 Parsing a source file to `dst` and printing the results after modification can be accomplished with 
@@ -81,11 +88,92 @@ use the `Clone` function to make a deep copy of the node before re-use:
 
 {{ "ExampleClone" | example }}
 
+`CloneWithMap` also returns a map from each original node to its copy, and can rebuild the `Obj` 
+and `Scope` links inside the copy:
+
+```go
+out, nodes := dst.CloneWithMap(fn.Body, dst.CloneOptions{Objects: true})
+```
+
+### Objects
+
+The `Obj` and `Scope` fields are not updated when the tree is modified. `ResolveFile` and 
+`ResolvePackage` rebuild them in place, in the same way as `go/parser`. An error is returned if a 
+node is used more than once in the tree:
+
+```go
+if err := dst.ResolveFile(f); err != nil {
+	return err
+}
+```
+
+### Equal
+
+The `Equal` function compares two trees structurally, and `Hash` returns a hash that is consistent 
+with it. Options can ignore decorations, spacing and `Obj` / `Scope` links, and normalize the `Path` 
+of identifiers:
+
+```go
+dst.Equal(a, b, dst.EqualOptions{IgnoreDecorations: true, IgnoreSpacing: true})
+```
+
+### JSON
+
+The `Marshal` and `Unmarshal` functions encode a tree as JSON, including decorations, so it can be 
+sent to another process or stored as a golden file, and restored to identical source. `Obj` and 
+`Scope` links and `File.Unresolved` are optionally encoded as references:
+
+```go
+b, err := dst.Marshal(f, dst.MarshalOptions{Objects: true, Indent: "  "})
+...
+n, err := dst.Unmarshal(b)
+```
+
 ### Apply
 
 The [dstutil](https://github.com/dave/dst/tree/master/dstutil) package is a fork of `golang.org/x/tools/go/ast/astutil`, 
 and provides the `Apply` function with similar semantics.     
 
+### Match
+
+The [match](https://github.com/dave/dst/tree/master/dstutil/match) package finds and rewrites nodes 
+using Go syntax patterns with wildcards, in the style of gogrep. `$x` matches any node and binds it, 
+`$x...` (or `$*x`) matches a list of nodes, and `$_` matches without binding:
+
+```go
+rule := match.MustNewRule(`if $err != nil { return $_... }`, `if $err != nil { return wrap($err) }`)
+result, count, err := rule.Apply(f)
+```
+
+Bound nodes are moved into the rewritten code, so their decorations are preserved.
+
+### Inspector
+
+The [inspector](https://github.com/dave/dst/tree/master/dstutil/inspector) package is a fork of 
+`golang.org/x/tools/go/ast/inspector`. It walks the trees once and provides fast `Preorder`, `Nodes` 
+and `WithStack` traversals filtered by node type, so many analyses can share one walk. The event list 
+is rebuilt automatically after the trees are modified by the `Cursor` methods of `dstutil.Apply`, and 
+`Invalidate` must be called after the trees are modified in other ways.
+
+### Diff
+
+The [diff](https://github.com/dave/dst/tree/master/dstutil/diff) package compares two trees and 
+reports the nodes that were inserted, deleted, moved and modified with their field paths, including 
+changes to decorations:
+
+```go
+changes := diff.Files(before, after)
+fmt.Println(changes)           // e.g. modify Decls[0].Body.List[0] *dst.AssignStmt: Tok = -> :=
+fmt.Println(changes.Summary()) // e.g. 1 modified
+```
+
+### Graph
+
+The [graph](https://github.com/dave/dst/tree/master/dstutil/graph) package renders a tree as a 
+Graphviz DOT graph (`graph.Dot`) or a self-contained HTML page (`graph.HTML`), showing each node, 
+the fields that contain it, and every decoration attachment point with its decorations. This is 
+useful when working out where a comment is attached.
+
 ### Imports
 
 The decorator can automatically manage the `import` block, which is a non-trivial task.
@@ -115,6 +203,48 @@ The [Load](https://godoc.org/github.com/dave/dst/decorator#Load) convenience fun
 
 {{ "ExampleImports" | example }}
 
+After the files in `Package.Syntax` are modified, `Check` type checks them again in memory, 
+using the loaded imports. It refreshes `Types`, `TypesInfo` and `TypesSyntax` without modifying the 
+`packages.Package`, and `Package.Map` maps the dst nodes to the ast nodes that `TypesInfo` refers to:
+
+```go
+if err := pkg.Check(); err != nil {
+	return err
+}
+t := pkg.TypeOf(expr)
+```
+
+The `TypeOf`, `ObjectOf`, `Selection` and `Implicits` methods look up `TypesInfo` by dst node, 
+and handle qualified identifiers that were decorated from a `SelectorExpr`.
+
+### Refactor
+
+The `dstutil/refactor` package implements refactorings of loaded packages using the type 
+information. `Rename` renames an identifier and every reference to it across the loaded packages, 
+and returns an error if the rename would conflict with another declaration or change what a 
+reference refers to:
+
+```go
+pkgs, err := decorator.Load(nil, "./...")
+...
+changed, err := refactor.Rename(pkgs, pkg, ident, "NewName")
+...
+for _, p := range changed {
+	if err := p.Save(); err != nil {
+		...
+	}
+}
+```
+
+`Move` moves top-level declarations (with their decorations, and the methods of moved types) to a 
+file in another package. The `Path` of every reference is updated, so the import blocks are fixed 
+up by the restorer when the packages are saved. An error is returned if the move would create an 
+import cycle or break a reference to an unexported object:
+
+```go
+changed, err := refactor.Move(pkgs, from, []dst.Decl{decl}, to, to.Syntax[0])
+```
+
 ### Mappings
 
 The decorator exposes `Dst.Nodes` and `Ast.Nodes` which map between `ast.Node` and `dst.Node`. This 
@@ -158,6 +288,15 @@ to resolve identifiers from dot-imported packages, so will panic if a dot-import
 the import block. It uses the provided `RestorerResolver` to resolve the names of all imported 
 packages. If no `RestorerResolver` is provided, the [guess](#guess-and-simple) implementation is used. 
 
+#### goimporter
+
+The [goimporter](https://github.com/dave/dst/blob/master/decorator/resolver/goimporter/resolver.go) 
+package provides a `DecoratorResolver` that scans a single ast file like `goast`, but loads the 
+compiled export data of the imported packages with a `go/types.Importer` (`go/importer.Default()` 
+unless another is provided). Identifiers from dot-imported packages are resolved by looking them up in 
+the scope of the dot-imported package, so individual files can be decorated without a full 
+`packages.Load`. 
+
 ### RestorerResolver
 
 #### gopackages
@@ -166,6 +305,15 @@ The [gopackages](https://github.com/dave/dst/blob/master/decorator/resolver/gopa
 package provides a `RestorerResolver` with full compatibility with Go modules. It uses 
 `golang.org/x/tools/go/packages` to load the package data. This may be very slow, and uses the `go` 
 command line tool to query package data, so may not be compatible with some environments. 
+Resolved names can be cached by setting `Cache` (keyed by directory, build flags, environment and 
+overlay), and all the imports of a file are resolved with a single `packages.Load` call. 
+
+#### gomod
+
+The [gomod](https://github.com/dave/dst/blob/master/decorator/resolver/gomod/resolver.go) 
+package provides a `RestorerResolver` that reads `go.mod`, the local module tree, 
+`vendor/modules.txt` and the module cache directly. Only the package clauses of the source files are 
+parsed, so it's fast and never invokes the `go` command or accesses the network. 
 
 #### gobuild
 
@@ -182,6 +330,15 @@ provide simple `RestorerResolver` implementations that may be useful in certain
 where performance is critical. `simple` resolves paths only if they occur in a provided map. 
 `guess` guesses the package name based on the last part of the path.
 
+#### chain
+
+The [chain](https://github.com/dave/dst/blob/master/decorator/resolver/chain/resolver.go) package 
+provides a `RestorerResolver` and a `DecoratorResolver` that try several resolvers in turn, falling 
+back to the next when one returns an error. `chain.Default` tries a hints map, then `gomod`, then 
+`gopackages`, and finally `guess`. The resolver that answered each path and the failures of the 
+others are recorded, and are available from the `Answered` and `Failures` methods until `Reset` is 
+called. Only the most recent `chain.MaxFailures` failures are kept. 
+
 ### Example
 
 Here's an example of supplying resolvers for the decorator and restorer:
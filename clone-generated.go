@@ -633,12 +633,18 @@ func Clone(n Node) Node {
 		// Decoration: Start
 		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
 
+		// Special decoration: Start
+		out.Type.Decs.Start = append(out.Type.Decs.Start, n.Type.Decs.Start...)
+
 		// Token: Func
 		out.Type.Func = n.Type.Func
 
 		// Decoration: Func
 		out.Decs.Func = append(out.Decs.Func, n.Decs.Func...)
 
+		// Special decoration: Func
+		out.Type.Decs.Func = append(out.Type.Decs.Func, n.Type.Decs.Func...)
+
 		// Node: Recv
 		if n.Recv != nil {
 			out.Recv = Clone(n.Recv).(*FieldList)
@@ -655,6 +661,17 @@ func Clone(n Node) Node {
 		// Decoration: Name
 		out.Decs.Name = append(out.Decs.Name, n.Decs.Name...)
 
+		// Node: TypeParams
+		if n.Type.TypeParams != nil {
+			out.Type.TypeParams = Clone(n.Type.TypeParams).(*FieldList)
+		}
+
+		// Decoration: TypeParams
+		out.Decs.TypeParams = append(out.Decs.TypeParams, n.Decs.TypeParams...)
+
+		// Special decoration: TypeParams
+		out.Type.Decs.TypeParams = append(out.Type.Decs.TypeParams, n.Type.Decs.TypeParams...)
+
 		// Node: Params
 		if n.Type.Params != nil {
 			out.Type.Params = Clone(n.Type.Params).(*FieldList)
@@ -663,6 +680,9 @@ func Clone(n Node) Node {
 		// Decoration: Params
 		out.Decs.Params = append(out.Decs.Params, n.Decs.Params...)
 
+		// Special decoration: Params
+		out.Type.Decs.Params = append(out.Type.Decs.Params, n.Type.Decs.Params...)
+
 		// Node: Results
 		if n.Type.Results != nil {
 			out.Type.Results = Clone(n.Type.Results).(*FieldList)
@@ -671,6 +691,9 @@ func Clone(n Node) Node {
 		// Decoration: Results
 		out.Decs.Results = append(out.Decs.Results, n.Decs.Results...)
 
+		// Special decoration: End
+		out.Type.Decs.End = append(out.Type.Decs.End, n.Type.Decs.End...)
+
 		// Node: Body
 		if n.Body != nil {
 			out.Body = Clone(n.Body).(*BlockStmt)
@@ -723,6 +746,14 @@ func Clone(n Node) Node {
 		// Decoration: Func
 		out.Decs.Func = append(out.Decs.Func, n.Decs.Func...)
 
+		// Node: TypeParams
+		if n.TypeParams != nil {
+			out.TypeParams = Clone(n.TypeParams).(*FieldList)
+		}
+
+		// Decoration: TypeParams
+		out.Decs.TypeParams = append(out.Decs.TypeParams, n.Decs.TypeParams...)
+
 		// Node: Params
 		if n.Params != nil {
 			out.Params = Clone(n.Params).(*FieldList)
@@ -954,6 +985,39 @@ func Clone(n Node) Node {
 
 		out.Decs.After = n.Decs.After
 
+		return out
+	case *IndexListExpr:
+		out := &IndexListExpr{}
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = Clone(n.X).(Expr)
+		}
+
+		// Decoration: X
+		out.Decs.X = append(out.Decs.X, n.Decs.X...)
+
+		// Decoration: Lbrack
+		out.Decs.Lbrack = append(out.Decs.Lbrack, n.Decs.Lbrack...)
+
+		// List: Indices
+		for _, v := range n.Indices {
+			out.Indices = append(out.Indices, Clone(v).(Expr))
+		}
+
+		// Decoration: Indices
+		out.Decs.Indices = append(out.Decs.Indices, n.Decs.Indices...)
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
 		return out
 	case *InterfaceType:
 		out := &InterfaceType{}
@@ -1453,9 +1517,17 @@ func Clone(n Node) Node {
 			out.Name = Clone(n.Name).(*Ident)
 		}
 
+		// Node: TypeParams
+		if n.TypeParams != nil {
+			out.TypeParams = Clone(n.TypeParams).(*FieldList)
+		}
+
 		// Token: Assign
 		out.Assign = n.Assign
 
+		// Decoration: TypeParams
+		out.Decs.TypeParams = append(out.Decs.TypeParams, n.Decs.TypeParams...)
+
 		// Decoration: Name
 		out.Decs.Name = append(out.Decs.Name, n.Decs.Name...)
 
@@ -1569,3 +1641,1705 @@ func Clone(n Node) Node {
 		panic(fmt.Sprintf("%T", n))
 	}
 }
+
+// clone returns a deep copy of the node, recording the copy of each node. See CloneWithMap.
+func (c *cloner) clone(n Node) Node {
+	switch n := n.(type) {
+	case *ArrayType:
+		out := &ArrayType{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Lbrack
+		out.Decs.Lbrack = append(out.Decs.Lbrack, n.Decs.Lbrack...)
+
+		// Node: Len
+		if n.Len != nil {
+			out.Len = c.clone(n.Len).(Expr)
+		}
+
+		// Decoration: Len
+		out.Decs.Len = append(out.Decs.Len, n.Decs.Len...)
+
+		// Node: Elt
+		if n.Elt != nil {
+			out.Elt = c.clone(n.Elt).(Expr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *AssignStmt:
+		out := &AssignStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// List: Lhs
+		for _, v := range n.Lhs {
+			out.Lhs = append(out.Lhs, c.clone(v).(Expr))
+		}
+
+		// Token: Tok
+		out.Tok = n.Tok
+
+		// Decoration: Tok
+		out.Decs.Tok = append(out.Decs.Tok, n.Decs.Tok...)
+
+		// List: Rhs
+		for _, v := range n.Rhs {
+			out.Rhs = append(out.Rhs, c.clone(v).(Expr))
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *BadDecl:
+		out := &BadDecl{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Bad
+		out.Length = n.Length
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *BadExpr:
+		out := &BadExpr{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Bad
+		out.Length = n.Length
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *BadStmt:
+		out := &BadStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Bad
+		out.Length = n.Length
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *BasicLit:
+		out := &BasicLit{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// String: Value
+		out.Value = n.Value
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		// Value: Kind
+		out.Kind = n.Kind
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *BinaryExpr:
+		out := &BinaryExpr{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = c.clone(n.X).(Expr)
+		}
+
+		// Decoration: X
+		out.Decs.X = append(out.Decs.X, n.Decs.X...)
+
+		// Token: Op
+		out.Op = n.Op
+
+		// Decoration: Op
+		out.Decs.Op = append(out.Decs.Op, n.Decs.Op...)
+
+		// Node: Y
+		if n.Y != nil {
+			out.Y = c.clone(n.Y).(Expr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *BlockStmt:
+		out := &BlockStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Lbrace
+		out.Decs.Lbrace = append(out.Decs.Lbrace, n.Decs.Lbrace...)
+
+		// List: List
+		for _, v := range n.List {
+			out.List = append(out.List, c.clone(v).(Stmt))
+		}
+
+		// Token: Rbrace
+		out.RbraceHasNoPos = n.RbraceHasNoPos
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *BranchStmt:
+		out := &BranchStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Token: Tok
+		out.Tok = n.Tok
+
+		// Decoration: Tok
+		out.Decs.Tok = append(out.Decs.Tok, n.Decs.Tok...)
+
+		// Node: Label
+		if n.Label != nil {
+			out.Label = c.clone(n.Label).(*Ident)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *CallExpr:
+		out := &CallExpr{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: Fun
+		if n.Fun != nil {
+			out.Fun = c.clone(n.Fun).(Expr)
+		}
+
+		// Decoration: Fun
+		out.Decs.Fun = append(out.Decs.Fun, n.Decs.Fun...)
+
+		// Decoration: Lparen
+		out.Decs.Lparen = append(out.Decs.Lparen, n.Decs.Lparen...)
+
+		// List: Args
+		for _, v := range n.Args {
+			out.Args = append(out.Args, c.clone(v).(Expr))
+		}
+
+		// Token: Ellipsis
+		out.Ellipsis = n.Ellipsis
+
+		// Decoration: Ellipsis
+		out.Decs.Ellipsis = append(out.Decs.Ellipsis, n.Decs.Ellipsis...)
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *CaseClause:
+		out := &CaseClause{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Case
+		out.Decs.Case = append(out.Decs.Case, n.Decs.Case...)
+
+		// List: List
+		for _, v := range n.List {
+			out.List = append(out.List, c.clone(v).(Expr))
+		}
+
+		// Decoration: Colon
+		out.Decs.Colon = append(out.Decs.Colon, n.Decs.Colon...)
+
+		// List: Body
+		for _, v := range n.Body {
+			out.Body = append(out.Body, c.clone(v).(Stmt))
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *ChanType:
+		out := &ChanType{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Begin
+		out.Decs.Begin = append(out.Decs.Begin, n.Decs.Begin...)
+
+		// Decoration: Arrow
+		out.Decs.Arrow = append(out.Decs.Arrow, n.Decs.Arrow...)
+
+		// Node: Value
+		if n.Value != nil {
+			out.Value = c.clone(n.Value).(Expr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		// Value: Dir
+		out.Dir = n.Dir
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *CommClause:
+		out := &CommClause{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Case
+		out.Decs.Case = append(out.Decs.Case, n.Decs.Case...)
+
+		// Node: Comm
+		if n.Comm != nil {
+			out.Comm = c.clone(n.Comm).(Stmt)
+		}
+
+		// Decoration: Comm
+		out.Decs.Comm = append(out.Decs.Comm, n.Decs.Comm...)
+
+		// Decoration: Colon
+		out.Decs.Colon = append(out.Decs.Colon, n.Decs.Colon...)
+
+		// List: Body
+		for _, v := range n.Body {
+			out.Body = append(out.Body, c.clone(v).(Stmt))
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *CompositeLit:
+		out := &CompositeLit{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: Type
+		if n.Type != nil {
+			out.Type = c.clone(n.Type).(Expr)
+		}
+
+		// Decoration: Type
+		out.Decs.Type = append(out.Decs.Type, n.Decs.Type...)
+
+		// Decoration: Lbrace
+		out.Decs.Lbrace = append(out.Decs.Lbrace, n.Decs.Lbrace...)
+
+		// List: Elts
+		for _, v := range n.Elts {
+			out.Elts = append(out.Elts, c.clone(v).(Expr))
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		// Value: Incomplete
+		out.Incomplete = n.Incomplete
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *DeclStmt:
+		out := &DeclStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: Decl
+		if n.Decl != nil {
+			out.Decl = c.clone(n.Decl).(Decl)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *DeferStmt:
+		out := &DeferStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Defer
+		out.Decs.Defer = append(out.Decs.Defer, n.Decs.Defer...)
+
+		// Node: Call
+		if n.Call != nil {
+			out.Call = c.clone(n.Call).(*CallExpr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *Ellipsis:
+		out := &Ellipsis{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Ellipsis
+		out.Decs.Ellipsis = append(out.Decs.Ellipsis, n.Decs.Ellipsis...)
+
+		// Node: Elt
+		if n.Elt != nil {
+			out.Elt = c.clone(n.Elt).(Expr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *EmptyStmt:
+		out := &EmptyStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		// Value: Implicit
+		out.Implicit = n.Implicit
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *ExprStmt:
+		out := &ExprStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = c.clone(n.X).(Expr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *Field:
+		out := &Field{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// List: Names
+		for _, v := range n.Names {
+			out.Names = append(out.Names, c.clone(v).(*Ident))
+		}
+
+		// Node: Type
+		if n.Type != nil {
+			out.Type = c.clone(n.Type).(Expr)
+		}
+
+		// Decoration: Type
+		out.Decs.Type = append(out.Decs.Type, n.Decs.Type...)
+
+		// Node: Tag
+		if n.Tag != nil {
+			out.Tag = c.clone(n.Tag).(*BasicLit)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *FieldList:
+		out := &FieldList{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Token: Opening
+		out.Opening = n.Opening
+
+		// Decoration: Opening
+		out.Decs.Opening = append(out.Decs.Opening, n.Decs.Opening...)
+
+		// List: List
+		for _, v := range n.List {
+			out.List = append(out.List, c.clone(v).(*Field))
+		}
+
+		// Token: Closing
+		out.Closing = n.Closing
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *File:
+		out := &File{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Package
+		out.Decs.Package = append(out.Decs.Package, n.Decs.Package...)
+
+		// Node: Name
+		if n.Name != nil {
+			out.Name = c.clone(n.Name).(*Ident)
+		}
+
+		// Decoration: Name
+		out.Decs.Name = append(out.Decs.Name, n.Decs.Name...)
+
+		// List: Decls
+		for _, v := range n.Decls {
+			out.Decls = append(out.Decls, c.clone(v).(Decl))
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		// Scope: Scope
+		out.Scope = c.scope(n.Scope)
+
+		// List: Imports
+		for _, v := range n.Imports {
+			out.Imports = append(out.Imports, c.reuse(v).(*ImportSpec))
+		}
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *ForStmt:
+		out := &ForStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: For
+		out.Decs.For = append(out.Decs.For, n.Decs.For...)
+
+		// Node: Init
+		if n.Init != nil {
+			out.Init = c.clone(n.Init).(Stmt)
+		}
+
+		// Decoration: Init
+		out.Decs.Init = append(out.Decs.Init, n.Decs.Init...)
+
+		// Node: Cond
+		if n.Cond != nil {
+			out.Cond = c.clone(n.Cond).(Expr)
+		}
+
+		// Decoration: Cond
+		out.Decs.Cond = append(out.Decs.Cond, n.Decs.Cond...)
+
+		// Node: Post
+		if n.Post != nil {
+			out.Post = c.clone(n.Post).(Stmt)
+		}
+
+		// Decoration: Post
+		out.Decs.Post = append(out.Decs.Post, n.Decs.Post...)
+
+		// Node: Body
+		if n.Body != nil {
+			out.Body = c.clone(n.Body).(*BlockStmt)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *FuncDecl:
+		out := &FuncDecl{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Init: Type
+		out.Type = &FuncType{}
+		if n.Type != nil {
+			c.nodes[n.Type] = out.Type
+		}
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Special decoration: Start
+		out.Type.Decs.Start = append(out.Type.Decs.Start, n.Type.Decs.Start...)
+
+		// Token: Func
+		out.Type.Func = n.Type.Func
+
+		// Decoration: Func
+		out.Decs.Func = append(out.Decs.Func, n.Decs.Func...)
+
+		// Special decoration: Func
+		out.Type.Decs.Func = append(out.Type.Decs.Func, n.Type.Decs.Func...)
+
+		// Node: Recv
+		if n.Recv != nil {
+			out.Recv = c.clone(n.Recv).(*FieldList)
+		}
+
+		// Decoration: Recv
+		out.Decs.Recv = append(out.Decs.Recv, n.Decs.Recv...)
+
+		// Node: Name
+		if n.Name != nil {
+			out.Name = c.clone(n.Name).(*Ident)
+		}
+
+		// Decoration: Name
+		out.Decs.Name = append(out.Decs.Name, n.Decs.Name...)
+
+		// Node: TypeParams
+		if n.Type.TypeParams != nil {
+			out.Type.TypeParams = c.clone(n.Type.TypeParams).(*FieldList)
+		}
+
+		// Decoration: TypeParams
+		out.Decs.TypeParams = append(out.Decs.TypeParams, n.Decs.TypeParams...)
+
+		// Special decoration: TypeParams
+		out.Type.Decs.TypeParams = append(out.Type.Decs.TypeParams, n.Type.Decs.TypeParams...)
+
+		// Node: Params
+		if n.Type.Params != nil {
+			out.Type.Params = c.clone(n.Type.Params).(*FieldList)
+		}
+
+		// Decoration: Params
+		out.Decs.Params = append(out.Decs.Params, n.Decs.Params...)
+
+		// Special decoration: Params
+		out.Type.Decs.Params = append(out.Type.Decs.Params, n.Type.Decs.Params...)
+
+		// Node: Results
+		if n.Type.Results != nil {
+			out.Type.Results = c.clone(n.Type.Results).(*FieldList)
+		}
+
+		// Decoration: Results
+		out.Decs.Results = append(out.Decs.Results, n.Decs.Results...)
+
+		// Special decoration: End
+		out.Type.Decs.End = append(out.Type.Decs.End, n.Type.Decs.End...)
+
+		// Node: Body
+		if n.Body != nil {
+			out.Body = c.clone(n.Body).(*BlockStmt)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *FuncLit:
+		out := &FuncLit{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: Type
+		if n.Type != nil {
+			out.Type = c.clone(n.Type).(*FuncType)
+		}
+
+		// Decoration: Type
+		out.Decs.Type = append(out.Decs.Type, n.Decs.Type...)
+
+		// Node: Body
+		if n.Body != nil {
+			out.Body = c.clone(n.Body).(*BlockStmt)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *FuncType:
+		out := &FuncType{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Token: Func
+		out.Func = n.Func
+
+		// Decoration: Func
+		out.Decs.Func = append(out.Decs.Func, n.Decs.Func...)
+
+		// Node: TypeParams
+		if n.TypeParams != nil {
+			out.TypeParams = c.clone(n.TypeParams).(*FieldList)
+		}
+
+		// Decoration: TypeParams
+		out.Decs.TypeParams = append(out.Decs.TypeParams, n.Decs.TypeParams...)
+
+		// Node: Params
+		if n.Params != nil {
+			out.Params = c.clone(n.Params).(*FieldList)
+		}
+
+		// Decoration: Params
+		out.Decs.Params = append(out.Decs.Params, n.Decs.Params...)
+
+		// Node: Results
+		if n.Results != nil {
+			out.Results = c.clone(n.Results).(*FieldList)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *GenDecl:
+		out := &GenDecl{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Token: Tok
+		out.Tok = n.Tok
+
+		// Decoration: Tok
+		out.Decs.Tok = append(out.Decs.Tok, n.Decs.Tok...)
+
+		// Token: Lparen
+		out.Lparen = n.Lparen
+
+		// Decoration: Lparen
+		out.Decs.Lparen = append(out.Decs.Lparen, n.Decs.Lparen...)
+
+		// List: Specs
+		for _, v := range n.Specs {
+			out.Specs = append(out.Specs, c.clone(v).(Spec))
+		}
+
+		// Token: Rparen
+		out.Rparen = n.Rparen
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *GoStmt:
+		out := &GoStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Go
+		out.Decs.Go = append(out.Decs.Go, n.Decs.Go...)
+
+		// Node: Call
+		if n.Call != nil {
+			out.Call = c.clone(n.Call).(*CallExpr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *Ident:
+		out := &Ident{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: X
+		out.Decs.X = append(out.Decs.X, n.Decs.X...)
+
+		// String: Name
+		out.Name = n.Name
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		// Object: Obj
+		c.object(n.Obj, func(o *Object) {
+			out.Obj = o
+		})
+
+		// Path: Path
+		out.Path = n.Path
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *IfStmt:
+		out := &IfStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: If
+		out.Decs.If = append(out.Decs.If, n.Decs.If...)
+
+		// Node: Init
+		if n.Init != nil {
+			out.Init = c.clone(n.Init).(Stmt)
+		}
+
+		// Decoration: Init
+		out.Decs.Init = append(out.Decs.Init, n.Decs.Init...)
+
+		// Node: Cond
+		if n.Cond != nil {
+			out.Cond = c.clone(n.Cond).(Expr)
+		}
+
+		// Decoration: Cond
+		out.Decs.Cond = append(out.Decs.Cond, n.Decs.Cond...)
+
+		// Node: Body
+		if n.Body != nil {
+			out.Body = c.clone(n.Body).(*BlockStmt)
+		}
+
+		// Decoration: Else
+		out.Decs.Else = append(out.Decs.Else, n.Decs.Else...)
+
+		// Node: Else
+		if n.Else != nil {
+			out.Else = c.clone(n.Else).(Stmt)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *ImportSpec:
+		out := &ImportSpec{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: Name
+		if n.Name != nil {
+			out.Name = c.clone(n.Name).(*Ident)
+		}
+
+		// Decoration: Name
+		out.Decs.Name = append(out.Decs.Name, n.Decs.Name...)
+
+		// Node: Path
+		if n.Path != nil {
+			out.Path = c.clone(n.Path).(*BasicLit)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *IncDecStmt:
+		out := &IncDecStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = c.clone(n.X).(Expr)
+		}
+
+		// Decoration: X
+		out.Decs.X = append(out.Decs.X, n.Decs.X...)
+
+		// Token: Tok
+		out.Tok = n.Tok
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *IndexExpr:
+		out := &IndexExpr{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = c.clone(n.X).(Expr)
+		}
+
+		// Decoration: X
+		out.Decs.X = append(out.Decs.X, n.Decs.X...)
+
+		// Decoration: Lbrack
+		out.Decs.Lbrack = append(out.Decs.Lbrack, n.Decs.Lbrack...)
+
+		// Node: Index
+		if n.Index != nil {
+			out.Index = c.clone(n.Index).(Expr)
+		}
+
+		// Decoration: Index
+		out.Decs.Index = append(out.Decs.Index, n.Decs.Index...)
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *IndexListExpr:
+		out := &IndexListExpr{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = c.clone(n.X).(Expr)
+		}
+
+		// Decoration: X
+		out.Decs.X = append(out.Decs.X, n.Decs.X...)
+
+		// Decoration: Lbrack
+		out.Decs.Lbrack = append(out.Decs.Lbrack, n.Decs.Lbrack...)
+
+		// List: Indices
+		for _, v := range n.Indices {
+			out.Indices = append(out.Indices, c.clone(v).(Expr))
+		}
+
+		// Decoration: Indices
+		out.Decs.Indices = append(out.Decs.Indices, n.Decs.Indices...)
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *InterfaceType:
+		out := &InterfaceType{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Interface
+		out.Decs.Interface = append(out.Decs.Interface, n.Decs.Interface...)
+
+		// Node: Methods
+		if n.Methods != nil {
+			out.Methods = c.clone(n.Methods).(*FieldList)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		// Value: Incomplete
+		out.Incomplete = n.Incomplete
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *KeyValueExpr:
+		out := &KeyValueExpr{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: Key
+		if n.Key != nil {
+			out.Key = c.clone(n.Key).(Expr)
+		}
+
+		// Decoration: Key
+		out.Decs.Key = append(out.Decs.Key, n.Decs.Key...)
+
+		// Decoration: Colon
+		out.Decs.Colon = append(out.Decs.Colon, n.Decs.Colon...)
+
+		// Node: Value
+		if n.Value != nil {
+			out.Value = c.clone(n.Value).(Expr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *LabeledStmt:
+		out := &LabeledStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: Label
+		if n.Label != nil {
+			out.Label = c.clone(n.Label).(*Ident)
+		}
+
+		// Decoration: Label
+		out.Decs.Label = append(out.Decs.Label, n.Decs.Label...)
+
+		// Decoration: Colon
+		out.Decs.Colon = append(out.Decs.Colon, n.Decs.Colon...)
+
+		// Node: Stmt
+		if n.Stmt != nil {
+			out.Stmt = c.clone(n.Stmt).(Stmt)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *MapType:
+		out := &MapType{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Map
+		out.Decs.Map = append(out.Decs.Map, n.Decs.Map...)
+
+		// Node: Key
+		if n.Key != nil {
+			out.Key = c.clone(n.Key).(Expr)
+		}
+
+		// Decoration: Key
+		out.Decs.Key = append(out.Decs.Key, n.Decs.Key...)
+
+		// Node: Value
+		if n.Value != nil {
+			out.Value = c.clone(n.Value).(Expr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *Package:
+		out := &Package{}
+		c.nodes[n] = out
+
+		// Value: Name
+		out.Name = n.Name
+
+		// Scope: Scope
+		out.Scope = c.scope(n.Scope)
+
+		// Map: Imports
+		out.Imports = map[string]*Object{}
+		for k, v := range n.Imports {
+			k := k
+			c.object(v, func(o *Object) {
+				out.Imports[k] = o
+			})
+		}
+
+		// Map: Files
+		out.Files = map[string]*File{}
+		for k, v := range n.Files {
+			out.Files[k] = c.clone(v).(*File)
+		}
+
+		return out
+	case *ParenExpr:
+		out := &ParenExpr{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Lparen
+		out.Decs.Lparen = append(out.Decs.Lparen, n.Decs.Lparen...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = c.clone(n.X).(Expr)
+		}
+
+		// Decoration: X
+		out.Decs.X = append(out.Decs.X, n.Decs.X...)
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *RangeStmt:
+		out := &RangeStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: For
+		out.Decs.For = append(out.Decs.For, n.Decs.For...)
+
+		// Node: Key
+		if n.Key != nil {
+			out.Key = c.clone(n.Key).(Expr)
+		}
+
+		// Decoration: Key
+		out.Decs.Key = append(out.Decs.Key, n.Decs.Key...)
+
+		// Node: Value
+		if n.Value != nil {
+			out.Value = c.clone(n.Value).(Expr)
+		}
+
+		// Decoration: Value
+		out.Decs.Value = append(out.Decs.Value, n.Decs.Value...)
+
+		// Token: Tok
+		out.Tok = n.Tok
+
+		// Decoration: Range
+		out.Decs.Range = append(out.Decs.Range, n.Decs.Range...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = c.clone(n.X).(Expr)
+		}
+
+		// Decoration: X
+		out.Decs.X = append(out.Decs.X, n.Decs.X...)
+
+		// Node: Body
+		if n.Body != nil {
+			out.Body = c.clone(n.Body).(*BlockStmt)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *ReturnStmt:
+		out := &ReturnStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Return
+		out.Decs.Return = append(out.Decs.Return, n.Decs.Return...)
+
+		// List: Results
+		for _, v := range n.Results {
+			out.Results = append(out.Results, c.clone(v).(Expr))
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *SelectStmt:
+		out := &SelectStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Select
+		out.Decs.Select = append(out.Decs.Select, n.Decs.Select...)
+
+		// Node: Body
+		if n.Body != nil {
+			out.Body = c.clone(n.Body).(*BlockStmt)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *SelectorExpr:
+		out := &SelectorExpr{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = c.clone(n.X).(Expr)
+		}
+
+		// Decoration: X
+		out.Decs.X = append(out.Decs.X, n.Decs.X...)
+
+		// Node: Sel
+		if n.Sel != nil {
+			out.Sel = c.clone(n.Sel).(*Ident)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *SendStmt:
+		out := &SendStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: Chan
+		if n.Chan != nil {
+			out.Chan = c.clone(n.Chan).(Expr)
+		}
+
+		// Decoration: Chan
+		out.Decs.Chan = append(out.Decs.Chan, n.Decs.Chan...)
+
+		// Decoration: Arrow
+		out.Decs.Arrow = append(out.Decs.Arrow, n.Decs.Arrow...)
+
+		// Node: Value
+		if n.Value != nil {
+			out.Value = c.clone(n.Value).(Expr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *SliceExpr:
+		out := &SliceExpr{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = c.clone(n.X).(Expr)
+		}
+
+		// Decoration: X
+		out.Decs.X = append(out.Decs.X, n.Decs.X...)
+
+		// Decoration: Lbrack
+		out.Decs.Lbrack = append(out.Decs.Lbrack, n.Decs.Lbrack...)
+
+		// Node: Low
+		if n.Low != nil {
+			out.Low = c.clone(n.Low).(Expr)
+		}
+
+		// Decoration: Low
+		out.Decs.Low = append(out.Decs.Low, n.Decs.Low...)
+
+		// Node: High
+		if n.High != nil {
+			out.High = c.clone(n.High).(Expr)
+		}
+
+		// Decoration: High
+		out.Decs.High = append(out.Decs.High, n.Decs.High...)
+
+		// Node: Max
+		if n.Max != nil {
+			out.Max = c.clone(n.Max).(Expr)
+		}
+
+		// Decoration: Max
+		out.Decs.Max = append(out.Decs.Max, n.Decs.Max...)
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		// Value: Slice3
+		out.Slice3 = n.Slice3
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *StarExpr:
+		out := &StarExpr{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Star
+		out.Decs.Star = append(out.Decs.Star, n.Decs.Star...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = c.clone(n.X).(Expr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *StructType:
+		out := &StructType{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Struct
+		out.Decs.Struct = append(out.Decs.Struct, n.Decs.Struct...)
+
+		// Node: Fields
+		if n.Fields != nil {
+			out.Fields = c.clone(n.Fields).(*FieldList)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		// Value: Incomplete
+		out.Incomplete = n.Incomplete
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *SwitchStmt:
+		out := &SwitchStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Switch
+		out.Decs.Switch = append(out.Decs.Switch, n.Decs.Switch...)
+
+		// Node: Init
+		if n.Init != nil {
+			out.Init = c.clone(n.Init).(Stmt)
+		}
+
+		// Decoration: Init
+		out.Decs.Init = append(out.Decs.Init, n.Decs.Init...)
+
+		// Node: Tag
+		if n.Tag != nil {
+			out.Tag = c.clone(n.Tag).(Expr)
+		}
+
+		// Decoration: Tag
+		out.Decs.Tag = append(out.Decs.Tag, n.Decs.Tag...)
+
+		// Node: Body
+		if n.Body != nil {
+			out.Body = c.clone(n.Body).(*BlockStmt)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *TypeAssertExpr:
+		out := &TypeAssertExpr{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = c.clone(n.X).(Expr)
+		}
+
+		// Decoration: X
+		out.Decs.X = append(out.Decs.X, n.Decs.X...)
+
+		// Decoration: Lparen
+		out.Decs.Lparen = append(out.Decs.Lparen, n.Decs.Lparen...)
+
+		// Node: Type
+		if n.Type != nil {
+			out.Type = c.clone(n.Type).(Expr)
+		}
+
+		// Decoration: Type
+		out.Decs.Type = append(out.Decs.Type, n.Decs.Type...)
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *TypeSpec:
+		out := &TypeSpec{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Node: Name
+		if n.Name != nil {
+			out.Name = c.clone(n.Name).(*Ident)
+		}
+
+		// Node: TypeParams
+		if n.TypeParams != nil {
+			out.TypeParams = c.clone(n.TypeParams).(*FieldList)
+		}
+
+		// Token: Assign
+		out.Assign = n.Assign
+
+		// Decoration: TypeParams
+		out.Decs.TypeParams = append(out.Decs.TypeParams, n.Decs.TypeParams...)
+
+		// Decoration: Name
+		out.Decs.Name = append(out.Decs.Name, n.Decs.Name...)
+
+		// Node: Type
+		if n.Type != nil {
+			out.Type = c.clone(n.Type).(Expr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *TypeSwitchStmt:
+		out := &TypeSwitchStmt{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Decoration: Switch
+		out.Decs.Switch = append(out.Decs.Switch, n.Decs.Switch...)
+
+		// Node: Init
+		if n.Init != nil {
+			out.Init = c.clone(n.Init).(Stmt)
+		}
+
+		// Decoration: Init
+		out.Decs.Init = append(out.Decs.Init, n.Decs.Init...)
+
+		// Node: Assign
+		if n.Assign != nil {
+			out.Assign = c.clone(n.Assign).(Stmt)
+		}
+
+		// Decoration: Assign
+		out.Decs.Assign = append(out.Decs.Assign, n.Decs.Assign...)
+
+		// Node: Body
+		if n.Body != nil {
+			out.Body = c.clone(n.Body).(*BlockStmt)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *UnaryExpr:
+		out := &UnaryExpr{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// Token: Op
+		out.Op = n.Op
+
+		// Decoration: Op
+		out.Decs.Op = append(out.Decs.Op, n.Decs.Op...)
+
+		// Node: X
+		if n.X != nil {
+			out.X = c.clone(n.X).(Expr)
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	case *ValueSpec:
+		out := &ValueSpec{}
+		c.nodes[n] = out
+
+		out.Decs.Before = n.Decs.Before
+
+		// Decoration: Start
+		out.Decs.Start = append(out.Decs.Start, n.Decs.Start...)
+
+		// List: Names
+		for _, v := range n.Names {
+			out.Names = append(out.Names, c.clone(v).(*Ident))
+		}
+
+		// Node: Type
+		if n.Type != nil {
+			out.Type = c.clone(n.Type).(Expr)
+		}
+
+		// Decoration: Assign
+		out.Decs.Assign = append(out.Decs.Assign, n.Decs.Assign...)
+
+		// List: Values
+		for _, v := range n.Values {
+			out.Values = append(out.Values, c.clone(v).(Expr))
+		}
+
+		// Decoration: End
+		out.Decs.End = append(out.Decs.End, n.Decs.End...)
+
+		out.Decs.After = n.Decs.After
+
+		return out
+	default:
+		panic(fmt.Sprintf("%T", n))
+	}
+}
@@ -9,3 +9,121 @@ func CloneObject(o *Object) *Object {
 func CloneScope(s *Scope) *Scope {
 	return nil
 }
+
+// CloneOptions configures CloneWithMap.
+type CloneOptions struct {
+	// Objects rebuilds the Obj and Scope links in the copy. The Scopes of copied nodes are copied,
+	// and so are the Objects that are declared by copied nodes. Links to Objects and Scopes that
+	// belong to nodes outside of the copied tree are preserved, so identifiers that refer to
+	// declarations outside the tree are still resolved.
+	Objects bool
+}
+
+// CloneWithMap returns a deep copy of the node like Clone, and a map from each node in the original
+// tree to its copy.
+func CloneWithMap(n Node, opts CloneOptions) (Node, map[Node]Node) {
+	c := &cloner{opts: opts, nodes: map[Node]Node{}, scopes: map[*Scope]*Scope{}}
+	if n = nilNode(n); n == nil {
+		return nil, c.nodes
+	}
+	out := c.clone(n)
+	c.finish()
+	return out, c.nodes
+}
+
+type cloner struct {
+	opts    CloneOptions
+	nodes   map[Node]Node
+	scopes  map[*Scope]*Scope
+	objects []clonerObject
+}
+
+// clonerObject is a link to an object. The links are set after all the nodes are copied, because an
+// object can be used before the node that declares it.
+type clonerObject struct {
+	o   *Object
+	set func(o *Object)
+}
+
+// reuse returns the copy of n if it has already been copied.
+func (c *cloner) reuse(n Node) Node {
+	if out, ok := c.nodes[n]; ok {
+		return out
+	}
+	return c.clone(n)
+}
+
+func (c *cloner) object(o *Object, set func(o *Object)) {
+	if o == nil || !c.opts.Objects {
+		return
+	}
+	c.objects = append(c.objects, clonerObject{o, set})
+}
+
+func (c *cloner) scope(s *Scope) *Scope {
+	if s == nil || !c.opts.Objects {
+		return nil
+	}
+	if out, ok := c.scopes[s]; ok {
+		return out
+	}
+	out := &Scope{}
+	c.scopes[s] = out
+	return out
+}
+
+// finish sets the links to objects, and the contents of the copied scopes.
+func (c *cloner) finish() {
+	objects := map[*Object]*Object{}
+	var object func(o *Object) *Object
+	object = func(o *Object) *Object {
+		if o == nil {
+			return nil
+		}
+		if out, ok := objects[o]; ok {
+			return out
+		}
+		var decl interface{}
+		switch d := o.Decl.(type) {
+		case *Scope:
+			if s, ok := c.scopes[d]; ok {
+				decl = s
+			}
+		case Node:
+			if n, ok := c.nodes[d]; ok {
+				decl = n
+			}
+		}
+		if decl == nil {
+			// declared outside the copied tree
+			objects[o] = o
+			return o
+		}
+		out := &Object{Kind: o.Kind, Name: o.Name, Decl: decl, Data: o.Data, Type: o.Type}
+		objects[o] = out
+		switch d := o.Data.(type) {
+		case *Scope:
+			if s, ok := c.scopes[d]; ok {
+				out.Data = s
+			}
+		case Node:
+			if n, ok := c.nodes[d]; ok {
+				out.Data = n
+			}
+		}
+		return out
+	}
+	for _, link := range c.objects {
+		link.set(object(link.o))
+	}
+	for s, out := range c.scopes {
+		out.Outer = s.Outer
+		if outer, ok := c.scopes[s.Outer]; ok {
+			out.Outer = outer
+		}
+		out.Objects = make(map[string]*Object, len(s.Objects))
+		for name, o := range s.Objects {
+			out.Objects[name] = object(o)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package dst_test
+
+import (
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+)
+
+const cloneSrc = `package main
+
+import "fmt"
+
+var outer = 1
+
+func f(a int) {
+	b := a + outer
+	fmt.Println(b)
+}`
+
+func TestCloneWithMap(t *testing.T) {
+	f, err := decorator.Parse(cloneSrc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, nodes := dst.CloneWithMap(f, dst.CloneOptions{})
+	if !dst.Equal(f, out, dst.EqualOptions{IgnoreObjects: true}) {
+		t.Fatal("expected copy to be equal")
+	}
+	if nodes[f] != out {
+		t.Fatal("expected the root to be in the map")
+	}
+	dst.Inspect(f, func(n dst.Node) bool {
+		if n == nil {
+			return false
+		}
+		c, ok := nodes[n]
+		if !ok {
+			t.Errorf("%T not in map", n)
+		} else if c == n {
+			t.Errorf("%T not copied", n)
+		}
+		return true
+	})
+	file := out.(*dst.File)
+	if file.Imports[0] != file.Decls[0].(*dst.GenDecl).Specs[0] {
+		t.Fatal("expected File.Imports to contain the copied import spec")
+	}
+	if file.Scope != nil || file.Decls[2].(*dst.FuncDecl).Name.Obj != nil {
+		t.Fatal("expected no objects or scopes")
+	}
+}
+
+func TestCloneWithMapObjects(t *testing.T) {
+	f, err := decorator.Parse(cloneSrc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := f.Decls[2].(*dst.FuncDecl)
+
+	t.Run("file", func(t *testing.T) {
+		out, nodes := dst.CloneWithMap(f, dst.CloneOptions{Objects: true})
+		if !dst.Equal(f, out, dst.EqualOptions{}) {
+			t.Fatal("expected copy to be equal")
+		}
+		file := out.(*dst.File)
+		outFn := nodes[fn].(*dst.FuncDecl)
+		param := outFn.Type.Params.List[0]
+		use := outFn.Body.List[0].(*dst.AssignStmt).Rhs[0].(*dst.BinaryExpr).X.(*dst.Ident)
+		if use.Obj == nil || use.Obj == fn.Type.Params.List[0].Names[0].Obj {
+			t.Fatal("expected a copy of the object")
+		}
+		if use.Obj != param.Names[0].Obj || use.Obj.Decl != param {
+			t.Fatal("expected the copied object to be declared by the copied field")
+		}
+		if file.Scope == f.Scope || file.Scope.Objects["f"] != outFn.Name.Obj || outFn.Name.Obj.Decl != outFn {
+			t.Fatal("expected a copy of the file scope")
+		}
+	})
+
+	t.Run("subtree", func(t *testing.T) {
+		out, _ := dst.CloneWithMap(fn.Body, dst.CloneOptions{Objects: true})
+		body := out.(*dst.BlockStmt)
+		expr := body.List[0].(*dst.AssignStmt).Rhs[0].(*dst.BinaryExpr)
+		original := fn.Body.List[0].(*dst.AssignStmt).Rhs[0].(*dst.BinaryExpr)
+		if expr.X.(*dst.Ident).Obj != original.X.(*dst.Ident).Obj {
+			t.Fatal("expected parameter declared outside the copy to keep the original object")
+		}
+		if expr.Y.(*dst.Ident).Obj != original.Y.(*dst.Ident).Obj {
+			t.Fatal("expected variable declared outside the copy to keep the original object")
+		}
+		b := body.List[0].(*dst.AssignStmt).Lhs[0].(*dst.Ident)
+		use := body.List[1].(*dst.ExprStmt).X.(*dst.CallExpr).Args[0].(*dst.Ident)
+		if b.Obj == fn.Body.List[0].(*dst.AssignStmt).Lhs[0].(*dst.Ident).Obj {
+			t.Fatal("expected a copy of the object declared in the copy")
+		}
+		if use.Obj != b.Obj || b.Obj.Decl != body.List[0] {
+			t.Fatal("expected the copied object to be declared by the copied statement")
+		}
+	})
+}
@@ -2,8 +2,7 @@ package dst
 
 // ArrayTypeDecorations holds decorations for ArrayType:
 //
-// 	type R /*Start*/ [ /*Lbrack*/ 1] /*Len*/ int /*End*/
-//
+//	type R /*Start*/ [ /*Lbrack*/ 1] /*Len*/ int /*End*/
 type ArrayTypeDecorations struct {
 	NodeDecs
 	Lbrack Decorations
@@ -12,42 +11,40 @@ type ArrayTypeDecorations struct {
 
 // AssignStmtDecorations holds decorations for AssignStmt:
 //
-// 	/*Start*/
-// 	i = /*Tok*/ 1 /*End*/
-//
+//	/*Start*/
+//	i = /*Tok*/ 1 /*End*/
 type AssignStmtDecorations struct {
 	NodeDecs
 	Tok Decorations
 }
 
 // BadDeclDecorations holds decorations for BadDecl:
-//
 type BadDeclDecorations struct {
 	NodeDecs
 }
 
 // BadExprDecorations holds decorations for BadExpr:
-//
 type BadExprDecorations struct {
 	NodeDecs
 }
 
 // BadStmtDecorations holds decorations for BadStmt:
-//
 type BadStmtDecorations struct {
 	NodeDecs
 }
 
 // BasicLitDecorations holds decorations for BasicLit:
-//
 type BasicLitDecorations struct {
 	NodeDecs
 }
 
 // BinaryExprDecorations holds decorations for BinaryExpr:
 //
-// 	var P = /*Start*/ 1 /*X*/ & /*Op*/ 2 /*End*/
+//	var P = /*Start*/ 1 /*X*/ & /*Op*/ 2 /*End*/
 //
+//	type P1 interface {
+//		/*Start*/ ~int /*X*/ | /*Op*/ ~string /*End*/
+//	}
 type BinaryExprDecorations struct {
 	NodeDecs
 	X  Decorations
@@ -56,12 +53,11 @@ type BinaryExprDecorations struct {
 
 // BlockStmtDecorations holds decorations for BlockStmt:
 //
-// 	if true /*Start*/ { /*Lbrace*/
-// 		i++
-// 	} /*End*/
-//
-// 	func() /*Start*/ { /*Lbrace*/ i++ } /*End*/ ()
+//	if true /*Start*/ { /*Lbrace*/
+//		i++
+//	} /*End*/
 //
+//	func() /*Start*/ { /*Lbrace*/ i++ } /*End*/ ()
 type BlockStmtDecorations struct {
 	NodeDecs
 	Lbrace Decorations
@@ -69,9 +65,8 @@ type BlockStmtDecorations struct {
 
 // BranchStmtDecorations holds decorations for BranchStmt:
 //
-// 	/*Start*/
-// 	goto /*Tok*/ A /*End*/
-//
+//	/*Start*/
+//	goto /*Tok*/ A /*End*/
 type BranchStmtDecorations struct {
 	NodeDecs
 	Tok Decorations
@@ -79,8 +74,7 @@ type BranchStmtDecorations struct {
 
 // CallExprDecorations holds decorations for CallExpr:
 //
-// 	var L = /*Start*/ C /*Fun*/ ( /*Lparen*/ 0, []int{}... /*Ellipsis*/) /*End*/
-//
+//	var L = /*Start*/ C /*Fun*/ ( /*Lparen*/ 0, []int{}... /*Ellipsis*/) /*End*/
 type CallExprDecorations struct {
 	NodeDecs
 	Fun      Decorations
@@ -90,11 +84,10 @@ type CallExprDecorations struct {
 
 // CaseClauseDecorations holds decorations for CaseClause:
 //
-// 	switch i {
-// 	/*Start*/ case /*Case*/ 1: /*Colon*/
-// 		i++ /*End*/
-// 	}
-//
+//	switch i {
+//	/*Start*/ case /*Case*/ 1: /*Colon*/
+//		i++ /*End*/
+//	}
 type CaseClauseDecorations struct {
 	NodeDecs
 	Case  Decorations
@@ -103,12 +96,11 @@ type CaseClauseDecorations struct {
 
 // ChanTypeDecorations holds decorations for ChanType:
 //
-// 	type W /*Start*/ chan /*Begin*/ int /*End*/
+//	type W /*Start*/ chan /*Begin*/ int /*End*/
 //
-// 	type X /*Start*/ <-chan /*Begin*/ int /*End*/
-//
-// 	type Y /*Start*/ chan /*Begin*/ <- /*Arrow*/ int /*End*/
+//	type X /*Start*/ <-chan /*Begin*/ int /*End*/
 //
+//	type Y /*Start*/ chan /*Begin*/ <- /*Arrow*/ int /*End*/
 type ChanTypeDecorations struct {
 	NodeDecs
 	Begin Decorations
@@ -117,11 +109,10 @@ type ChanTypeDecorations struct {
 
 // CommClauseDecorations holds decorations for CommClause:
 //
-// 	select {
-// 	/*Start*/ case /*Case*/ a := <-c /*Comm*/ : /*Colon*/
-// 		print(a) /*End*/
-// 	}
-//
+//	select {
+//	/*Start*/ case /*Case*/ a := <-c /*Comm*/ : /*Colon*/
+//		print(a) /*End*/
+//	}
 type CommClauseDecorations struct {
 	NodeDecs
 	Case  Decorations
@@ -131,8 +122,7 @@ type CommClauseDecorations struct {
 
 // CompositeLitDecorations holds decorations for CompositeLit:
 //
-// 	var D = /*Start*/ A /*Type*/ { /*Lbrace*/ A: 0} /*End*/
-//
+//	var D = /*Start*/ A /*Type*/ { /*Lbrace*/ A: 0} /*End*/
 type CompositeLitDecorations struct {
 	NodeDecs
 	Type   Decorations
@@ -140,16 +130,14 @@ type CompositeLitDecorations struct {
 }
 
 // DeclStmtDecorations holds decorations for DeclStmt:
-//
 type DeclStmtDecorations struct {
 	NodeDecs
 }
 
 // DeferStmtDecorations holds decorations for DeferStmt:
 //
-// 	/*Start*/
-// 	defer /*Defer*/ func() {}() /*End*/
-//
+//	/*Start*/
+//	defer /*Defer*/ func() {}() /*End*/
 type DeferStmtDecorations struct {
 	NodeDecs
 	Defer Decorations
@@ -157,31 +145,27 @@ type DeferStmtDecorations struct {
 
 // EllipsisDecorations holds decorations for Ellipsis:
 //
-// 	func B(a /*Start*/ ... /*Ellipsis*/ int /*End*/) {}
-//
+//	func B(a /*Start*/ ... /*Ellipsis*/ int /*End*/) {}
 type EllipsisDecorations struct {
 	NodeDecs
 	Ellipsis Decorations
 }
 
 // EmptyStmtDecorations holds decorations for EmptyStmt:
-//
 type EmptyStmtDecorations struct {
 	NodeDecs
 }
 
 // ExprStmtDecorations holds decorations for ExprStmt:
-//
 type ExprStmtDecorations struct {
 	NodeDecs
 }
 
 // FieldDecorations holds decorations for Field:
 //
-// 	type A struct {
-// 		/*Start*/ A int /*Type*/ `a:"a"` /*End*/
-// 	}
-//
+//	type A struct {
+//		/*Start*/ A int /*Type*/ `a:"a"` /*End*/
+//	}
 type FieldDecorations struct {
 	NodeDecs
 	Type Decorations
@@ -189,11 +173,10 @@ type FieldDecorations struct {
 
 // FieldListDecorations holds decorations for FieldList:
 //
-// 	type A1 struct /*Start*/ { /*Opening*/
-// 		a, b int
-// 		c    string
-// 	} /*End*/
-//
+//	type A1 struct /*Start*/ { /*Opening*/
+//		a, b int
+//		c    string
+//	} /*End*/
 type FieldListDecorations struct {
 	NodeDecs
 	Opening Decorations
@@ -201,8 +184,7 @@ type FieldListDecorations struct {
 
 // FileDecorations holds decorations for File:
 //
-// 	/*Start*/ package /*Package*/ data /*Name*/
-//
+//	/*Start*/ package /*Package*/ data /*Name*/
 type FileDecorations struct {
 	NodeDecs
 	Package Decorations
@@ -211,21 +193,20 @@ type FileDecorations struct {
 
 // ForStmtDecorations holds decorations for ForStmt:
 //
-// 	/*Start*/
-// 	for /*For*/ {
-// 		i++
-// 	} /*End*/
-//
-// 	/*Start*/
-// 	for /*For*/ i < 1 /*Cond*/ {
-// 		i++
-// 	} /*End*/
+//	/*Start*/
+//	for /*For*/ {
+//		i++
+//	} /*End*/
 //
-// 	/*Start*/
-// 	for /*For*/ i = 0; /*Init*/ i < 10; /*Cond*/ i++ /*Post*/ {
-// 		i++
-// 	} /*End*/
+//	/*Start*/
+//	for /*For*/ i < 1 /*Cond*/ {
+//		i++
+//	} /*End*/
 //
+//	/*Start*/
+//	for /*For*/ i = 0; /*Init*/ i < 10; /*Cond*/ i++ /*Post*/ {
+//		i++
+//	} /*End*/
 type ForStmtDecorations struct {
 	NodeDecs
 	For  Decorations
@@ -236,34 +217,38 @@ type ForStmtDecorations struct {
 
 // FuncDeclDecorations holds decorations for FuncDecl:
 //
-// 	/*Start*/
-// 	func /*Func*/ d /*Name*/ (d, e int) /*Params*/ {
-// 		return
-// 	} /*End*/
-//
-// 	/*Start*/
-// 	func /*Func*/ (a *A) /*Recv*/ e /*Name*/ (d, e int) /*Params*/ {
-// 		return
-// 	} /*End*/
-//
-// 	/*Start*/
-// 	func /*Func*/ (a *A) /*Recv*/ f /*Name*/ (d, e int) /*Params*/ (f, g int) /*Results*/ {
-// 		return
-// 	} /*End*/
-//
+//	/*Start*/
+//	func /*Func*/ d /*Name*/ (d, e int) /*Params*/ {
+//		return
+//	} /*End*/
+//
+//	/*Start*/
+//	func /*Func*/ (a *A) /*Recv*/ e /*Name*/ (d, e int) /*Params*/ {
+//		return
+//	} /*End*/
+//
+//	/*Start*/
+//	func /*Func*/ (a *A) /*Recv*/ f /*Name*/ (d, e int) /*Params*/ (f, g int) /*Results*/ {
+//		return
+//	} /*End*/
+//
+//	/*Start*/
+//	func /*Func*/ h /*Name*/ [P any] /*TypeParams*/ (d P) /*Params*/ {
+//		return
+//	} /*End*/
 type FuncDeclDecorations struct {
 	NodeDecs
-	Func    Decorations
-	Recv    Decorations
-	Name    Decorations
-	Params  Decorations
-	Results Decorations
+	Func       Decorations
+	Recv       Decorations
+	Name       Decorations
+	TypeParams Decorations
+	Params     Decorations
+	Results    Decorations
 }
 
 // FuncLitDecorations holds decorations for FuncLit:
 //
-// 	var C = /*Start*/ func(a int, b ...int) (c int) /*Type*/ { return 0 } /*End*/
-//
+//	var C = /*Start*/ func(a int, b ...int) (c int) /*Type*/ { return 0 } /*End*/
 type FuncLitDecorations struct {
 	NodeDecs
 	Type Decorations
@@ -271,25 +256,24 @@ type FuncLitDecorations struct {
 
 // FuncTypeDecorations holds decorations for FuncType:
 //
-// 	type T /*Start*/ func /*Func*/ (a int) /*Params*/ (b int) /*End*/
-//
+//	type T /*Start*/ func /*Func*/ (a int) /*Params*/ (b int) /*End*/
 type FuncTypeDecorations struct {
 	NodeDecs
-	Func   Decorations
-	Params Decorations
+	Func       Decorations
+	TypeParams Decorations
+	Params     Decorations
 }
 
 // GenDeclDecorations holds decorations for GenDecl:
 //
-// 	/*Start*/
-// 	const /*Tok*/ ( /*Lparen*/
-// 		a, b = 1, 2
-// 		c    = 3
-// 	) /*End*/
-//
-// 	/*Start*/
-// 	const /*Tok*/ d = 1 /*End*/
+//	/*Start*/
+//	const /*Tok*/ ( /*Lparen*/
+//		a, b = 1, 2
+//		c    = 3
+//	) /*End*/
 //
+//	/*Start*/
+//	const /*Tok*/ d = 1 /*End*/
 type GenDeclDecorations struct {
 	NodeDecs
 	Tok    Decorations
@@ -298,9 +282,8 @@ type GenDeclDecorations struct {
 
 // GoStmtDecorations holds decorations for GoStmt:
 //
-// 	/*Start*/
-// 	go /*Go*/ func() {}() /*End*/
-//
+//	/*Start*/
+//	go /*Go*/ func() {}() /*End*/
 type GoStmtDecorations struct {
 	NodeDecs
 	Go Decorations
@@ -308,12 +291,11 @@ type GoStmtDecorations struct {
 
 // IdentDecorations holds decorations for Ident:
 //
-// 	/*Start*/
-// 	i /*End*/ ++
-//
-// 	/*Start*/
-// 	fmt. /*X*/ Print /*End*/ ()
+//	/*Start*/
+//	i /*End*/ ++
 //
+//	/*Start*/
+//	fmt. /*X*/ Print /*End*/ ()
 type IdentDecorations struct {
 	NodeDecs
 	X Decorations
@@ -321,13 +303,12 @@ type IdentDecorations struct {
 
 // IfStmtDecorations holds decorations for IfStmt:
 //
-// 	/*Start*/
-// 	if /*If*/ a := b; /*Init*/ a /*Cond*/ {
-// 		i++
-// 	} else /*Else*/ {
-// 		i++
-// 	} /*End*/
-//
+//	/*Start*/
+//	if /*If*/ a := b; /*Init*/ a /*Cond*/ {
+//		i++
+//	} else /*Else*/ {
+//		i++
+//	} /*End*/
 type IfStmtDecorations struct {
 	NodeDecs
 	If   Decorations
@@ -338,10 +319,9 @@ type IfStmtDecorations struct {
 
 // ImportSpecDecorations holds decorations for ImportSpec:
 //
-// 	import (
-// 		/*Start*/ fmt /*Name*/ "fmt" /*End*/
-// 	)
-//
+//	import (
+//		/*Start*/ fmt /*Name*/ "fmt" /*End*/
+//	)
 type ImportSpecDecorations struct {
 	NodeDecs
 	Name Decorations
@@ -349,9 +329,8 @@ type ImportSpecDecorations struct {
 
 // IncDecStmtDecorations holds decorations for IncDecStmt:
 //
-// 	/*Start*/
-// 	i /*X*/ ++ /*End*/
-//
+//	/*Start*/
+//	i /*X*/ ++ /*End*/
 type IncDecStmtDecorations struct {
 	NodeDecs
 	X Decorations
@@ -359,8 +338,7 @@ type IncDecStmtDecorations struct {
 
 // IndexExprDecorations holds decorations for IndexExpr:
 //
-// 	var G = /*Start*/ []int{0} /*X*/ [ /*Lbrack*/ 0 /*Index*/] /*End*/
-//
+//	var G = /*Start*/ []int{0} /*X*/ [ /*Lbrack*/ 0 /*Index*/] /*End*/
 type IndexExprDecorations struct {
 	NodeDecs
 	X      Decorations
@@ -368,12 +346,21 @@ type IndexExprDecorations struct {
 	Index  Decorations
 }
 
-// InterfaceTypeDecorations holds decorations for InterfaceType:
+// IndexListExprDecorations holds decorations for IndexListExpr:
 //
-// 	type U /*Start*/ interface /*Interface*/ {
-// 		A()
-// 	} /*End*/
+//	var G1 = /*Start*/ GF /*X*/ [ /*Lbrack*/ int, string /*Indices*/] /*End*/
+type IndexListExprDecorations struct {
+	NodeDecs
+	X       Decorations
+	Lbrack  Decorations
+	Indices Decorations
+}
+
+// InterfaceTypeDecorations holds decorations for InterfaceType:
 //
+//	type U /*Start*/ interface /*Interface*/ {
+//		A()
+//	} /*End*/
 type InterfaceTypeDecorations struct {
 	NodeDecs
 	Interface Decorations
@@ -381,10 +368,9 @@ type InterfaceTypeDecorations struct {
 
 // KeyValueExprDecorations holds decorations for KeyValueExpr:
 //
-// 	var Q = map[string]string{
-// 		/*Start*/ "a" /*Key*/ : /*Colon*/ "a", /*End*/
-// 	}
-//
+//	var Q = map[string]string{
+//		/*Start*/ "a" /*Key*/ : /*Colon*/ "a", /*End*/
+//	}
 type KeyValueExprDecorations struct {
 	NodeDecs
 	Key   Decorations
@@ -393,10 +379,9 @@ type KeyValueExprDecorations struct {
 
 // LabeledStmtDecorations holds decorations for LabeledStmt:
 //
-// 	/*Start*/
-// 	A /*Label*/ : /*Colon*/
-// 		print("Stmt") /*End*/
-//
+//	/*Start*/
+//	A /*Label*/ : /*Colon*/
+//		print("Stmt") /*End*/
 type LabeledStmtDecorations struct {
 	NodeDecs
 	Label Decorations
@@ -405,8 +390,7 @@ type LabeledStmtDecorations struct {
 
 // MapTypeDecorations holds decorations for MapType:
 //
-// 	type V /*Start*/ map[ /*Map*/ int] /*Key*/ int /*End*/
-//
+//	type V /*Start*/ map[ /*Map*/ int] /*Key*/ int /*End*/
 type MapTypeDecorations struct {
 	NodeDecs
 	Map Decorations
@@ -414,15 +398,13 @@ type MapTypeDecorations struct {
 }
 
 // PackageDecorations holds decorations for Package:
-//
 type PackageDecorations struct {
 	NodeDecs
 }
 
 // ParenExprDecorations holds decorations for ParenExpr:
 //
-// 	var E = /*Start*/ ( /*Lparen*/ 1 + 1 /*X*/) /*End*/ / 2
-//
+//	var E = /*Start*/ ( /*Lparen*/ 1 + 1 /*X*/) /*End*/ / 2
 type ParenExprDecorations struct {
 	NodeDecs
 	Lparen Decorations
@@ -431,20 +413,19 @@ type ParenExprDecorations struct {
 
 // RangeStmtDecorations holds decorations for RangeStmt:
 //
-// 	/*Start*/
-// 	for range /*Range*/ a /*X*/ {
-// 	} /*End*/
-//
-// 	/*Start*/
-// 	for /*For*/ k /*Key*/ := range /*Range*/ a /*X*/ {
-// 		print(k)
-// 	} /*End*/
+//	/*Start*/
+//	for range /*Range*/ a /*X*/ {
+//	} /*End*/
 //
-// 	/*Start*/
-// 	for /*For*/ k /*Key*/, v /*Value*/ := range /*Range*/ a /*X*/ {
-// 		print(k, v)
-// 	} /*End*/
+//	/*Start*/
+//	for /*For*/ k /*Key*/ := range /*Range*/ a /*X*/ {
+//		print(k)
+//	} /*End*/
 //
+//	/*Start*/
+//	for /*For*/ k /*Key*/, v /*Value*/ := range /*Range*/ a /*X*/ {
+//		print(k, v)
+//	} /*End*/
 type RangeStmtDecorations struct {
 	NodeDecs
 	For   Decorations
@@ -456,10 +437,9 @@ type RangeStmtDecorations struct {
 
 // ReturnStmtDecorations holds decorations for ReturnStmt:
 //
-// 	func() int {
-// 		/*Start*/ return /*Return*/ 1 /*End*/
-// 	}()
-//
+//	func() int {
+//		/*Start*/ return /*Return*/ 1 /*End*/
+//	}()
 type ReturnStmtDecorations struct {
 	NodeDecs
 	Return Decorations
@@ -467,10 +447,9 @@ type ReturnStmtDecorations struct {
 
 // SelectStmtDecorations holds decorations for SelectStmt:
 //
-// 	/*Start*/
-// 	select /*Select*/ {
-// 	} /*End*/
-//
+//	/*Start*/
+//	select /*Select*/ {
+//	} /*End*/
 type SelectStmtDecorations struct {
 	NodeDecs
 	Select Decorations
@@ -478,8 +457,7 @@ type SelectStmtDecorations struct {
 
 // SelectorExprDecorations holds decorations for SelectorExpr:
 //
-// 	var F = /*Start*/ tt. /*X*/ F /*End*/ ()
-//
+//	var F = /*Start*/ tt. /*X*/ F /*End*/ ()
 type SelectorExprDecorations struct {
 	NodeDecs
 	X Decorations
@@ -487,9 +465,8 @@ type SelectorExprDecorations struct {
 
 // SendStmtDecorations holds decorations for SendStmt:
 //
-// 	/*Start*/
-// 	c /*Chan*/ <- /*Arrow*/ 0 /*End*/
-//
+//	/*Start*/
+//	c /*Chan*/ <- /*Arrow*/ 0 /*End*/
 type SendStmtDecorations struct {
 	NodeDecs
 	Chan  Decorations
@@ -498,18 +475,17 @@ type SendStmtDecorations struct {
 
 // SliceExprDecorations holds decorations for SliceExpr:
 //
-// 	var H = /*Start*/ []int{0, 1, 2} /*X*/ [ /*Lbrack*/ 1: /*Low*/ 2: /*High*/ 3 /*Max*/] /*End*/
+//	var H = /*Start*/ []int{0, 1, 2} /*X*/ [ /*Lbrack*/ 1: /*Low*/ 2: /*High*/ 3 /*Max*/] /*End*/
 //
-// 	var H1 = /*Start*/ []int{0, 1, 2} /*X*/ [ /*Lbrack*/ 1: /*Low*/ 2 /*High*/] /*End*/
+//	var H1 = /*Start*/ []int{0, 1, 2} /*X*/ [ /*Lbrack*/ 1: /*Low*/ 2 /*High*/] /*End*/
 //
-// 	var H2 = /*Start*/ []int{0} /*X*/ [: /*Low*/] /*End*/
+//	var H2 = /*Start*/ []int{0} /*X*/ [: /*Low*/] /*End*/
 //
-// 	var H3 = /*Start*/ []int{0} /*X*/ [ /*Lbrack*/ 1: /*Low*/] /*End*/
+//	var H3 = /*Start*/ []int{0} /*X*/ [ /*Lbrack*/ 1: /*Low*/] /*End*/
 //
-// 	var H4 = /*Start*/ []int{0, 1, 2} /*X*/ [: /*Low*/ 2 /*High*/] /*End*/
-//
-// 	var H5 = /*Start*/ []int{0, 1, 2} /*X*/ [: /*Low*/ 2: /*High*/ 3 /*Max*/] /*End*/
+//	var H4 = /*Start*/ []int{0, 1, 2} /*X*/ [: /*Low*/ 2 /*High*/] /*End*/
 //
+//	var H5 = /*Start*/ []int{0, 1, 2} /*X*/ [: /*Low*/ 2: /*High*/ 3 /*Max*/] /*End*/
 type SliceExprDecorations struct {
 	NodeDecs
 	X      Decorations
@@ -521,8 +497,7 @@ type SliceExprDecorations struct {
 
 // StarExprDecorations holds decorations for StarExpr:
 //
-// 	var N = /*Start*/ * /*Star*/ p /*End*/
-//
+//	var N = /*Start*/ * /*Star*/ p /*End*/
 type StarExprDecorations struct {
 	NodeDecs
 	Star Decorations
@@ -530,10 +505,9 @@ type StarExprDecorations struct {
 
 // StructTypeDecorations holds decorations for StructType:
 //
-// 	type S /*Start*/ struct /*Struct*/ {
-// 		A int
-// 	} /*End*/
-//
+//	type S /*Start*/ struct /*Struct*/ {
+//		A int
+//	} /*End*/
 type StructTypeDecorations struct {
 	NodeDecs
 	Struct Decorations
@@ -541,14 +515,13 @@ type StructTypeDecorations struct {
 
 // SwitchStmtDecorations holds decorations for SwitchStmt:
 //
-// 	/*Start*/
-// 	switch /*Switch*/ i /*Tag*/ {
-// 	} /*End*/
-//
-// 	/*Start*/
-// 	switch /*Switch*/ a := i; /*Init*/ a /*Tag*/ {
-// 	} /*End*/
+//	/*Start*/
+//	switch /*Switch*/ i /*Tag*/ {
+//	} /*End*/
 //
+//	/*Start*/
+//	switch /*Switch*/ a := i; /*Init*/ a /*Tag*/ {
+//	} /*End*/
 type SwitchStmtDecorations struct {
 	NodeDecs
 	Switch Decorations
@@ -558,8 +531,7 @@ type SwitchStmtDecorations struct {
 
 // TypeAssertExprDecorations holds decorations for TypeAssertExpr:
 //
-// 	var J = /*Start*/ f. /*X*/ ( /*Lparen*/ int /*Type*/) /*End*/
-//
+//	var J = /*Start*/ f. /*X*/ ( /*Lparen*/ int /*Type*/) /*End*/
 type TypeAssertExprDecorations struct {
 	NodeDecs
 	X      Decorations
@@ -569,37 +541,44 @@ type TypeAssertExprDecorations struct {
 
 // TypeSpecDecorations holds decorations for TypeSpec:
 //
-// 	type (
-// 		/*Start*/ T1 /*Name*/ []int /*End*/
-// 	)
+//	type (
+//		/*Start*/ T1 /*Name*/ []int /*End*/
+//	)
+//
+//	type (
+//		/*Start*/ T2 = /*Name*/ T1 /*End*/
+//	)
 //
-// 	type (
-// 		/*Start*/ T2 = /*Name*/ T1 /*End*/
-// 	)
+//	type (
+//		/*Start*/ T3[P any] /*TypeParams*/ []P /*End*/
+//	)
 //
+//	type (
+//		/*Start*/ T4[P any] = /*TypeParams*/ T3[P] /*End*/
+//	)
 type TypeSpecDecorations struct {
 	NodeDecs
-	Name Decorations
+	TypeParams Decorations
+	Name       Decorations
 }
 
 // TypeSwitchStmtDecorations holds decorations for TypeSwitchStmt:
 //
-// 	/*Start*/
-// 	switch /*Switch*/ f.(type) /*Assign*/ {
-// 	} /*End*/
-//
-// 	/*Start*/
-// 	switch /*Switch*/ g := f.(type) /*Assign*/ {
-// 	case int:
-// 		print(g)
-// 	} /*End*/
-//
-// 	/*Start*/
-// 	switch /*Switch*/ g := f; /*Init*/ g := g.(type) /*Assign*/ {
-// 	case int:
-// 		print(g)
-// 	} /*End*/
-//
+//	/*Start*/
+//	switch /*Switch*/ f.(type) /*Assign*/ {
+//	} /*End*/
+//
+//	/*Start*/
+//	switch /*Switch*/ g := f.(type) /*Assign*/ {
+//	case int:
+//		print(g)
+//	} /*End*/
+//
+//	/*Start*/
+//	switch /*Switch*/ g := f; /*Init*/ g := g.(type) /*Assign*/ {
+//	case int:
+//		print(g)
+//	} /*End*/
 type TypeSwitchStmtDecorations struct {
 	NodeDecs
 	Switch Decorations
@@ -609,8 +588,11 @@ type TypeSwitchStmtDecorations struct {
 
 // UnaryExprDecorations holds decorations for UnaryExpr:
 //
-// 	var O = /*Start*/ ^ /*Op*/ 1 /*End*/
+//	var O = /*Start*/ ^ /*Op*/ 1 /*End*/
 //
+//	type O1 interface {
+//		/*Start*/ ~ /*Op*/ int /*End*/
+//	}
 type UnaryExprDecorations struct {
 	NodeDecs
 	Op Decorations
@@ -618,18 +600,17 @@ type UnaryExprDecorations struct {
 
 // ValueSpecDecorations holds decorations for ValueSpec:
 //
-// 	var (
-// 		/*Start*/ j = /*Assign*/ 1 /*End*/
-// 	)
-//
-// 	var (
-// 		/*Start*/ k, l = /*Assign*/ 1, 2 /*End*/
-// 	)
+//	var (
+//		/*Start*/ j = /*Assign*/ 1 /*End*/
+//	)
 //
-// 	var (
-// 		/*Start*/ m, n int = /*Assign*/ 1, 2 /*End*/
-// 	)
+//	var (
+//		/*Start*/ k, l = /*Assign*/ 1, 2 /*End*/
+//	)
 //
+//	var (
+//		/*Start*/ m, n int = /*Assign*/ 1, 2 /*End*/
+//	)
 type ValueSpecDecorations struct {
 	NodeDecs
 	Assign Decorations
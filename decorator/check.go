@@ -0,0 +1,128 @@
+package decorator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/dave/dst/decorator/resolver"
+)
+
+// Check restores the files in Syntax in memory and type checks them, so the type information can be
+// queried after the files have been modified. The imports are not reloaded: the types of the
+// imported packages are taken from the packages loaded by Load, and the package names are resolved
+// from the same packages. Use CheckWithResolver if the files import packages that weren't loaded.
+//
+// Types, TypesInfo, TypeErrors, Fset and TypesSyntax are replaced with the results, and Map is
+// replaced with the mapping between the dst nodes and the restored ast nodes. The embedded
+// packages.Package is not modified. The files in Syntax are not changed, except for the updates to
+// the imports made by the Restorer. The decorator is unchanged, so the original positions of the
+// nodes are still available.
+//
+// If the package has type errors, they are stored in TypeErrors and the first is returned.
+func (p *Package) Check() error {
+	return p.CheckWithResolver(loadedResolver{p})
+}
+
+// CheckWithResolver is the same as Check, but uses the provided resolver to resolve the package
+// names of the imports.
+func (p *Package) CheckWithResolver(res resolver.RestorerResolver) error {
+	r := NewRestorerWithImports(p.PkgPath, res)
+	var files []*ast.File
+	for _, file := range p.Syntax {
+		fr := r.FileRestorer()
+		fr.Name = p.Decorator.Filenames[file]
+		f, err := fr.RestoreFile(file)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+	}
+
+	info := &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Instances:  map[*ast.Ident]types.Instance{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Implicits:  map[ast.Node]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+		Scopes:     map[ast.Node]*types.Scope{},
+	}
+	var typeErrors []types.Error
+	config := &types.Config{
+		Importer: importerFunc(p.importPackage),
+		Sizes:    p.TypesSizes,
+		Error: func(err error) {
+			if err, ok := err.(types.Error); ok {
+				typeErrors = append(typeErrors, err)
+			}
+		},
+	}
+	if p.Module != nil && p.Module.GoVersion != "" {
+		config.GoVersion = "go" + p.Module.GoVersion
+	}
+	pkg, err := config.Check(p.PkgPath, r.Fset, files, info)
+
+	p.Fset = r.Fset
+	p.TypesSyntax = files
+	p.Types = pkg
+	p.TypesInfo = info
+	p.TypeErrors = typeErrors
+	p.Map = r.Map
+	return err
+}
+
+// importPackage returns the types of a loaded package that is imported by p. Packages that are not
+// imported by p are found in the imports of the other loaded packages.
+func (p *Package) importPackage(path string) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+	imp := p.findImport(path)
+	if imp == nil || imp.Types == nil {
+		return nil, fmt.Errorf("package %s was not loaded", path)
+	}
+	return imp.Types, nil
+}
+
+// findImport finds the loaded package with the path, in the imports of p or their imports.
+func (p *Package) findImport(path string) *Package {
+	if imp, ok := p.Imports[path]; ok {
+		return imp
+	}
+	seen := map[*Package]bool{}
+	var find func(pkg *Package) *Package
+	find = func(pkg *Package) *Package {
+		if seen[pkg] {
+			return nil
+		}
+		seen[pkg] = true
+		if pkg.PkgPath == path {
+			return pkg
+		}
+		for _, imp := range pkg.Imports {
+			if found := find(imp); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return find(p)
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+// loadedResolver resolves package names from the loaded packages.
+type loadedResolver struct {
+	pkg *Package
+}
+
+func (r loadedResolver) ResolvePackage(path string) (string, error) {
+	imp := r.pkg.findImport(path)
+	if imp == nil || imp.Name == "" {
+		return "", resolver.ErrPackageNotFound
+	}
+	return imp.Name, nil
+}
@@ -1,6 +1,8 @@
 package decorator
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/parser"
@@ -59,6 +61,14 @@ type Decorator struct {
 	// is renamed. Setting ResolveLocalPath to true prevents this, so all idents will have the
 	// package path added.
 	ResolveLocalPath bool
+	// If KeepSource is set, ParseFile keeps the source of each file, so a Restorer with Preserve
+	// set can copy unmodified declarations from it. The source is kept for the lifetime of the
+	// Decorator. Without KeepSource, the source is read from disk when it is needed, so files
+	// parsed from memory are re-printed in full.
+	KeepSource bool
+
+	sources   map[*ast.File][]byte    // Source of files parsed by ParseFile, used by Restorer.Preserve
+	originals map[*ast.File]*dst.File // Unmodified decorations of files, used by Restorer.Preserve
 }
 
 // Parse uses parser.ParseFile to parse and decorate a Go source file. The src parameter should
@@ -71,13 +81,25 @@ func (d *Decorator) Parse(src interface{}) (*dst.File, error) {
 // is added to mode if it doesn't exist.
 func (d *Decorator) ParseFile(filename string, src interface{}, mode parser.Mode) (*dst.File, error) {
 
+	data, err := readSource(filename, src)
+	if err != nil {
+		return nil, err
+	}
+
 	// If ParseFile returns an error and also a non-nil file, the errors were just parse errors so
 	// we should continue decorating the file and return the error.
-	f, perr := parser.ParseFile(d.Fset, filename, src, mode|parser.ParseComments)
+	f, perr := parser.ParseFile(d.Fset, filename, data, mode|parser.ParseComments)
 	if perr != nil && f == nil {
 		return nil, perr
 	}
 
+	if d.KeepSource {
+		if d.sources == nil {
+			d.sources = map[*ast.File][]byte{}
+		}
+		d.sources[f] = data
+	}
+
 	file, err := d.DecorateFile(f)
 	if err != nil {
 		return nil, err
@@ -86,6 +108,27 @@ func (d *Decorator) ParseFile(filename string, src interface{}, mode parser.Mode
 	return file, perr
 }
 
+// readSource converts src to a []byte in the same way as parser.ParseFile. If src is nil, the
+// file is read from disk.
+func readSource(filename string, src interface{}) ([]byte, error) {
+	if src == nil {
+		return os.ReadFile(filename)
+	}
+	switch s := src.(type) {
+	case string:
+		return []byte(s), nil
+	case []byte:
+		return s, nil
+	case *bytes.Buffer:
+		if s != nil {
+			return s.Bytes(), nil
+		}
+	case io.Reader:
+		return io.ReadAll(s)
+	}
+	return nil, errors.New("invalid source")
+}
+
 // ParseDir uses parser.ParseDir to parse and decorate a directory containing Go source. The
 // ParseComments flag is added to mode if it doesn't exist.
 func (d *Decorator) ParseDir(dir string, filter func(os.FileInfo) bool, mode parser.Mode) (map[string]*dst.Package, error) {
@@ -431,6 +431,37 @@ KeyValueExpr [New line before] [New line after]`,
 			expect: `FuncDecl [Empty line before] [Start "// FuncDecl"] [Func "/*FuncDeclDoc*/"] [Recv "/*FuncDeclRecv*/"] [Name "/*FuncDeclName*/"] [Params "/*FuncDeclParams*/"] [Results "/*FuncDeclType*/"]
 BlockStmt [Lbrace "\n"]`,
 		},
+		{
+			name: "generic FuncDecl",
+			code: `package main
+
+			// FuncDecl
+			func /*Func*/ a /*Name*/ [ /*Opening*/ T any, U ~int | ~string] /*TypeParams*/ (t T, u U) /*Params*/ {
+			}`,
+			expect: `FuncDecl [Empty line before] [Start "// FuncDecl"] [Func "/*Func*/"] [Name "/*Name*/"] [TypeParams "/*TypeParams*/"] [Params "/*Params*/"]
+FieldList [Opening "/*Opening*/"]
+BlockStmt [Lbrace "\n"]`,
+		},
+		{
+			name: "generic TypeSpec",
+			code: `package main
+
+			type A[T any /*T*/, U any] /*TypeParams*/ struct {
+				t T
+				u U
+			}
+
+			var b = /*Start*/ A /*X*/ [ /*Lbrack*/ int, string /*Indices*/] /*End*/ {}`,
+			expect: `GenDecl [Empty line before] [Empty line after]
+TypeSpec [TypeParams "/*TypeParams*/"]
+Field [End "/*T*/"]
+Field [New line before] [New line after]
+Field [New line before] [New line after]
+GenDecl [Empty line before]
+ValueSpec [Assign "/*Start*/"]
+CompositeLit [Type "/*End*/"]
+IndexListExpr [X "/*X*/"] [Lbrack "/*Lbrack*/"] [Indices "/*Indices*/"]`,
+		},
 	}
 	var solo bool
 	for _, test := range tests {
@@ -0,0 +1,75 @@
+package decorator
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/dave/dst"
+)
+
+// TypeOf returns the type of the expression from TypesInfo, or nil if it isn't known. Qualified
+// identifiers (an Ident with Path set) have the type of the selector expression they were decorated
+// from. Nodes added after Load have no type information until Check is called.
+func (p *Package) TypeOf(e dst.Expr) types.Type {
+	if p.TypesInfo == nil {
+		return nil
+	}
+	switch n := p.Map.Ast.Nodes[e].(type) {
+	case *ast.SelectorExpr:
+		if t := p.TypesInfo.TypeOf(n); t != nil {
+			return t
+		}
+		if _, ok := e.(*dst.Ident); ok {
+			return p.TypesInfo.TypeOf(n.Sel)
+		}
+	case ast.Expr:
+		return p.TypesInfo.TypeOf(n)
+	}
+	return nil
+}
+
+// ObjectOf returns the object denoted by the identifier from TypesInfo, or nil if it isn't known.
+// For qualified identifiers (an Ident with Path set) this is the object denoted by the selector of
+// the selector expression they were decorated from.
+func (p *Package) ObjectOf(id *dst.Ident) types.Object {
+	if p.TypesInfo == nil {
+		return nil
+	}
+	switch n := p.Map.Ast.Nodes[id].(type) {
+	case *ast.SelectorExpr:
+		return p.TypesInfo.ObjectOf(n.Sel)
+	case *ast.Ident:
+		return p.TypesInfo.ObjectOf(n)
+	}
+	return nil
+}
+
+// Selection returns the selection of the selector expression from TypesInfo, or nil if it isn't a
+// field or method selector. Qualified identifiers are decorated to an Ident, so a SelectorExpr is
+// never a qualified identifier.
+func (p *Package) Selection(s *dst.SelectorExpr) *types.Selection {
+	if p.TypesInfo == nil {
+		return nil
+	}
+	n, ok := p.Map.Ast.Nodes[s].(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	return p.TypesInfo.Selections[n]
+}
+
+// Implicits returns the implicitly declared objects from TypesInfo, keyed by the dst node: the
+// ImportSpec of an import without a name, the CaseClause of a type switch that declares a variable,
+// or the Field of an anonymous function parameter.
+func (p *Package) Implicits() map[dst.Node]types.Object {
+	implicits := map[dst.Node]types.Object{}
+	if p.TypesInfo == nil {
+		return implicits
+	}
+	for n, obj := range p.TypesInfo.Implicits {
+		if dn, ok := p.Map.Dst.Nodes[n]; ok {
+			implicits[dn] = obj
+		}
+	}
+	return implicits
+}
@@ -0,0 +1,86 @@
+package decorator
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/dave/dst"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestPackage_Info(t *testing.T) {
+	code := map[string]string{
+		"a.go": `package a
+
+			import "strings"
+
+			type T struct{ b strings.Builder }
+
+			func f(t T, x interface{}) int {
+				switch y := x.(type) {
+				case int:
+					return y
+				}
+				return t.b.Len()
+			}
+		`,
+		"go.mod": "module root\n\ngo 1.14",
+	}
+	dir, err := tempDir(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkgs, err := Load(&packages.Config{Mode: packages.LoadSyntax, Dir: dir}, "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := pkgs[0]
+	file := pkg.Syntax[0]
+
+	check := func(t *testing.T) {
+		t.Helper()
+		field := file.Decls[1].(*dst.GenDecl).Specs[0].(*dst.TypeSpec).Type.(*dst.StructType).Fields.List[0]
+		builder := field.Type.(*dst.Ident)
+		if builder.Path != "strings" {
+			t.Fatal("expected qualified identifier")
+		}
+		if s := pkg.TypeOf(builder).String(); s != "strings.Builder" {
+			t.Fatalf("expected strings.Builder, found %s", s)
+		}
+		if obj := pkg.ObjectOf(builder); obj == nil || obj.Pkg().Path() != "strings" || obj.Name() != "Builder" {
+			t.Fatalf("expected strings.Builder object, found %v", obj)
+		}
+
+		fn := file.Decls[2].(*dst.FuncDecl)
+		if obj := pkg.ObjectOf(fn.Name); obj == nil || obj.Name() != "f" {
+			t.Fatalf("expected f object, found %v", obj)
+		}
+		ret := fn.Body.List[1].(*dst.ReturnStmt).Results[0].(*dst.CallExpr)
+		sel := ret.Fun.(*dst.SelectorExpr)
+		if s := pkg.Selection(sel); s == nil || s.Kind() != types.MethodVal || s.Obj().Name() != "Len" {
+			t.Fatalf("expected Len method selection, found %v", s)
+		}
+		if s := pkg.Selection(sel.X.(*dst.SelectorExpr)); s == nil || s.Kind() != types.FieldVal {
+			t.Fatalf("expected field selection, found %v", s)
+		}
+		if typ := pkg.TypeOf(ret); typ == nil || typ.String() != "int" {
+			t.Fatalf("expected int, found %v", typ)
+		}
+
+		clause := fn.Body.List[0].(*dst.TypeSwitchStmt).Body.List[0]
+		implicits := pkg.Implicits()
+		if obj := implicits[clause]; obj == nil || obj.Name() != "y" || obj.Type().String() != "int" {
+			t.Fatalf("expected implicit y int, found %v", obj)
+		}
+		if obj := implicits[file.Imports[0]]; obj == nil || obj.Name() != "strings" {
+			t.Fatalf("expected implicit strings package name, found %v", obj)
+		}
+	}
+
+	t.Run("load", check)
+
+	if err := pkg.Check(); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("check", check)
+}
@@ -3,6 +3,9 @@ package decorator
 import (
 	"bytes"
 	"errors"
+	"go/ast"
+	"go/token"
+	"go/types"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -36,8 +39,13 @@ func Load(cfg *packages.Config, patterns ...string) ([]*Package, error) {
 			return dp, nil
 		}
 		p := &Package{
-			Package: pkg,
-			Imports: map[string]*Package{},
+			Package:     pkg,
+			Imports:     map[string]*Package{},
+			Fset:        pkg.Fset,
+			Types:       pkg.Types,
+			TypesInfo:   pkg.TypesInfo,
+			TypeErrors:  pkg.TypeErrors,
+			TypesSyntax: pkg.Syntax,
 		}
 		dpkgs[pkg] = p
 		if len(pkg.Syntax) > 0 {
@@ -50,6 +58,7 @@ func Load(cfg *packages.Config, patterns ...string) ([]*Package, error) {
 			}
 
 			p.Decorator = NewDecoratorFromPackage(pkg)
+			p.Map = p.Decorator.Map
 			for _, f := range pkg.Syntax {
 				fpath := pkg.Fset.File(f.Pos()).Name()
 				if !goFiles[fpath] {
@@ -94,6 +103,22 @@ type Package struct {
 	Decorator *Decorator
 	Imports   map[string]*Package
 	Syntax    []*dst.File
+
+	// Fset, Types, TypesInfo and TypeErrors hide the fields of packages.Package, so Check can
+	// replace them without modifying the packages.Package. After Load they are copied from the
+	// packages.Package, and after Check they are the results of type checking the restored files.
+	Fset       *token.FileSet
+	Types      *types.Package
+	TypesInfo  *types.Info
+	TypeErrors []types.Error
+
+	// TypesSyntax holds the ast files that TypesInfo refers to. After Load these are the files in
+	// Package.Syntax, and after Check they are the restored files.
+	TypesSyntax []*ast.File
+
+	// Map holds the mapping between the dst nodes in Syntax and the ast nodes in TypesSyntax. After
+	// Load this is the Decorator mapping, and after Check it is the mapping to the restored files.
+	Map Map
 }
 
 func (p *Package) Save() error {
@@ -1,6 +1,8 @@
 package decorator
 
 import (
+	"go/ast"
+	"go/token"
 	"testing"
 
 	"github.com/dave/dst"
@@ -103,3 +105,70 @@ func TestPackage_SaveWithResolver(t *testing.T) {
 	}
 	compareDir(t, dir, expect)
 }
+
+func TestPackage_Check(t *testing.T) {
+	code := map[string]string{
+		"a.go": `package a
+
+			import "fmt"
+
+			var a = 1
+
+			func f() {
+				fmt.Println(a)
+			}
+		`,
+		"go.mod": "module root\n\ngo 1.14",
+	}
+	dir, err := tempDir(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkgs, err := Load(&packages.Config{Mode: packages.LoadSyntax, Dir: dir}, "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := pkgs[0]
+	file := pkg.Syntax[0]
+	spec := file.Decls[1].(*dst.GenDecl).Specs[0].(*dst.ValueSpec)
+	typeOf := func(e dst.Expr) string {
+		t.Helper()
+		return pkg.TypesInfo.TypeOf(pkg.Map.Ast.Nodes[e].(ast.Expr)).String()
+	}
+	if typeOf(spec.Values[0]) != "int" {
+		t.Fatal("expected int before modification")
+	}
+
+	// change the value to a string, and add a statement that uses a package that wasn't imported
+	lit := &dst.BasicLit{Kind: token.STRING, Value: `"a"`}
+	spec.Values[0] = lit
+	body := file.Decls[2].(*dst.FuncDecl).Body
+	call := &dst.CallExpr{Fun: &dst.Ident{Name: "Sprint", Path: "fmt"}, Args: []dst.Expr{dst.NewIdent("a")}}
+	body.List = append(body.List, &dst.AssignStmt{Lhs: []dst.Expr{dst.NewIdent("_")}, Tok: token.ASSIGN, Rhs: []dst.Expr{call}})
+	if err := pkg.Check(); err != nil {
+		t.Fatal(err)
+	}
+	if typeOf(lit) != "string" {
+		t.Fatalf("expected string, found %s", typeOf(lit))
+	}
+	if typeOf(call) != "string" {
+		t.Fatalf("expected string, found %s", typeOf(call))
+	}
+	if obj := pkg.Types.Scope().Lookup("a"); obj == nil || obj.Type().String() != "string" {
+		t.Fatal("expected a to be a string")
+	}
+
+	// the packages.Package still has the results of the load
+	if pkg.Package.Syntax[0] == pkg.TypesSyntax[0] || pkg.Package.Fset == pkg.Fset || pkg.Package.TypesInfo == pkg.TypesInfo {
+		t.Fatal("expected the packages.Package to be unchanged")
+	}
+	if obj := pkg.Package.Types.Scope().Lookup("a"); obj == nil || obj.Type().String() != "int" {
+		t.Fatal("expected a to be an int in the packages.Package")
+	}
+
+	// type errors are returned and stored in TypeErrors
+	spec.Values[0] = &dst.CallExpr{Fun: dst.NewIdent("undefined")}
+	if err := pkg.Check(); err == nil || len(pkg.TypeErrors) == 0 {
+		t.Fatal("expected type error")
+	}
+}
@@ -0,0 +1,67 @@
+package decorator
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/dstutil"
+)
+
+// ErrSyntheticNode is returned when position information is requested for a node that has no
+// counterpart in the original source - e.g. a node that was created or cloned after decoration.
+var ErrSyntheticNode = errors.New("synthetic node has no source position")
+
+// Pos returns the start and end positions in Fset of the ast node that n was decorated from. For
+// nodes that were merged during decoration (e.g. a *ast.SelectorExpr that became a *dst.Ident
+// with Path set), the range of the original merged node is returned. ok is false if n was not
+// created by this Decorator or has no valid position.
+func (d *Decorator) Pos(n dst.Node) (start, end token.Pos, ok bool) {
+	an, found := d.Ast.Nodes[n]
+	if !found || an == nil || !an.Pos().IsValid() {
+		return token.NoPos, token.NoPos, false
+	}
+	return an.Pos(), an.End(), true
+}
+
+// Position returns the start and end source positions of the ast node that n was decorated from.
+// If n was mutated after decoration the original position is still returned. ErrSyntheticNode is
+// returned if n was not created by this Decorator (e.g. it was created or cloned after decoration)
+// or has no position in the source (e.g. *dst.Package).
+func (d *Decorator) Position(n dst.Node) (start, end token.Position, err error) {
+	s, e, ok := d.Pos(n)
+	if !ok {
+		return token.Position{}, token.Position{}, ErrSyntheticNode
+	}
+	return d.Fset.Position(s), d.Fset.Position(e), nil
+}
+
+// Position returns the start and end source positions of the ast node that n was decorated from.
+// See Decorator.Position for more details.
+func (p *Package) Position(n dst.Node) (start, end token.Position, err error) {
+	if p.Decorator == nil {
+		return token.Position{}, token.Position{}, ErrSyntheticNode
+	}
+	return p.Decorator.Position(n)
+}
+
+// PathEnclosingInterval returns the node in f that encloses the interval [start, end) of byte
+// offsets in the original source of f, and all its ancestors up to f. See
+// dstutil.PathEnclosingInterval for more details.
+func (d *Decorator) PathEnclosingInterval(f *dst.File, start, end int) (path []dst.Node, exact bool, err error) {
+	af, ok := d.Ast.Nodes[f].(*ast.File)
+	if !ok {
+		return nil, false, ErrSyntheticNode
+	}
+	tf := d.Fset.File(af.Pos())
+	if tf == nil {
+		return nil, false, ErrSyntheticNode
+	}
+	if start < 0 || end < start || end > tf.Size() {
+		return nil, false, fmt.Errorf("interval [%d, %d) is outside %s", start, end, tf.Name())
+	}
+	path, exact = dstutil.PathEnclosingInterval(d, f, tf.Pos(start), tf.Pos(end))
+	return path, exact, nil
+}
@@ -0,0 +1,91 @@
+package decorator
+
+import (
+	"fmt"
+	"go/token"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator/resolver/goast"
+)
+
+func TestPosition(t *testing.T) {
+	code := `package main
+
+import "fmt"
+
+func main() {
+	var a int
+	fmt.
+		Println(a)
+}
+`
+	d := NewDecoratorWithImports(token.NewFileSet(), "main", goast.New())
+	f, err := d.ParseFile("main.go", code, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := f.Decls[1].(*dst.FuncDecl).Body
+	call := body.List[1].(*dst.ExprStmt).X.(*dst.CallExpr)
+
+	format := func(start, end token.Position) string {
+		return fmt.Sprintf("%s-%d:%d", start, end.Line, end.Column)
+	}
+
+	tests := []struct {
+		name   string
+		node   dst.Node
+		expect string
+	}{
+		{"file", f, "main.go:1:1-9:2"},
+		{"decl", body.List[0], "main.go:6:2-6:11"},
+		{"call", call, "main.go:7:2-8:13"},
+		{"merged", call.Fun, "main.go:7:2-8:10"},
+		{"arg", call.Args[0], "main.go:8:11-8:12"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start, end, err := d.Position(test.node)
+			if err != nil {
+				t.Fatal(err)
+			}
+			compare(t, test.expect, format(start, end))
+		})
+	}
+
+	t.Run("merged-path", func(t *testing.T) {
+		compare(t, "fmt", call.Fun.(*dst.Ident).Path)
+	})
+
+	t.Run("synthetic", func(t *testing.T) {
+		if _, _, err := d.Position(dst.NewIdent("b")); err != ErrSyntheticNode {
+			t.Fatalf("expected ErrSyntheticNode, got %v", err)
+		}
+		if _, _, err := d.Position(dst.Clone(call)); err != ErrSyntheticNode {
+			t.Fatalf("expected ErrSyntheticNode for cloned node, got %v", err)
+		}
+		if _, _, ok := d.Pos(call); !ok {
+			t.Fatal("expected original node to have a position")
+		}
+	})
+
+	t.Run("mutated", func(t *testing.T) {
+		call.Args = append(call.Args, dst.NewIdent("b"))
+		start, end, err := d.Position(call)
+		if err != nil {
+			t.Fatal(err)
+		}
+		compare(t, "main.go:7:2-8:13", format(start, end))
+	})
+
+	t.Run("package", func(t *testing.T) {
+		p := &Package{Decorator: d}
+		if _, _, err := p.Position(body); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := (&Package{}).Position(body); err != ErrSyntheticNode {
+			t.Fatalf("expected ErrSyntheticNode, got %v", err)
+		}
+	})
+
+}
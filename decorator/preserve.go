@@ -0,0 +1,194 @@
+package decorator
+
+import (
+	"bytes"
+	"go/ast"
+	"os"
+
+	"github.com/dave/dst"
+)
+
+// preserve replaces the unmodified declarations in the formatted output with the original source.
+// Declarations are unmodified if they are identical to a fresh decoration of the original ast, and
+// all the remote identifiers they contain are restored with the same package name. The space
+// between two unmodified declarations that were adjacent in the original file is also preserved.
+func (r *FileRestorer) preserve(af *ast.File, formatted []byte) ([]byte, error) {
+
+	d := r.Preserve
+
+	of, ok := d.Ast.Nodes[r.file].(*ast.File)
+	if !ok {
+		return formatted, nil
+	}
+	src := d.source(of)
+	if src == nil {
+		return formatted, nil
+	}
+	tf := d.Fset.File(of.Pos())
+
+	original, err := d.original(of)
+	if err != nil {
+		return formatted, nil
+	}
+
+	sm, err := r.sourceMap(af, formatted)
+	if err != nil {
+		return nil, err
+	}
+
+	// unmodified returns the index of the original declaration if decl is unmodified
+	unmodified := func(decl dst.Decl) (int, bool) {
+		an, ok := d.Ast.Nodes[decl]
+		if !ok {
+			return 0, false
+		}
+		for i, od := range of.Decls {
+			if od != an {
+				continue
+			}
+			return i, dst.Equal(decl, original.Decls[i], dst.EqualOptions{IgnoreObjects: true}) && r.unmodifiedNames(decl)
+		}
+		return 0, false
+	}
+
+	// header is true if the package clause and the comments before it are unmodified. footer is true
+	// if the comments at the end of the file are unmodified.
+	header := dst.Equal(r.file.Name, original.Name, dst.EqualOptions{IgnoreObjects: true}) &&
+		r.file.Decs.Before == original.Decs.Before &&
+		equalDecorations(r.file.Decs.Start, original.Decs.Start) &&
+		equalDecorations(r.file.Decs.Package, original.Decs.Package) &&
+		equalDecorations(r.file.Decs.Name, original.Decs.Name)
+	footer := equalDecorations(r.file.Decs.End, original.Decs.End) && r.file.Decs.After == original.Decs.After
+
+	buf := &bytes.Buffer{}
+	cursor := 0        // offset in formatted
+	previous := -1     // index of the previous declaration in the original file
+	preserved := false // true if the previous declaration was preserved
+
+	if span, ok := sm[r.file.Name]; ok && header {
+		buf.Write(src[:tf.Offset(of.Name.End())])
+		cursor = span.End.Offset
+	}
+	for i, decl := range r.file.Decls {
+		span, ok := sm[decl]
+		if !ok {
+			return formatted, nil
+		}
+		index, same := unmodified(decl)
+		if !same {
+			buf.Write(formatted[cursor:span.End.Offset])
+			cursor = span.End.Offset
+			preserved = false
+			continue
+		}
+
+		start, end := tf.Offset(of.Decls[index].Pos()), tf.Offset(of.Decls[index].End())
+		switch {
+		case i == 0 && index == 0 && header:
+			buf.Write(src[tf.Offset(of.Name.End()):start])
+		case i > 0 && preserved && index == previous+1:
+			buf.Write(src[tf.Offset(of.Decls[previous].End()):start])
+		default:
+			buf.Write(formatted[cursor:span.Start.Offset])
+		}
+		buf.Write(src[start:end])
+
+		cursor = span.End.Offset
+		previous = index
+		preserved = true
+	}
+
+	switch {
+	case len(r.file.Decls) == 0 && len(of.Decls) == 0 && header && footer:
+		buf.Write(src[tf.Offset(of.Name.End()):])
+	case len(r.file.Decls) > 0 && preserved && previous == len(of.Decls)-1 && footer:
+		buf.Write(src[tf.Offset(of.Decls[previous].End()):])
+	default:
+		buf.Write(formatted[cursor:])
+	}
+
+	return buf.Bytes(), nil
+}
+
+// unmodifiedNames returns true if all the remote identifiers in n will be restored with the same
+// package name as in the original source.
+func (r *FileRestorer) unmodifiedNames(n dst.Node) bool {
+	ok := true
+	dst.Inspect(n, func(n dst.Node) bool {
+		id, isIdent := n.(*dst.Ident)
+		if !ok || !isIdent || id.Path == "" {
+			return ok
+		}
+		var name, original string
+		if id.Path != r.Path && r.packageNames[id.Path] != "." {
+			name = r.packageNames[id.Path]
+		}
+		if se, isSelector := r.Preserve.Ast.Nodes[id].(*ast.SelectorExpr); isSelector {
+			if x, isIdent := se.X.(*ast.Ident); isIdent {
+				original = x.Name
+			}
+		}
+		ok = name == original
+		return ok
+	})
+	return ok
+}
+
+// source returns the original source of f, or nil if it is not available. Files parsed with
+// ParseFile use the source provided. Other files are read from disk, and are only used if the size
+// matches the file in Fset.
+func (d *Decorator) source(f *ast.File) []byte {
+	tf := d.Fset.File(f.Pos())
+	if tf == nil {
+		return nil
+	}
+	src, ok := d.sources[f]
+	if !ok {
+		var err error
+		if src, err = os.ReadFile(tf.Name()); err != nil {
+			return nil
+		}
+	}
+	if len(src) != tf.Size() {
+		return nil
+	}
+	return src
+}
+
+// original returns an unmodified decoration of f for comparison with the restored file. It is
+// decorated the first time it is needed and reused after that.
+func (d *Decorator) original(f *ast.File) (*dst.File, error) {
+	if file, ok := d.originals[f]; ok {
+		return file, nil
+	}
+	fresh := &Decorator{
+		Map:              newMap(),
+		Filenames:        map[*dst.File]string{},
+		Fset:             d.Fset,
+		Resolver:         d.Resolver,
+		Path:             d.Path,
+		ResolveLocalPath: d.ResolveLocalPath,
+	}
+	file, err := fresh.DecorateFile(f)
+	if err != nil {
+		return nil, err
+	}
+	if d.originals == nil {
+		d.originals = map[*ast.File]*dst.File{}
+	}
+	d.originals[f] = file
+	return file, nil
+}
+
+// equalDecorations returns true if a and b contain the same decorations.
+func equalDecorations(a, b dst.Decorations) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
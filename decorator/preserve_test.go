@@ -0,0 +1,224 @@
+package decorator
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator/resolver/goast"
+	"github.com/dave/dst/decorator/resolver/guess"
+)
+
+func TestPreserve(t *testing.T) {
+	code := `// Package main is not gofmt'ed
+package  main
+
+import "fmt"
+
+func a( ) {
+	fmt.Println( "a" )
+}
+var b  =  1 // b
+
+
+func c() {   }
+// end
+`
+	tests := []struct {
+		skip, solo bool
+		name       string
+		mutate     func(f *dst.File)
+		restorer   func(r *FileRestorer)
+		expect     string
+	}{
+		{
+			name:   "unmodified",
+			expect: code,
+		},
+		{
+			name: "modified-decl",
+			mutate: func(f *dst.File) {
+				f.Decls[2].(*dst.GenDecl).Specs[0].(*dst.ValueSpec).Values[0].(*dst.BasicLit).Value = "2"
+			},
+			expect: `// Package main is not gofmt'ed
+package  main
+
+import "fmt"
+
+func a( ) {
+	fmt.Println( "a" )
+}
+
+var b = 2 // b
+func c() {   }
+// end
+`,
+		},
+		{
+			name: "modified-func-type-decoration",
+			mutate: func(f *dst.File) {
+				f.Decls[3].(*dst.FuncDecl).Type.Decs.Params.Append("/* c */")
+			},
+			expect: `// Package main is not gofmt'ed
+package  main
+
+import "fmt"
+
+func a( ) {
+	fmt.Println( "a" )
+}
+var b  =  1 // b
+func c()/* c */ {}
+
+// end
+`,
+		},
+		{
+			name: "modified-header",
+			mutate: func(f *dst.File) {
+				f.Name.Name = "other"
+			},
+			expect: `// Package main is not gofmt'ed
+package other
+
+import "fmt"
+
+func a( ) {
+	fmt.Println( "a" )
+}
+var b  =  1 // b
+
+
+func c() {   }
+// end
+`,
+		},
+		{
+			name: "added-decl",
+			mutate: func(f *dst.File) {
+				f.Decls = append(f.Decls, &dst.FuncDecl{
+					Name: dst.NewIdent("d"),
+					Type: &dst.FuncType{},
+					Body: &dst.BlockStmt{List: []dst.Stmt{
+						&dst.ExprStmt{X: &dst.CallExpr{Fun: &dst.Ident{Path: "strings", Name: "ToUpper"}, Args: []dst.Expr{&dst.BasicLit{Kind: token.STRING, Value: `"d"`}}}},
+					}},
+				})
+			},
+			expect: `// Package main is not gofmt'ed
+package  main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func a( ) {
+	fmt.Println( "a" )
+}
+var b  =  1 // b
+
+
+func c() {   }
+
+// end
+func d() { strings.ToUpper("d") }
+`,
+		},
+		{
+			name: "renamed-import",
+			restorer: func(r *FileRestorer) {
+				r.Alias["fmt"] = "fmt1"
+			},
+			expect: `// Package main is not gofmt'ed
+package  main
+
+import fmt1 "fmt"
+
+func a() {
+	fmt1.Println("a")
+}
+
+var b  =  1 // b
+
+
+func c() {   }
+// end
+`,
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			if test.skip {
+				t.Skip()
+			}
+			d := NewDecoratorWithImports(token.NewFileSet(), "main", goast.New())
+			d.KeepSource = true
+			f, err := d.ParseFile("main.go", code, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.mutate != nil {
+				test.mutate(f)
+			}
+			fprint := func() string {
+				r := NewRestorerWithImports("main", guess.New()).FileRestorer()
+				r.Preserve = d
+				if test.restorer != nil {
+					test.restorer(r)
+				}
+				buf := &bytes.Buffer{}
+				if err := r.Fprint(buf, f); err != nil {
+					t.Fatal(err)
+				}
+				return buf.String()
+			}
+			compare(t, test.expect, fprint())
+
+			// the original is decorated once and reused by later calls
+			of := d.Ast.Nodes[f].(*ast.File)
+			original := d.originals[of]
+			compare(t, test.expect, fprint())
+			if len(d.originals) != 1 || d.originals[of] != original {
+				t.Error("expected the original decoration to be reused")
+			}
+		})
+	}
+}
+
+func TestPreserveKeepSource(t *testing.T) {
+	code := "package  main\n\nvar a  =  1\n"
+	for _, keep := range []bool{false, true} {
+		d := NewDecorator(token.NewFileSet())
+		d.KeepSource = keep
+		f, err := d.ParseFile("main.go", code, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found := len(d.sources) > 0; found != keep {
+			t.Errorf("keep %v: expected source kept %v, found %v", keep, keep, found)
+		}
+		r := NewRestorer().FileRestorer()
+		r.Preserve = d
+		buf := &bytes.Buffer{}
+		if err := r.Fprint(buf, f); err != nil {
+			t.Fatal(err)
+		}
+		expect := "package main\n\nvar a = 1\n"
+		if keep {
+			expect = code
+		}
+		compare(t, expect, buf.String())
+	}
+}
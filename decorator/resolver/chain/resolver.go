@@ -0,0 +1,256 @@
+package chain
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dave/dst/decorator/resolver"
+	"github.com/dave/dst/decorator/resolver/gomod"
+	"github.com/dave/dst/decorator/resolver/gopackages"
+	"github.com/dave/dst/decorator/resolver/guess"
+	"github.com/dave/dst/decorator/resolver/simple"
+)
+
+func New(resolvers ...resolver.RestorerResolver) *RestorerResolver {
+	return &RestorerResolver{Resolvers: resolvers}
+}
+
+// Default returns a RestorerResolver that tries the hints map, then the go.mod aware gomod
+// resolver, then the gopackages resolver, and finally guesses the name from the path.
+func Default(dir string, hints map[string]string) *RestorerResolver {
+	return New(
+		simple.New(hints),
+		gomod.New(dir),
+		gopackages.New(dir),
+		guess.New(),
+	)
+}
+
+// RestorerResolver tries each resolver in turn until one resolves the package. It records which
+// resolver answered each path, and the most recent failures of the resolvers that didn't (see
+// MaxFailures). It is safe for concurrent use if the resolvers are.
+type RestorerResolver struct {
+	Resolvers []resolver.RestorerResolver
+
+	m        sync.Mutex
+	answered map[string]resolver.RestorerResolver
+	failures []Failure
+}
+
+// MaxFailures is the number of failures kept by a RestorerResolver or DecoratorResolver. When more
+// failures are recorded, the oldest are discarded.
+const MaxFailures = 1000
+
+// Failure records an error returned by a resolver in the chain.
+type Failure struct {
+	Path     string      // Package path or identifier name that failed to resolve
+	Resolver interface{} // The RestorerResolver or DecoratorResolver that failed
+	Err      error
+}
+
+func (f Failure) Error() string {
+	return fmt.Sprintf("%T resolving %s: %v", f.Resolver, f.Path, f.Err)
+}
+
+func (f Failure) Unwrap() error {
+	return f.Err
+}
+
+// Error is returned when none of the resolvers in the chain succeed. It matches
+// resolver.ErrPackageNotFound with errors.Is if any of the failures do.
+type Error struct {
+	Path     string
+	Failures []Failure
+}
+
+func (e *Error) Error() string {
+	if len(e.Failures) == 0 {
+		return fmt.Sprintf("no resolvers for %s", e.Path)
+	}
+	var messages []string
+	for _, f := range e.Failures {
+		messages = append(messages, f.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *Error) Unwrap() []error {
+	var errs []error
+	for _, f := range e.Failures {
+		errs = append(errs, f)
+	}
+	return errs
+}
+
+func (r *RestorerResolver) ResolvePackage(path string) (string, error) {
+	var failures []Failure
+	for _, res := range r.Resolvers {
+		name, err := res.ResolvePackage(path)
+		if err != nil {
+			failures = append(failures, Failure{Path: path, Resolver: res, Err: err})
+			continue
+		}
+		r.record(failures, map[string]resolver.RestorerResolver{path: res})
+		return name, nil
+	}
+	r.record(failures, nil)
+	return "", &Error{Path: path, Failures: failures}
+}
+
+// ResolvePackages resolves several package paths. Resolvers that implement
+// resolver.BatchRestorerResolver are given all the paths that are still unresolved in a single
+// call. If the batch fails, its error is recorded as a failure of each path, and each path is tried
+// individually.
+func (r *RestorerResolver) ResolvePackages(paths []string) (map[string]string, error) {
+	names := map[string]string{}
+	answered := map[string]resolver.RestorerResolver{}
+	var failures []Failure
+
+	remaining := append([]string(nil), paths...)
+	for _, res := range r.Resolvers {
+		if len(remaining) == 0 {
+			break
+		}
+		if br, ok := res.(resolver.BatchRestorerResolver); ok && len(remaining) > 1 {
+			resolved, err := br.ResolvePackages(remaining)
+			if err == nil {
+				for _, path := range remaining {
+					names[path] = resolved[path]
+					answered[path] = res
+				}
+				remaining = nil
+				continue
+			}
+			for _, path := range remaining {
+				failures = append(failures, Failure{Path: path, Resolver: res, Err: err})
+			}
+		}
+		var unresolved []string
+		for _, path := range remaining {
+			name, err := res.ResolvePackage(path)
+			if err != nil {
+				failures = append(failures, Failure{Path: path, Resolver: res, Err: err})
+				unresolved = append(unresolved, path)
+				continue
+			}
+			names[path] = name
+			answered[path] = res
+		}
+		remaining = unresolved
+	}
+
+	r.record(failures, answered)
+
+	if len(remaining) > 0 {
+		sort.Strings(remaining)
+		path := remaining[0]
+		var pathFailures []Failure
+		for _, f := range failures {
+			if f.Path == path {
+				pathFailures = append(pathFailures, f)
+			}
+		}
+		return nil, &Error{Path: path, Failures: pathFailures}
+	}
+	return names, nil
+}
+
+// Answered returns the resolver that resolved the package path.
+func (r *RestorerResolver) Answered(path string) (resolver.RestorerResolver, bool) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	res, ok := r.answered[path]
+	return res, ok
+}
+
+// Failures returns the errors returned by the resolvers in the chain, including those that were
+// followed by a resolver that succeeded. Only the most recent MaxFailures are kept.
+func (r *RestorerResolver) Failures() []Failure {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return append([]Failure(nil), r.failures...)
+}
+
+// Reset discards the recorded answers and failures.
+func (r *RestorerResolver) Reset() {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.answered = nil
+	r.failures = nil
+}
+
+func (r *RestorerResolver) record(failures []Failure, answered map[string]resolver.RestorerResolver) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.failures = appendFailures(r.failures, failures)
+	if r.answered == nil {
+		r.answered = map[string]resolver.RestorerResolver{}
+	}
+	for path, res := range answered {
+		r.answered[path] = res
+	}
+}
+
+func NewDecoratorResolver(resolvers ...resolver.DecoratorResolver) *DecoratorResolver {
+	return &DecoratorResolver{Resolvers: resolvers}
+}
+
+// DecoratorResolver tries each resolver in turn until one resolves the identifier without an
+// error. Note that an empty path is a valid answer (the identifier is local or not a reference), so
+// the next resolver is only tried when a resolver returns an error. The failures of the resolvers
+// are recorded (see MaxFailures).
+type DecoratorResolver struct {
+	Resolvers []resolver.DecoratorResolver
+
+	m        sync.Mutex
+	failures []Failure
+}
+
+func (r *DecoratorResolver) ResolveIdent(file *ast.File, parent ast.Node, parentField string, id *ast.Ident) (string, error) {
+	var failures []Failure
+	defer func() {
+		if len(failures) == 0 {
+			return
+		}
+		r.m.Lock()
+		defer r.m.Unlock()
+		r.failures = appendFailures(r.failures, failures)
+	}()
+	for _, res := range r.Resolvers {
+		path, err := res.ResolveIdent(file, parent, parentField, id)
+		if err != nil {
+			failures = append(failures, Failure{Path: id.Name, Resolver: res, Err: err})
+			continue
+		}
+		return path, nil
+	}
+	return "", &Error{Path: id.Name, Failures: failures}
+}
+
+// Failures returns the errors returned by the resolvers in the chain, including those that were
+// followed by a resolver that succeeded. Only the most recent MaxFailures are kept.
+func (r *DecoratorResolver) Failures() []Failure {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return append([]Failure(nil), r.failures...)
+}
+
+// Reset discards the recorded failures.
+func (r *DecoratorResolver) Reset() {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.failures = nil
+}
+
+// appendFailures appends failures to recorded, discarding the oldest so at most MaxFailures are
+// kept.
+func appendFailures(recorded, failures []Failure) []Failure {
+	recorded = append(recorded, failures...)
+	if len(recorded) > MaxFailures {
+		recorded = append([]Failure(nil), recorded[len(recorded)-MaxFailures:]...)
+	}
+	return recorded
+}
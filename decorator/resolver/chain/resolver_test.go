@@ -0,0 +1,203 @@
+package chain_test
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/decorator/resolver"
+	"github.com/dave/dst/decorator/resolver/chain"
+	"github.com/dave/dst/decorator/resolver/goast"
+	"github.com/dave/dst/decorator/resolver/guess"
+	"github.com/dave/dst/decorator/resolver/simple"
+)
+
+type failing struct{ err error }
+
+func (f failing) ResolvePackage(path string) (string, error) {
+	return "", f.err
+}
+
+func (f failing) ResolveIdent(file *ast.File, parent ast.Node, parentField string, id *ast.Ident) (string, error) {
+	return "", f.err
+}
+
+// batch resolves the paths in the map in a single call
+type batch struct {
+	names map[string]string
+	calls int
+}
+
+func (b *batch) ResolvePackage(path string) (string, error) {
+	names, err := b.ResolvePackages([]string{path})
+	return names[path], err
+}
+
+func (b *batch) ResolvePackages(paths []string) (map[string]string, error) {
+	b.calls++
+	out := map[string]string{}
+	for _, path := range paths {
+		name, ok := b.names[path]
+		if !ok {
+			return nil, resolver.ErrPackageNotFound
+		}
+		out[path] = name
+	}
+	return out, nil
+}
+
+func TestRestorerResolver(t *testing.T) {
+	hints := simple.New(map[string]string{"a.com/go-a": "a"})
+	broken := failing{errors.New("broken")}
+	r := chain.New(hints, broken, guess.New())
+
+	tests := []struct {
+		path, expect string
+	}{
+		{"a.com/go-a", "a"},
+		{"b.com/b", "b"},
+	}
+	for _, test := range tests {
+		name, err := r.ResolvePackage(test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != test.expect {
+			t.Errorf("%s: expect %q, found %q", test.path, test.expect, name)
+		}
+		answered, ok := r.Answered(test.path)
+		if !ok {
+			t.Fatalf("%s: not answered", test.path)
+		}
+		if isGuess(answered) != (test.path == "b.com/b") {
+			t.Errorf("%s: unexpected resolver %T", test.path, answered)
+		}
+	}
+
+	failures := r.Failures()
+	if len(failures) != 2 {
+		t.Fatalf("expect 2 failures, found %d: %v", len(failures), failures)
+	}
+	if failures[0].Path != "b.com/b" || !errors.Is(failures[0], resolver.ErrPackageNotFound) {
+		t.Errorf("unexpected failure %v", failures[0])
+	}
+	if failures[1].Path != "b.com/b" || failures[1].Err != broken.err {
+		t.Errorf("unexpected failure %v", failures[1])
+	}
+
+	// when all resolvers fail, the error contains all the failures
+	_, err := chain.New(hints, broken).ResolvePackage("c.com/c")
+	var chainErr *chain.Error
+	if !errors.As(err, &chainErr) || len(chainErr.Failures) != 2 {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !errors.Is(err, resolver.ErrPackageNotFound) {
+		t.Errorf("expected error to match ErrPackageNotFound")
+	}
+}
+
+func isGuess(r resolver.RestorerResolver) bool {
+	_, ok := r.(guess.RestorerResolver)
+	return ok
+}
+
+func TestRestorerResolverBatch(t *testing.T) {
+	b := &batch{names: map[string]string{"a.com/a": "a", "b.com/b": "b"}}
+	r := chain.New(b, guess.New())
+
+	names, err := r.ResolvePackages([]string{"a.com/a", "b.com/b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names["a.com/a"] != "a" || names["b.com/b"] != "b" || b.calls != 1 {
+		t.Fatalf("unexpected result %v with %d calls", names, b.calls)
+	}
+
+	// if the batch fails, each path is tried individually
+	names, err = r.ResolvePackages([]string{"a.com/a", "c.com/go-c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names["a.com/a"] != "a" || names["c.com/go-c"] != "go-c" {
+		t.Fatalf("unexpected result %v", names)
+	}
+	if answered, _ := r.Answered("c.com/go-c"); !isGuess(answered) {
+		t.Errorf("unexpected resolver %T", answered)
+	}
+
+	// the error of the batch is recorded for each path
+	var batchFailures int
+	for _, f := range r.Failures() {
+		if f.Resolver == b && errors.Is(f, resolver.ErrPackageNotFound) {
+			batchFailures++
+		}
+	}
+	if batchFailures != 3 {
+		t.Errorf("expect 3 batch failures, found %d: %v", batchFailures, r.Failures())
+	}
+
+	r.Reset()
+	if len(r.Failures()) != 0 {
+		t.Errorf("unexpected failures after Reset: %v", r.Failures())
+	}
+	if _, ok := r.Answered("a.com/a"); ok {
+		t.Errorf("unexpected answer after Reset")
+	}
+}
+
+func TestRestorerResolverMaxFailures(t *testing.T) {
+	r := chain.New(failing{errors.New("broken")}, guess.New())
+	for i := 0; i < chain.MaxFailures+10; i++ {
+		if _, err := r.ResolvePackage(fmt.Sprintf("a.com/a%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	failures := r.Failures()
+	if len(failures) != chain.MaxFailures {
+		t.Fatalf("expect %d failures, found %d", chain.MaxFailures, len(failures))
+	}
+	if last := fmt.Sprintf("a.com/a%d", chain.MaxFailures+9); failures[len(failures)-1].Path != last {
+		t.Errorf("expect the most recent failure to be %s, found %s", last, failures[len(failures)-1].Path)
+	}
+}
+
+func TestDecoratorResolver(t *testing.T) {
+	src := `package main
+
+		import . "fmt"
+
+		func main() {
+			Println()
+		}`
+
+	// goast fails on dot-imports, so the next resolver is used
+	d := decorator.NewDecoratorWithImports(token.NewFileSet(), "main", chain.NewDecoratorResolver(goast.New(), failing{errors.New("broken")}))
+	if _, err := d.Parse(src); err == nil {
+		t.Fatal("expected error")
+	}
+
+	r := chain.NewDecoratorResolver(goast.New(), simpleDecoratorResolver{"Println": "fmt"})
+	d = decorator.NewDecoratorWithImports(token.NewFileSet(), "main", r)
+	f, err := d.Parse(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := f.Decls[1].(*dst.FuncDecl).Body.List[0].(*dst.ExprStmt).X.(*dst.CallExpr)
+	if path := call.Fun.(*dst.Ident).Path; path != "fmt" {
+		t.Errorf("expect %q, found %q", "fmt", path)
+	}
+	if len(r.Failures()) == 0 {
+		t.Errorf("expected goast failures to be recorded")
+	}
+}
+
+// simpleDecoratorResolver resolves idents by name
+type simpleDecoratorResolver map[string]string
+
+func (s simpleDecoratorResolver) ResolveIdent(file *ast.File, parent ast.Node, parentField string, id *ast.Ident) (string, error) {
+	return s[id.Name], nil
+}
@@ -22,8 +22,8 @@ func WithResolver(resolver resolver.RestorerResolver) *DecoratorResolver {
 // DecoratorResolver is a simple ident resolver that parses the imports block of the file and resolves
 // qualified identifiers using resolved package names. It is not possible to resolve identifiers in
 // dot-imported packages without the full export data of the imported package, so this resolver will
-// return an error if it encounters a dot-import. See gotypes.DecoratorResolver for a dot-imports
-// capable ident resolver.
+// return an error if it encounters a dot-import. See gotypes.DecoratorResolver and
+// goimporter.DecoratorResolver for dot-imports capable ident resolvers.
 type DecoratorResolver struct {
 	RestorerResolver resolver.RestorerResolver
 	filesM           sync.Mutex
@@ -0,0 +1,177 @@
+package goimporter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/types"
+	"strconv"
+	"sync"
+
+	"github.com/dave/dst/decorator/resolver"
+)
+
+func New() *DecoratorResolver {
+	return &DecoratorResolver{}
+}
+
+func WithImporter(importer types.Importer) *DecoratorResolver {
+	return &DecoratorResolver{Importer: importer}
+}
+
+// DecoratorResolver is an ident resolver that parses the imports block of the file, and loads the
+// export data of the imported packages with a types.Importer. Unlike goast.DecoratorResolver it
+// supports dot-imports: an unqualified identifier that isn't declared in the file is resolved to a
+// dot-imported package if it is declared in the scope of that package.
+//
+// The file must be parsed with object resolution enabled (so locally declared identifiers have Obj
+// set). Keys in composite literals are never resolved to dot-imported packages because without type
+// information they can't be distinguished from field names.
+type DecoratorResolver struct {
+	// Importer is used to load the export data of the imported packages. If nil,
+	// importer.Default() is used. If Importer implements types.ImporterFrom, packages are imported
+	// from Dir.
+	Importer types.Importer
+	Dir      string
+
+	// If RestorerResolver is set, it is used to resolve the names of the packages that are not
+	// dot-imported. If nil, the package name from the export data is used.
+	RestorerResolver resolver.RestorerResolver
+
+	m        sync.Mutex
+	files    map[*ast.File]*imports
+	packages map[string]*types.Package
+}
+
+// imports is the information from the imports block of a file.
+type imports struct {
+	names map[string]string // name -> path of the packages that are not dot-imported
+	dot   []*types.Package  // dot-imported packages
+}
+
+func (r *DecoratorResolver) ResolveIdent(file *ast.File, parent ast.Node, parentField string, id *ast.Ident) (string, error) {
+
+	imports, err := r.imports(file)
+	if err != nil {
+		return "", err
+	}
+
+	if se, ok := parent.(*ast.SelectorExpr); ok && parentField == "Sel" {
+
+		// if the parent is a SelectorExpr and this Ident is in the Sel field, only resolve the path
+		// if X is a package identifier
+
+		xid, ok := se.X.(*ast.Ident)
+		if !ok {
+			return "", nil
+		}
+		if xid.Obj != nil {
+			// Obj != nil -> not a qualified ident
+			return "", nil
+		}
+		return imports.names[xid.Name], nil
+	}
+
+	if _, ok := parent.(*ast.KeyValueExpr); ok && parentField == "Key" {
+		// possibly a field name in a composite literal
+		return "", nil
+	}
+
+	if id.Obj != nil {
+		// Obj != nil -> declared in this file
+		return "", nil
+	}
+
+	for _, pkg := range imports.dot {
+		if obj := pkg.Scope().Lookup(id.Name); obj != nil && obj.Exported() {
+			return pkg.Path(), nil
+		}
+	}
+
+	return "", nil
+}
+
+func (r *DecoratorResolver) imports(file *ast.File) (*imports, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if r.files == nil {
+		r.files = map[*ast.File]*imports{}
+	}
+
+	if imp, ok := r.files[file]; ok {
+		return imp, nil
+	}
+
+	imp := &imports{names: map[string]string{}}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		if path == "C" {
+			continue
+		}
+		var name string
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		switch name {
+		case "_":
+			// Don't need to worry about _ imports
+			continue
+		case ".":
+			pkg, err := r.load(path)
+			if err != nil {
+				return nil, err
+			}
+			imp.dot = append(imp.dot, pkg)
+			continue
+		case "":
+			if r.RestorerResolver != nil {
+				if name, err = r.RestorerResolver.ResolvePackage(path); err != nil {
+					return nil, err
+				}
+				break
+			}
+			pkg, err := r.load(path)
+			if err != nil {
+				return nil, err
+			}
+			name = pkg.Name()
+		}
+		if p, ok := imp.names[name]; ok {
+			return nil, fmt.Errorf("goimporter.DecoratorResolver found multiple packages using name %s: %s and %s", name, p, path)
+		}
+		imp.names[name] = path
+	}
+
+	r.files[file] = imp
+
+	return imp, nil
+}
+
+// load imports the package with the Importer. The caller must hold the lock.
+func (r *DecoratorResolver) load(path string) (*types.Package, error) {
+	if pkg, ok := r.packages[path]; ok {
+		return pkg, nil
+	}
+	if r.Importer == nil {
+		r.Importer = importer.Default()
+	}
+	var pkg *types.Package
+	var err error
+	if from, ok := r.Importer.(types.ImporterFrom); ok && r.Dir != "" {
+		pkg, err = from.ImportFrom(path, r.Dir, 0)
+	} else {
+		pkg, err = r.Importer.Import(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if r.packages == nil {
+		r.packages = map[string]*types.Package{}
+	}
+	r.packages[path] = pkg
+	return pkg, nil
+}
@@ -0,0 +1,160 @@
+package goimporter
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+)
+
+// mapImporter imports packages from a map, with exported names declared as funcs.
+type mapImporter map[string][]string
+
+func (m mapImporter) Import(path string) (*types.Package, error) {
+	names, ok := m[path]
+	if !ok {
+		return nil, fmt.Errorf("package %s not found", path)
+	}
+	pkg := types.NewPackage(path, names[0])
+	for _, name := range names[1:] {
+		pkg.Scope().Insert(types.NewFunc(token.NoPos, pkg, name, types.NewSignatureType(nil, nil, nil, nil, nil, false)))
+	}
+	pkg.MarkComplete()
+	return pkg, nil
+}
+
+func TestGoImporterDecoratorResolver(t *testing.T) {
+	importer := mapImporter{
+		"root/a":     {"a", "A"},
+		"root/b":     {"b", "B", "C", "shadowed"},
+		"root/go-cc": {"cc", "D"},
+	}
+	type tc struct{ id, expect string }
+	tests := []struct {
+		skip, solo bool
+		name       string
+		src        string
+		cases      []tc
+	}{
+		{
+			name: "qualified",
+			src: `package main
+
+				import (
+					"root/a"
+					"root/go-cc"
+				)
+
+				func main(){
+					a.A()
+					cc.D()
+				}`,
+			cases: []tc{
+				{"A", "root/a"},
+				{"D", "root/go-cc"},
+			},
+		},
+		{
+			name: "dot-import",
+			src: `package main
+
+				import (
+					. "root/b"
+				)
+
+				func main(){
+					B()
+					var C int
+					_ = C
+					_ = T{S: 1}
+					_ = len("")
+				}
+
+				type T struct{ S int }`,
+			cases: []tc{
+				{"B", "root/b"},
+				{"C", ""},
+				{"S", ""},
+				{"len", ""},
+			},
+		},
+		{
+			name: "dot-import-unexported",
+			src: `package main
+
+				import . "root/b"
+
+				func main(){
+					shadowed()
+				}`,
+			cases: []tc{
+				{"shadowed", ""},
+			},
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if solo && !test.solo {
+				t.Skip()
+			}
+			if test.skip {
+				t.Skip()
+			}
+
+			d := decorator.NewDecoratorWithImports(token.NewFileSet(), "main", WithImporter(importer))
+
+			f, err := d.Parse(test.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			nodes := map[string]string{}
+			dst.Inspect(f, func(n dst.Node) bool {
+				switch n := n.(type) {
+				case *dst.Ident:
+					nodes[n.Name] = n.Path
+				}
+				return true
+			})
+
+			for _, c := range test.cases {
+				found, ok := nodes[c.id]
+				if !ok {
+					t.Errorf("node %s not found", c.id)
+				}
+				if found != c.expect {
+					t.Errorf("%s: expect %q, found %q", c.id, c.expect, found)
+				}
+			}
+
+		})
+	}
+}
+
+func TestGoImporterDefault(t *testing.T) {
+	d := decorator.NewDecoratorWithImports(token.NewFileSet(), "main", New())
+	f, err := d.Parse(`package main
+
+		import . "strings"
+
+		func main() {
+			ToUpper("a")
+		}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := f.Decls[1].(*dst.FuncDecl).Body.List[0].(*dst.ExprStmt).X.(*dst.CallExpr)
+	if path := call.Fun.(*dst.Ident).Path; path != "strings" {
+		t.Fatalf("expect %q, found %q", "strings", path)
+	}
+}
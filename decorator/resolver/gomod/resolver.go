@@ -0,0 +1,292 @@
+package gomod
+
+import (
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dave/dst/decorator/resolver"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+func New(dir string) *RestorerResolver {
+	return &RestorerResolver{Dir: dir}
+}
+
+func WithHints(dir string, hints map[string]string) *RestorerResolver {
+	return &RestorerResolver{Dir: dir, Hints: hints}
+}
+
+// RestorerResolver resolves package names by reading go.mod, the local module tree,
+// vendor/modules.txt and the module cache directly. Only the package clauses of the source files
+// are parsed, and the go command is never invoked.
+type RestorerResolver struct {
+	// Dir is the directory the imports are resolved from. The go.mod file is found in Dir or the
+	// nearest parent directory.
+	Dir string
+
+	// GOROOT is used to locate standard library packages. If empty, build.Default.GOROOT is used.
+	GOROOT string
+
+	// GOMODCACHE is the module cache. If empty, the GOMODCACHE environment variable is used, or
+	// pkg/mod in the first GOPATH entry.
+	GOMODCACHE string
+
+	// Context is used to filter source files by build constraints. If nil, build.Default is used.
+	Context *build.Context
+
+	// Hints (package path -> name) is first checked before searching for the package
+	Hints map[string]string
+
+	m       sync.Mutex
+	modules map[string]*modInfo // parsed go.mod files by directory
+}
+
+// modInfo is the information from a go.mod file that is needed to locate packages.
+type modInfo struct {
+	dir      string
+	path     string
+	requires map[string]module.Version // module path -> version after replacements are applied
+	local    map[string]string         // module path -> directory for replacements with local directories
+	vendor   map[string]bool           // package paths listed in vendor/modules.txt
+}
+
+func (r *RestorerResolver) ResolvePackage(importPath string) (string, error) {
+
+	if name, ok := r.Hints[importPath]; ok {
+		return name, nil
+	}
+
+	dir, err := r.findDir(importPath)
+	if err != nil {
+		return "", err
+	}
+	if dir == "" {
+		return "", resolver.ErrPackageNotFound
+	}
+	return r.packageName(dir)
+}
+
+// findDir returns the directory containing the source of the package, or an empty string if it is
+// not found.
+func (r *RestorerResolver) findDir(importPath string) (string, error) {
+
+	mod, err := r.module()
+	if err != nil {
+		return "", err
+	}
+
+	if mod != nil && inModule(importPath, mod.path) {
+		return existingDir(filepath.Join(mod.dir, filepath.FromSlash(strings.TrimPrefix(importPath, mod.path)))), nil
+	}
+
+	if isStandard(importPath) {
+		goroot := r.GOROOT
+		if goroot == "" {
+			goroot = build.Default.GOROOT
+		}
+		return existingDir(filepath.Join(goroot, "src", filepath.FromSlash(importPath))), nil
+	}
+
+	if mod == nil {
+		return "", nil
+	}
+
+	if mod.vendor[importPath] {
+		return existingDir(filepath.Join(mod.dir, "vendor", filepath.FromSlash(importPath))), nil
+	}
+
+	// find the required module with the longest matching path
+	var found string
+	for path := range mod.requires {
+		if inModule(importPath, path) && len(path) > len(found) {
+			found = path
+		}
+	}
+	if found == "" {
+		return "", nil
+	}
+	rel := filepath.FromSlash(strings.TrimPrefix(importPath, found))
+
+	if dir, ok := mod.local[found]; ok {
+		return existingDir(filepath.Join(dir, rel)), nil
+	}
+
+	version := mod.requires[found]
+	escapedPath, err := module.EscapePath(version.Path)
+	if err != nil {
+		return "", err
+	}
+	escapedVersion, err := module.EscapeVersion(version.Version)
+	if err != nil {
+		return "", err
+	}
+	return existingDir(filepath.Join(r.modCache(), filepath.FromSlash(escapedPath)+"@"+escapedVersion, rel)), nil
+}
+
+// packageName parses the package clauses of the source files in dir, and returns the name of the
+// first file that matches the build constraints.
+func (r *RestorerResolver) packageName(dir string) (string, error) {
+	bc := r.Context
+	if bc == nil {
+		bc = &build.Default
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if match, err := bc.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.PackageClauseOnly)
+		if err != nil {
+			return "", err
+		}
+		if f.Name.Name == "documentation" {
+			// go/build ignores files in package documentation
+			continue
+		}
+		return f.Name.Name, nil
+	}
+	return "", resolver.ErrPackageNotFound
+}
+
+// module finds and parses the go.mod file for Dir. It returns nil if no go.mod file is found.
+func (r *RestorerResolver) module() (*modInfo, error) {
+	dir, err := filepath.Abs(r.Dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if mod, ok := r.modules[dir]; ok {
+		return mod, nil
+	}
+
+	mod, err := parseModule(dir)
+	if err != nil {
+		return nil, err
+	}
+	if r.modules == nil {
+		r.modules = map[string]*modInfo{}
+	}
+	r.modules[dir] = mod
+	return mod, nil
+}
+
+// parseModule parses the go.mod and vendor/modules.txt files in dir.
+func parseModule(dir string) (*modInfo, error) {
+	fpath := filepath.Join(dir, "go.mod")
+	data, err := os.ReadFile(fpath)
+	if err != nil {
+		return nil, err
+	}
+	mf, err := modfile.Parse(fpath, data, nil)
+	if err != nil {
+		return nil, err
+	}
+	if mf.Module == nil {
+		return nil, resolver.ErrPackageNotFound
+	}
+
+	mod := &modInfo{
+		dir:      dir,
+		path:     mf.Module.Mod.Path,
+		requires: map[string]module.Version{},
+		local:    map[string]string{},
+		vendor:   map[string]bool{},
+	}
+	for _, req := range mf.Require {
+		mod.requires[req.Mod.Path] = req.Mod
+	}
+	for _, rep := range mf.Replace {
+		if _, ok := mod.requires[rep.Old.Path]; !ok {
+			continue
+		}
+		if rep.Old.Version != "" && rep.Old.Version != mod.requires[rep.Old.Path].Version {
+			continue
+		}
+		if rep.New.Version == "" {
+			// replacement with a local directory
+			local := filepath.FromSlash(rep.New.Path)
+			if !filepath.IsAbs(local) {
+				local = filepath.Join(dir, local)
+			}
+			mod.local[rep.Old.Path] = local
+			continue
+		}
+		mod.requires[rep.Old.Path] = rep.New
+	}
+
+	// vendor/modules.txt lists the vendored packages on lines that don't start with "#"
+	if data, err := os.ReadFile(filepath.Join(dir, "vendor", "modules.txt")); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			mod.vendor[line] = true
+		}
+	}
+
+	return mod, nil
+}
+
+func (r *RestorerResolver) modCache() string {
+	if r.GOMODCACHE != "" {
+		return r.GOMODCACHE
+	}
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	gopath := filepath.SplitList(build.Default.GOPATH)
+	if len(gopath) == 0 {
+		return ""
+	}
+	return filepath.Join(gopath[0], "pkg", "mod")
+}
+
+// isStandard returns true if the first element of the path has no dot, which is the convention
+// used by the go command to identify standard library packages.
+func isStandard(importPath string) bool {
+	first := importPath
+	if i := strings.Index(importPath, "/"); i >= 0 {
+		first = importPath[:i]
+	}
+	return !strings.Contains(first, ".")
+}
+
+// inModule returns true if the package path is in the module with path modulePath.
+func inModule(importPath, modulePath string) bool {
+	return importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/")
+}
+
+func existingDir(dir string) string {
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		return ""
+	}
+	return dir
+}
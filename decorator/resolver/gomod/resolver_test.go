@@ -0,0 +1,87 @@
+package gomod_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dave/dst/decorator/resolver"
+	"github.com/dave/dst/decorator/resolver/gomod"
+)
+
+func TestRestorerResolver(t *testing.T) {
+	src := map[string]string{
+		"main/go.mod": `module example.com/main
+
+			require (
+				gopkg.in/yaml.v2 v2.4.0
+				github.com/x/go-foo v1.0.0
+				github.com/Upper/bar v1.2.0
+				example.com/replaced v1.0.0
+				example.com/local v1.0.0
+				example.com/vendored v1.0.0
+			)
+
+			replace example.com/replaced => example.com/other v1.1.0
+
+			replace example.com/local => ../local`,
+		"main/main.go":     "package main \n\n func main(){}",
+		"main/a/a.go":      "package aaa \n\n func A(){}",
+		"main/a/a_test.go": "package aaa_test",
+		"main/b/b.go":      "//go:build ignore\n\npackage ignored",
+		"main/b/c.go":      "package bbb",
+		"main/vendor/modules.txt": `# example.com/vendored v1.0.0
+			## explicit
+			example.com/vendored/pkg`,
+		"main/vendor/example.com/vendored/pkg/pkg.go": "package vendored",
+		"local/go.mod":                                  "module example.com/local",
+		"local/sub/local.go":                            "package localsub",
+		"cache/gopkg.in/yaml.v2@v2.4.0/yaml.go":         "package yaml",
+		"cache/github.com/x/go-foo@v1.0.0/foo.go":       "package foo",
+		"cache/github.com/!upper/bar@v1.2.0/bar/bar.go": "package barbar",
+		"cache/example.com/other@v1.1.0/other.go":       "package other",
+		"cache/example.com/replaced@v1.0.0/replaced.go": "package wrong",
+		"goroot/src/fmt/print.go":                       "package fmt",
+		"goroot/src/fmt/doc.go":                         "package documentation",
+	}
+	root, err := tempDir(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	r := gomod.New(filepath.Join(root, "main", "a"))
+	r.GOMODCACHE = filepath.Join(root, "cache")
+	r.GOROOT = filepath.Join(root, "goroot")
+
+	tests := []struct {
+		path, expect string
+	}{
+		{"example.com/main/a", "aaa"},
+		{"example.com/main/b", "bbb"},
+		{"example.com/main", "main"},
+		{"fmt", "fmt"},
+		{"gopkg.in/yaml.v2", "yaml"},
+		{"github.com/x/go-foo", "foo"},
+		{"github.com/Upper/bar/bar", "barbar"},
+		{"example.com/replaced", "other"},
+		{"example.com/local/sub", "localsub"},
+		{"example.com/vendored/pkg", "vendored"},
+		{"example.com/main/missing", ""},
+		{"example.com/unknown", ""},
+		{"os", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			name, err := r.ResolvePackage(test.path)
+			if err == resolver.ErrPackageNotFound {
+				name = ""
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if name != test.expect {
+				t.Errorf("expected %q, got %q", test.expect, name)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package gopackages
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/dave/dst/decorator/resolver"
 	"golang.org/x/tools/go/packages"
@@ -25,37 +28,166 @@ type RestorerResolver struct {
 
 	// Hints (package path -> name) is first checked before asking the packages package
 	Hints map[string]string
+
+	// Cache stores the resolved package names. If nil, the names are not cached. A Cache has no
+	// expiry, so it should not outlive changes to the packages it has resolved.
+	Cache *Cache
 }
 
-func (r *RestorerResolver) ResolvePackage(path string) (string, error) {
+// NewCache returns a new Cache.
+func NewCache() *Cache {
+	return &Cache{names: map[cacheKey]map[string]string{}}
+}
+
+// Cache is a concurrency-safe store of resolved package names, keyed by the fields of
+// packages.Config that affect the results: the directory, build flags, environment and overlay.
+// It can be shared by several RestorerResolvers.
+type Cache struct {
+	m     sync.Mutex
+	names map[cacheKey]map[string]string
+}
+
+type cacheKey struct {
+	dir, flags, env string
+	overlay         [sha256.Size]byte
+}
+
+func newCacheKey(config packages.Config) cacheKey {
+	var files []string
+	for file := range config.Overlay {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	h := sha256.New()
+	for _, file := range files {
+		fmt.Fprintf(h, "%q %q\n", file, config.Overlay[file])
+	}
+	key := cacheKey{
+		dir:   config.Dir,
+		flags: fmt.Sprintf("%q", config.BuildFlags),
+		env:   fmt.Sprintf("%q", config.Env),
+	}
+	copy(key.overlay[:], h.Sum(nil))
+	return key
+}
 
-	if name, ok := r.Hints[path]; ok {
-		return name, nil
+// Clear removes all the entries in the cache.
+func (c *Cache) Clear() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.names = map[cacheKey]map[string]string{}
+}
+
+func (c *Cache) get(key cacheKey, path string) (string, bool) {
+	if c == nil {
+		return "", false
 	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	name, ok := c.names[key][path]
+	return name, ok
+}
 
-	if r.Dir != "" {
-		r.Config.Dir = r.Dir
+func (c *Cache) set(key cacheKey, path, name string) {
+	if c == nil {
+		return
 	}
-	r.Config.Mode = packages.LoadTypes
-	r.Config.Tests = false
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.names[key] == nil {
+		c.names[key] = map[string]string{}
+	}
+	c.names[key][path] = name
+}
 
-	pkgs, err := packages.Load(&r.Config, "pattern="+path)
+func (r *RestorerResolver) ResolvePackage(path string) (string, error) {
+	names, err := r.ResolvePackages([]string{path})
 	if err != nil {
 		return "", err
 	}
+	return names[path], nil
+}
+
+// ResolvePackages resolves several package paths with a single call to packages.Load. If Cache is
+// set, package names that have previously been resolved with the same config are returned from the
+// cache.
+func (r *RestorerResolver) ResolvePackages(paths []string) (map[string]string, error) {
+
+	cache := r.Cache
+
+	// take a copy of the config so the RestorerResolver is safe for concurrent use
+	config := r.Config
+	if r.Dir != "" {
+		config.Dir = r.Dir
+	}
+	config.Mode = packages.LoadTypes
+	config.Tests = false
+
+	key := newCacheKey(config)
+
+	names := map[string]string{}
+	var patterns, missing []string
+	for _, path := range paths {
+		if name, ok := r.Hints[path]; ok {
+			names[path] = name
+			continue
+		}
+		if name, ok := cache.get(key, path); ok {
+			names[path] = name
+			continue
+		}
+		patterns = append(patterns, "pattern="+path)
+		missing = append(missing, path)
+	}
 
-	if len(pkgs) > 1 {
-		return "", fmt.Errorf("%d packages found for %s, %s", len(pkgs), path, r.Config.Dir)
+	if len(missing) == 0 {
+		return names, nil
 	}
-	if len(pkgs) == 0 {
-		return "", resolver.ErrPackageNotFound
+
+	pkgs, err := packages.Load(&config, patterns...)
+	if err != nil {
+		return nil, err
 	}
 
-	p := pkgs[0]
+	if len(missing) == 1 {
+		path := missing[0]
+		if len(pkgs) > 1 {
+			return nil, fmt.Errorf("%d packages found for %s, %s", len(pkgs), path, config.Dir)
+		}
+		if len(pkgs) == 0 {
+			return nil, resolver.ErrPackageNotFound
+		}
+		p := pkgs[0]
+		if len(p.Errors) > 0 {
+			return nil, p.Errors[0]
+		}
+		cache.set(key, path, p.Name)
+		names[path] = p.Name
+		return names, nil
+	}
 
-	if len(p.Errors) > 0 {
-		return "", p.Errors[0]
+	found := map[string]*packages.Package{}
+	for _, p := range pkgs {
+		found[p.PkgPath] = p
+	}
+	for _, path := range missing {
+		p, ok := found[path]
+		if !ok {
+			// vendored packages may be returned with a different package path, so we fall back to
+			// resolving the path on its own.
+			name, err := r.ResolvePackage(path)
+			if err != nil {
+				return nil, err
+			}
+			names[path] = name
+			continue
+		}
+		if len(p.Errors) > 0 {
+			return nil, p.Errors[0]
+		}
+		cache.set(key, path, p.Name)
+		names[path] = p.Name
 	}
 
-	return p.Name, nil
+	return names, nil
 }
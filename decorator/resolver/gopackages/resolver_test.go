@@ -3,6 +3,7 @@ package gopackages_test
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/dave/dst/decorator/resolver"
@@ -74,3 +75,77 @@ func TestRestorerResolver(t *testing.T) {
 		})
 	}
 }
+
+func TestRestorerResolverBatch(t *testing.T) {
+	src := map[string]string{
+		"main/main.go":    "package main \n\n func main(){}",
+		"foo/foo.go":      "package foo \n\n func A(){}",
+		"bar/go-bar/b.go": "package bar \n\n func B(){}",
+		"go.mod":          "module root",
+	}
+	root, err := tempDir(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	r := gopackages.New(filepath.Join(root, "main"))
+	r.Cache = gopackages.NewCache()
+	r.Hints = map[string]string{"root/hinted": "hinted"}
+
+	expect := map[string]string{
+		"root/foo":        "foo",
+		"root/bar/go-bar": "bar",
+		"fmt":             "fmt",
+		"root/hinted":     "hinted",
+	}
+	var paths []string
+	for path := range expect {
+		paths = append(paths, path)
+	}
+	names, err := r.ResolvePackages(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, expect) {
+		t.Fatalf("expected %v, got %v", expect, names)
+	}
+
+	// the names are now cached, so they resolve after the source has been deleted
+	if err := os.RemoveAll(filepath.Join(root, "foo")); err != nil {
+		t.Fatal(err)
+	}
+	name, err := r.ResolvePackage("root/foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "foo" {
+		t.Fatalf("expected foo, got %s", name)
+	}
+
+	// a different config resolves again
+	cache := r.Cache
+	r.Config.BuildFlags = []string{"-tags", "foo"}
+	if _, err := r.ResolvePackage("root/foo"); err == nil {
+		t.Fatal("expected error resolving deleted package with different build flags")
+	}
+	r.Config.BuildFlags = nil
+
+	// without a cache the names are resolved again
+	r.Cache = nil
+	if _, err := r.ResolvePackage("root/foo"); err == nil {
+		t.Fatal("expected error resolving deleted package without a cache")
+	}
+
+	// a different cache resolves again
+	r.Cache = gopackages.NewCache()
+	if _, err := r.ResolvePackage("root/foo"); err == nil {
+		t.Fatal("expected error resolving deleted package")
+	}
+
+	// the original cache still has the name
+	r.Cache = cache
+	if name, err := r.ResolvePackage("root/foo"); err != nil || name != "foo" {
+		t.Fatalf("expected foo from the cache, got %q, %v", name, err)
+	}
+}
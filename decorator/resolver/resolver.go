@@ -10,6 +10,13 @@ type RestorerResolver interface {
 	ResolvePackage(path string) (string, error)
 }
 
+// BatchRestorerResolver is a RestorerResolver that can resolve several package paths at once. It
+// should return a name for every path, or an error.
+type BatchRestorerResolver interface {
+	RestorerResolver
+	ResolvePackages(paths []string) (map[string]string, error)
+}
+
 // DecoratorResolver resolves an identifier to a local or remote reference.
 //
 // Returns path == "" if the node is not a local or remote reference (e.g. a field in a composite
@@ -1,9 +1,11 @@
 package decorator
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
 	"go/format"
+	"go/printer"
 	"go/token"
 	"io"
 	"os"
@@ -44,20 +46,35 @@ type Restorer struct {
 	Resolver resolver.RestorerResolver
 	// Local package path - required if Resolver is set.
 	Path string
+
+	// If Preserve is set to the Decorator that decorated the file, Fprint prints top-level
+	// declarations that have not been modified since decoration byte-for-byte from the original
+	// source. Only modified declarations are re-printed and gofmt'ed. The original source is kept
+	// by the Decorator if KeepSource is set, or else read from disk. If it is not available, the
+	// entire file is re-printed. The Decorator keeps an unmodified decoration of each file printed
+	// with Preserve, to find the modified declarations.
+	Preserve *Decorator
+
+	// If Config is set, Fprint uses it to print the file instead of format.Node. This allows the
+	// tab width and printer.Mode to be configured, and skips the gofmt normalization that
+	// format.Node performs (e.g. sorting imports).
+	Config *printer.Config
+
+	// If LineDirectives is set to the Decorator that decorated the file, Fprint emits a //line
+	// directive before each top-level declaration, mapping it to its position in the original
+	// source. Declarations that were created after decoration are mapped to their position in the
+	// output if Name is set.
+	LineDirectives *Decorator
 }
 
-// Print uses format.Node to print a *dst.File to stdout
+// Print uses format.Node (or Config if set) to print a *dst.File to stdout
 func (pr *Restorer) Print(f *dst.File) error {
 	return pr.Fprint(os.Stdout, f)
 }
 
-// Fprint uses format.Node to print a *dst.File to a writer
+// Fprint uses format.Node (or Config if set) to print a *dst.File to a writer
 func (pr *Restorer) Fprint(w io.Writer, f *dst.File) error {
-	af, err := pr.RestoreFile(f)
-	if err != nil {
-		return err
-	}
-	return format.Node(w, pr.Fset, af)
+	return pr.FileRestorer().Fprint(w, f)
 }
 
 // RestoreFile restores a *dst.File to an *ast.File
@@ -89,18 +106,137 @@ type FileRestorer struct {
 	packageNames    map[string]string        // names in the code of all imported packages ("." for dot-imports)
 }
 
-// Print uses format.Node to print a *dst.File to stdout
+// Print uses format.Node (or Config if set) to print a *dst.File to stdout
 func (r *FileRestorer) Print(f *dst.File) error {
 	return r.Fprint(os.Stdout, f)
 }
 
-// Fprint uses format.Node to print a *dst.File to a writer
+// Fprint uses format.Node (or Config if set) to print a *dst.File to a writer
 func (r *FileRestorer) Fprint(w io.Writer, f *dst.File) error {
 	af, err := r.RestoreFile(f)
 	if err != nil {
 		return err
 	}
-	return format.Node(w, r.Fset, af)
+	if r.Preserve == nil && r.Config == nil && r.LineDirectives == nil {
+		return format.Node(w, r.Fset, af)
+	}
+	b, err := r.render(af)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// render prints the restored file using Config or format.Node. If Preserve is set, unmodified
+// declarations are copied from the original source. If LineDirectives is set, //line directives
+// are added.
+func (r *FileRestorer) render(af *ast.File) ([]byte, error) {
+
+	if r.Config == nil {
+		// format.Node sorts the imports in a copy of the file if required. We sort them in the
+		// restored file so the order of the nodes matches the printed output.
+		ast.SortImports(r.Fset, af)
+	}
+
+	// The printer emits parentheses for a nil FuncType.Params, and they are parsed as an empty
+	// FieldList. We add the FieldList so the restored file stays in step with the printed output.
+	ast.Inspect(af, func(n ast.Node) bool {
+		if ft, ok := n.(*ast.FuncType); ok && ft.Params == nil {
+			ft.Params = &ast.FieldList{}
+		}
+		return true
+	})
+
+	buf := &bytes.Buffer{}
+	if r.Config != nil {
+		if err := r.Config.Fprint(buf, r.Fset, af); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := format.Node(buf, r.Fset, af); err != nil {
+			return nil, err
+		}
+	}
+
+	b := buf.Bytes()
+	if r.Preserve != nil {
+		var err error
+		if b, err = r.preserve(af, b); err != nil {
+			return nil, err
+		}
+	}
+	if r.LineDirectives != nil {
+		var err error
+		if b, err = r.lineDirectives(af, b); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// lineDirectives adds a //line directive before each top-level declaration in the printed output.
+// The directive is added before the doc comment of the declaration, so the doc comment stays
+// attached to the declaration.
+func (r *FileRestorer) lineDirectives(af *ast.File, b []byte) ([]byte, error) {
+	sm, pf, fset, err := r.parseSourceMap(af, b)
+	if err != nil {
+		return nil, err
+	}
+	// docs maps the offset of each printed declaration to the position of its doc comment
+	docs := map[int]token.Position{}
+	for _, decl := range pf.Decls {
+		var doc *ast.CommentGroup
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			doc = decl.Doc
+		case *ast.GenDecl:
+			doc = decl.Doc
+		}
+		if doc != nil {
+			docs[fset.PositionFor(decl.Pos(), false).Offset] = fset.PositionFor(doc.Pos(), false)
+		}
+	}
+	buf := &bytes.Buffer{}
+	cursor := 0       // offset in b
+	added := 0        // number of directives added so far
+	directed := false // true if a directive pointing at the original source has been added
+	for _, decl := range r.file.Decls {
+		span, ok := sm[decl]
+		if !ok {
+			continue
+		}
+		start := span.Start
+		if doc, ok := docs[start.Offset]; ok {
+			start = doc
+		}
+		if start.Column != 1 {
+			// directives are only recognized at the start of a line
+			continue
+		}
+		// lines is the number of lines of the doc comment before the declaration
+		lines := span.Start.Line - start.Line
+		var directive string
+		if pos, _, err := r.LineDirectives.Position(decl); err == nil && pos.Filename != "" {
+			line := pos.Line - lines
+			if line < 1 {
+				line = 1
+			}
+			directive = fmt.Sprintf("//line %s:%d:%d\n", pos.Filename, line, pos.Column)
+			directed = true
+		} else if directed && r.Name != "" {
+			// a new declaration after a directive is mapped back to the output
+			directive = fmt.Sprintf("//line %s:%d:1\n", r.Name, start.Line+added+1)
+		} else {
+			continue
+		}
+		buf.Write(b[cursor:start.Offset])
+		buf.WriteString(directive)
+		cursor = start.Offset
+		added++
+	}
+	buf.Write(b[cursor:])
+	return buf.Bytes(), nil
 }
 
 // RestoreFile restores a *dst.File to *ast.File
@@ -260,16 +396,37 @@ func (r *FileRestorer) updateImports() error {
 		}
 	}
 
+	var unresolved []string
 	for path := range packagesInUse {
 		if _, ok := effectiveAlias[path]; ok {
 			// no need to resolve the path of a package that has an alias
 			continue
 		}
-		name, err := r.Resolver.ResolvePackage(path)
+		unresolved = append(unresolved, path)
+	}
+	sort.Strings(unresolved)
+
+	if br, ok := r.Resolver.(resolver.BatchRestorerResolver); ok && len(unresolved) > 0 {
+		// resolve all the packages in one call if the resolver supports it
+		names, err := br.ResolvePackages(unresolved)
 		if err != nil {
 			return err
 		}
-		resolved[path] = name
+		for _, path := range unresolved {
+			name, ok := names[path]
+			if !ok {
+				return resolver.ErrPackageNotFound
+			}
+			resolved[path] = name
+		}
+	} else {
+		for _, path := range unresolved {
+			name, err := r.Resolver.ResolvePackage(path)
+			if err != nil {
+				return err
+			}
+			resolved[path] = name
+		}
 	}
 
 	// We sort the required imports so that the order going into the alias conflict detection
@@ -0,0 +1,190 @@
+package decorator
+
+import (
+	"bytes"
+	"go/printer"
+	"go/token"
+	"testing"
+
+	"github.com/dave/dst"
+)
+
+func TestRestorerPrinter(t *testing.T) {
+	code := `package main
+
+import (
+	"os"
+	"fmt"
+)
+
+// a is a function
+func a() {
+	if true {
+		fmt.Println()
+	}
+}
+
+var b = os.Args
+`
+	tests := []struct {
+		skip, solo bool
+		name       string
+		mutate     func(f *dst.File)
+		restorer   func(r *FileRestorer, d *Decorator)
+		expect     string
+	}{
+		{
+			name: "default",
+			expect: `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// a is a function
+func a() {
+	if true {
+		fmt.Println()
+	}
+}
+
+var b = os.Args
+`,
+		},
+		{
+			name: "spaces",
+			restorer: func(r *FileRestorer, d *Decorator) {
+				r.Config = &printer.Config{Mode: printer.UseSpaces, Tabwidth: 2}
+			},
+			expect: `package main
+
+import (
+  "os"
+  "fmt"
+)
+
+// a is a function
+func a() {
+  if true {
+    fmt.Println()
+  }
+}
+
+var b = os.Args
+`,
+		},
+		{
+			name: "line-directives",
+			restorer: func(r *FileRestorer, d *Decorator) {
+				r.LineDirectives = d
+				r.Name = "out.go"
+			},
+			mutate: func(f *dst.File) {
+				c := &dst.GenDecl{
+					Tok:   token.VAR,
+					Specs: []dst.Spec{&dst.ValueSpec{Names: []*dst.Ident{dst.NewIdent("c")}, Type: dst.NewIdent("int")}},
+				}
+				c.Decs.Before = dst.EmptyLine
+				f.Decls = append(f.Decls, c)
+			},
+			expect: `package main
+
+//line main.go:3:1
+import (
+	"fmt"
+	"os"
+)
+
+//line main.go:8:1
+// a is a function
+func a() {
+	if true {
+		fmt.Println()
+	}
+}
+
+//line main.go:15:1
+var b = os.Args
+
+//line out.go:21:1
+var c int
+`,
+		},
+		{
+			name: "line-directives-doc",
+			restorer: func(r *FileRestorer, d *Decorator) {
+				r.LineDirectives = d
+				r.Name = "out.go"
+			},
+			mutate: func(f *dst.File) {
+				c := &dst.FuncDecl{
+					Name: dst.NewIdent("c"),
+					Type: &dst.FuncType{},
+					Body: &dst.BlockStmt{},
+				}
+				c.Decs.Before = dst.EmptyLine
+				c.Decs.Start.Append("// c is a new function", "// with a long comment")
+				f.Decls = append(f.Decls, c)
+			},
+			expect: `package main
+
+//line main.go:3:1
+import (
+	"fmt"
+	"os"
+)
+
+//line main.go:8:1
+// a is a function
+func a() {
+	if true {
+		fmt.Println()
+	}
+}
+
+//line main.go:15:1
+var b = os.Args
+
+//line out.go:21:1
+// c is a new function
+// with a long comment
+func c() {}
+`,
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			if test.skip {
+				t.Skip()
+			}
+			d := NewDecorator(token.NewFileSet())
+			f, err := d.ParseFile("main.go", code, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.mutate != nil {
+				test.mutate(f)
+			}
+			r := NewRestorer().FileRestorer()
+			if test.restorer != nil {
+				test.restorer(r, d)
+			}
+			buf := &bytes.Buffer{}
+			if err := r.Fprint(buf, f); err != nil {
+				t.Fatal(err)
+			}
+			compare(t, test.expect, buf.String())
+		})
+	}
+}
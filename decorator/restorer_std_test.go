@@ -43,6 +43,19 @@ func TestLoadStdLibAll(t *testing.T) {
 
 }
 
+// duplicateImports lists standard library files that import the same path more than once (e.g.
+// "unsafe" and _ "unsafe").
+var duplicateImports = map[string]bool{
+	"net/http/server.go":          true,
+	"net/http/request.go":         true,
+	"crypto/x509/x509.go":         true,
+	"crypto/rand/rand.go":         true,
+	"internal/godebug/godebug.go": true,
+	"reflect/badlinkname.go":      true,
+	"runtime/rand.go":             true,
+	"testing/cryptotest/rand.go":  true,
+}
+
 func testPackageRestoresCorrectlyWithImports(t *testing.T, path ...string) {
 	t.Helper()
 	pkgs, err := Load(nil, path...)
@@ -65,8 +78,8 @@ func testPackageRestoresCorrectlyWithImports(t *testing.T, path ...string) {
 
 				t.Run(fname, func(t *testing.T) {
 
-					if (p.PkgPath == "net/http" && (fname == "server.go" || fname == "request.go")) || (p.PkgPath == "crypto/x509" && fname == "x509.go") {
-						t.Skip("TODO: In net/http/server.go, net/http/request.go, and crypto/x509/x509.go we multiple imports with the same path and different aliases. This edge case would need a complete rewrite of the import management block to support - see see https://github.com/dave/dst/issues/45")
+					if duplicateImports[p.PkgPath+"/"+fname] {
+						t.Skip("TODO: These files have multiple imports with the same path and different aliases. This edge case would need a complete rewrite of the import management block to support - see see https://github.com/dave/dst/issues/45")
 					}
 
 					buf := &bytes.Buffer{}
@@ -618,6 +618,21 @@ const d = 1
 			func /*FuncDeclDoc*/ (a *b) /*FuncDeclRecv*/ c /*FuncDeclName*/ (d, e int) (f, g int) /*FuncDeclType*/ {
 			}`,
 		},
+		{
+			name: "generics",
+			code: `package main
+
+			type A[T any, U ~int | ~string /*U*/] /*TypeParams*/ struct {
+				t T
+				u U
+			}
+
+			type B[T any] = /*TypeParams*/ A[T, int]
+
+			func /*Func*/ c /*Name*/ [T any] /*TypeParams*/ (t T) /*Params*/ {
+				var _ = A /*X*/ [ /*Lbrack*/ int, string /*Indices*/] /*End*/ {}
+			}`,
+		},
 		{
 			name: "sel-space-decoration",
 			code: `package main
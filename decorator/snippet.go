@@ -0,0 +1,194 @@
+package decorator
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/dave/dst"
+)
+
+// ParseExpr parses and decorates a Go expression.
+func ParseExpr(src string) (dst.Expr, error) {
+	return NewDecorator(token.NewFileSet()).ParseExpr(src, nil)
+}
+
+// ParseType parses and decorates a Go type expression.
+func ParseType(src string) (dst.Expr, error) {
+	return NewDecorator(token.NewFileSet()).ParseType(src, nil)
+}
+
+// ParseStmts parses and decorates a list of Go statements.
+func ParseStmts(src string) ([]dst.Stmt, error) {
+	return NewDecorator(token.NewFileSet()).ParseStmts(src, nil)
+}
+
+// ParseDecls parses and decorates a list of Go declarations.
+func ParseDecls(src string) ([]dst.Decl, error) {
+	return NewDecorator(token.NewFileSet()).ParseDecls(src, nil)
+}
+
+// ParseExpr parses and decorates a Go expression. Comments before and after the expression are
+// attached to the Start and End decorations. If imports is not nil, the snippet is parsed in the
+// context of the import block and package name of imports, so a Decorator with a Resolver (e.g.
+// goast.DecoratorResolver) resolves the qualified identifiers in the snippet.
+func (d *Decorator) ParseExpr(src string, imports *dst.File) (dst.Expr, error) {
+	stmts, err := d.ParseStmts(src, imports)
+	if err != nil {
+		return nil, err
+	}
+	if len(stmts) != 1 {
+		return nil, fmt.Errorf("expected a single expression, found %d statements", len(stmts))
+	}
+	es, ok := stmts[0].(*dst.ExprStmt)
+	if !ok {
+		return nil, fmt.Errorf("expected an expression, found %T", stmts[0])
+	}
+	expr := es.X
+	decs := expr.Decorations()
+	decs.Start.Prepend(es.Decs.Start...)
+	decs.End.Append(es.Decs.End...)
+	return expr, nil
+}
+
+// ParseType parses and decorates a Go type expression. See ParseExpr for more details.
+func (d *Decorator) ParseType(src string, imports *dst.File) (dst.Expr, error) {
+	// check the syntax is a type before parsing as an expression
+	const head = "package p; var _ "
+	if _, err := parser.ParseFile(token.NewFileSet(), "", head+src, parser.SkipObjectResolution); err != nil {
+		return nil, snippetError(err, head, src)
+	}
+	return d.ParseExpr(src, imports)
+}
+
+// ParseStmts parses and decorates a list of Go statements. See ParseExpr for more details.
+func (d *Decorator) ParseStmts(src string, imports *dst.File) ([]dst.Stmt, error) {
+	decls, err := d.parseDecls("func _() {\n", src, "\n}", imports)
+	if err != nil {
+		return nil, err
+	}
+	if len(decls) != 1 {
+		return nil, errors.New("expected statements")
+	}
+	fd, ok := decls[0].(*dst.FuncDecl)
+	if !ok || fd.Body == nil {
+		return nil, errors.New("expected statements")
+	}
+	return fd.Body.List, nil
+}
+
+// ParseDecls parses and decorates a list of Go declarations. See ParseExpr for more details.
+func (d *Decorator) ParseDecls(src string, imports *dst.File) ([]dst.Decl, error) {
+	return d.parseDecls("", src, "", imports)
+}
+
+// parseDecls parses src wrapped in prefix and suffix as a list of declarations in a file with the
+// package name and import block of imports. The positions of parse errors are relative to src.
+func (d *Decorator) parseDecls(prefix, src, suffix string, imports *dst.File) ([]dst.Decl, error) {
+	name := "p"
+	if imports != nil && imports.Name != nil {
+		name = imports.Name.Name
+	}
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "package %s\n\n", name)
+	var count int
+	if imports != nil {
+		for _, spec := range imports.Imports {
+			if spec.Name != nil {
+				fmt.Fprintf(sb, "import %s %s\n", spec.Name.Name, spec.Path.Value)
+			} else {
+				fmt.Fprintf(sb, "import %s\n", spec.Path.Value)
+			}
+			count++
+		}
+	}
+	sb.WriteString("\n")
+	sb.WriteString(prefix)
+	head := sb.String()
+	sb.WriteString(src)
+	sb.WriteString(suffix)
+	sb.WriteString("\n")
+
+	f, err := d.ParseFile("", sb.String(), parser.ParseComments)
+	if err != nil {
+		return nil, snippetError(err, head, src)
+	}
+	return f.Decls[count:], nil
+}
+
+// snippetError converts the positions of a parse error in a file that contains src after head to
+// positions in src. Errors after the end of src (e.g. an unexpected closing brace of the wrapper)
+// are reported at the end of src, and errors before src have no position.
+func snippetError(err error, head, src string) error {
+	list, ok := err.(scanner.ErrorList)
+	if !ok {
+		return err
+	}
+	out := make(scanner.ErrorList, len(list))
+	for i, e := range list {
+		offset := e.Pos.Offset - len(head)
+		var pos token.Position
+		if offset >= 0 {
+			if offset > len(src) {
+				offset = len(src)
+			}
+			pos = token.Position{
+				Filename: e.Pos.Filename,
+				Offset:   offset,
+				Line:     strings.Count(src[:offset], "\n") + 1,
+				Column:   offset - strings.LastIndex(src[:offset], "\n"),
+			}
+		}
+		out[i] = &scanner.Error{Pos: pos, Msg: e.Msg}
+	}
+	return out
+}
+
+// NewSnippetDecorator returns a Decorator that resolves the qualified identifiers of the imports,
+// and a file containing the import specs, to be used with the ParseExpr, ParseStmts and ParseDecls
+// methods of the Decorator. The imports are the paths of the packages that can be referenced by the
+// snippets. The package name is the last element of the path unless it is specified before the
+// path, separated by a space (e.g. "yaml gopkg.in/yaml.v2"). Selector expressions that don't
+// refer to one of the imports are not resolved.
+func NewSnippetDecorator(imports ...string) (*Decorator, *dst.File, error) {
+	file := &dst.File{Name: dst.NewIdent("p")}
+	names := map[string]string{}
+	for _, imp := range imports {
+		var name, p string
+		if i := strings.Index(imp, " "); i >= 0 {
+			name, p = imp[:i], strings.TrimSpace(imp[i+1:])
+		} else {
+			name, p = path.Base(imp), imp
+		}
+		if !token.IsIdentifier(name) {
+			return nil, nil, fmt.Errorf("invalid package name %q for %s", name, p)
+		}
+		names[name] = p
+		file.Imports = append(file.Imports, &dst.ImportSpec{
+			Name: dst.NewIdent(name),
+			Path: &dst.BasicLit{Kind: token.STRING, Value: strconv.Quote(p)},
+		})
+	}
+	return NewDecoratorWithImports(token.NewFileSet(), "p", snippetResolver(names)), file, nil
+}
+
+// snippetResolver resolves qualified identifiers using a map of package name -> path.
+type snippetResolver map[string]string
+
+func (r snippetResolver) ResolveIdent(file *ast.File, parent ast.Node, parentField string, id *ast.Ident) (string, error) {
+	se, ok := parent.(*ast.SelectorExpr)
+	if !ok || parentField != "Sel" {
+		return "", nil
+	}
+	xid, ok := se.X.(*ast.Ident)
+	if !ok || xid.Obj != nil {
+		return "", nil
+	}
+	return r[xid.Name], nil
+}
@@ -0,0 +1,173 @@
+package decorator
+
+import (
+	"bytes"
+	"go/token"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator/resolver/goast"
+	"github.com/dave/dst/decorator/resolver/guess"
+)
+
+func TestSnippet(t *testing.T) {
+	file := `package main
+
+import (
+	"fmt"
+	str "strings"
+)
+
+func main() {}
+`
+	tests := []struct {
+		skip, solo bool
+		name       string
+		imports    bool
+		parse      func(d *Decorator, imports *dst.File) (dst.Node, error)
+		expect     string
+	}{
+		{
+			name: "expr",
+			parse: func(d *Decorator, imports *dst.File) (dst.Node, error) {
+				return d.ParseExpr("// a\nfoo( /* b */ x) // c", imports)
+			},
+			expect: `CallExpr [Start "// a"] [Lparen "/* b */"] [End "// c"]`,
+		},
+		{
+			name:    "expr-imports",
+			imports: true,
+			parse: func(d *Decorator, imports *dst.File) (dst.Node, error) {
+				return d.ParseExpr(`fmt.Sprint(str.ToUpper("a"))`, imports)
+			},
+			expect: `Ident fmt.Sprint
+				Ident strings.ToUpper`,
+		},
+		{
+			name: "type",
+			parse: func(d *Decorator, imports *dst.File) (dst.Node, error) {
+				return d.ParseType("map[string] /* a */ []int", imports)
+			},
+			expect: `MapType [Key "/* a */"]`,
+		},
+		{
+			name: "stmts",
+			parse: func(d *Decorator, imports *dst.File) (dst.Node, error) {
+				stmts, err := d.ParseStmts("// a\nfoo()\n\n// b\nbar() // c", imports)
+				if err != nil {
+					return nil, err
+				}
+				return &dst.BlockStmt{List: stmts}, nil
+			},
+			expect: `ExprStmt [New line before] [Start "// a"] [Empty line after]
+				ExprStmt [Empty line before] [Start "// b"] [End "// c"] [New line after]`,
+		},
+		{
+			name:    "decls",
+			imports: true,
+			parse: func(d *Decorator, imports *dst.File) (dst.Node, error) {
+				decls, err := d.ParseDecls("// A is a\nfunc A() { fmt.Println() }\n\nvar B = str.Repeat", imports)
+				if err != nil {
+					return nil, err
+				}
+				return &dst.File{Name: dst.NewIdent("p"), Decls: decls}, nil
+			},
+			expect: `FuncDecl [Empty line before] [Start "// A is a"] [Empty line after]
+				GenDecl [Empty line before]
+				Ident fmt.Println
+				Ident strings.Repeat`,
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			if test.skip {
+				t.Skip()
+			}
+			var d *Decorator
+			var imports *dst.File
+			if test.imports {
+				d = NewDecoratorWithImports(token.NewFileSet(), "main", goast.WithResolver(guess.New()))
+				var err error
+				if imports, err = d.Parse(file); err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				d = NewDecorator(token.NewFileSet())
+			}
+			n, err := test.parse(d, imports)
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf := &bytes.Buffer{}
+			debug(buf, n)
+			dst.Inspect(n, func(n dst.Node) bool {
+				if id, ok := n.(*dst.Ident); ok && id.Path != "" {
+					buf.WriteString("Ident " + id.Path + "." + id.Name + "\n")
+				}
+				return true
+			})
+			compare(t, normalize(test.expect), normalize(buf.String()))
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := ParseExpr("a := 1"); err == nil {
+			t.Error("expected error for statement in ParseExpr")
+		}
+		if _, err := ParseType("1 + 2"); err == nil {
+			t.Error("expected error for expression in ParseType")
+		}
+		if _, err := ParseStmts("func"); err == nil {
+			t.Error("expected error for invalid statements")
+		}
+	})
+
+	t.Run("error-positions", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			parse  func() error
+			expect string
+		}{
+			{
+				name:   "expr",
+				parse:  func() error { _, err := ParseExpr("1 +"); return err },
+				expect: "1:4: expected operand, found '}'",
+			},
+			{
+				name:   "type",
+				parse:  func() error { _, err := ParseType("1 + 2"); return err },
+				expect: "1:1: expected type, found 1",
+			},
+			{
+				name:   "stmts",
+				parse:  func() error { _, err := ParseStmts("a := 1\nb := "); return err },
+				expect: "2:6: expected operand, found '}'",
+			},
+			{
+				name:   "decls",
+				parse:  func() error { _, err := ParseDecls("var a = 1\nfunc {"); return err },
+				expect: "2:6: expected 'IDENT', found '{'",
+			},
+		}
+		for _, test := range tests {
+			err := test.parse()
+			if err == nil {
+				t.Errorf("%s: expected error", test.name)
+				continue
+			}
+			if err.Error() != test.expect {
+				t.Errorf("%s: expected %q, found %q", test.name, test.expect, err.Error())
+			}
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package decorator
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"reflect"
+
+	"github.com/dave/dst"
+)
+
+// SourceMap maps each restored dst.Node to its location in the printed output.
+type SourceMap map[dst.Node]Span
+
+// Span is the location of a node in the printed output. Offset, Line and Column are set in both
+// Start and End. Filename is the Name of the FileRestorer.
+type Span struct {
+	Start, End token.Position
+}
+
+// FprintSourceMap prints a *dst.File to a writer in the same way as Fprint, and returns the
+// location in the output of every restored dst.Node.
+func (pr *Restorer) FprintSourceMap(w io.Writer, f *dst.File) (SourceMap, error) {
+	return pr.FileRestorer().FprintSourceMap(w, f)
+}
+
+// FprintSourceMap prints a *dst.File to a writer in the same way as Fprint, and returns the
+// location in the output of every restored dst.Node. Nodes that are restored to a different
+// structure (e.g. an Ident with Path that becomes a SelectorExpr) are given the span of the
+// entire restored structure.
+func (r *FileRestorer) FprintSourceMap(w io.Writer, f *dst.File) (SourceMap, error) {
+	af, err := r.RestoreFile(f)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := r.render(af)
+	if err != nil {
+		return nil, err
+	}
+
+	sm, err := r.sourceMap(af, b)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
+
+// sourceMap parses the printed output and walks it in step with the restored file. The printer
+// preserves the structure of the tree, so each node in the output corresponds to the restored node
+// at the same index in a pre-order traversal.
+func (r *FileRestorer) sourceMap(af *ast.File, output []byte) (SourceMap, error) {
+	sm, _, _, err := r.parseSourceMap(af, output)
+	return sm, err
+}
+
+// parseSourceMap is sourceMap, and also returns the parsed output and its FileSet.
+func (r *FileRestorer) parseSourceMap(af *ast.File, output []byte) (SourceMap, *ast.File, *token.FileSet, error) {
+	fset := token.NewFileSet()
+	pf, err := parser.ParseFile(fset, r.Name, output, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	restored, printed := flatten(af), flatten(pf)
+	if len(restored) != len(printed) {
+		return nil, nil, nil, errors.New("printed output does not match restored file")
+	}
+
+	sm := SourceMap{}
+	for i, an := range restored {
+		if reflect.TypeOf(an) != reflect.TypeOf(printed[i]) {
+			return nil, nil, nil, errors.New("printed output does not match restored file")
+		}
+		dn, ok := r.Dst.Nodes[an]
+		if !ok {
+			continue
+		}
+		if _, ok := sm[dn]; ok {
+			// if a dst.Node was restored to several ast nodes, the first (outermost) is used.
+			continue
+		}
+		// positions are not adjusted by //line directives because they refer to the output
+		sm[dn] = Span{
+			Start: fset.PositionFor(printed[i].Pos(), false),
+			End:   fset.PositionFor(printed[i].End(), false),
+		}
+	}
+	return sm, pf, fset, nil
+}
+
+// flatten returns the nodes in a pre-order traversal of n, excluding comments. Comments are not
+// attached to the same nodes by the restorer and the parser.
+func flatten(n ast.Node) []ast.Node {
+	var nodes []ast.Node
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n.(type) {
+		case nil:
+			return false
+		case *ast.CommentGroup, *ast.Comment:
+			return false
+		}
+		nodes = append(nodes, n)
+		return true
+	})
+	return nodes
+}
@@ -0,0 +1,93 @@
+package decorator
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator/resolver/goast"
+	"github.com/dave/dst/decorator/resolver/guess"
+)
+
+func TestSourceMap(t *testing.T) {
+	code := `package main
+
+import (
+	"strings"
+	"fmt"
+)
+
+func main() {
+	var a  =  strings.ToUpper("a")
+	fmt.Println(a)
+}
+`
+	d := NewDecoratorWithImports(token.NewFileSet(), "main", goast.New())
+	f, err := d.ParseFile("main.go", code, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := f.Decls[1].(*dst.FuncDecl).Body
+	decl := body.List[0].(*dst.DeclStmt)
+	call := body.List[1].(*dst.ExprStmt).X.(*dst.CallExpr)
+	added := &dst.ExprStmt{X: &dst.CallExpr{
+		Fun:  &dst.Ident{Path: "os", Name: "Exit"},
+		Args: []dst.Expr{&dst.BasicLit{Kind: token.INT, Value: "1"}},
+	}}
+	added.Decs.Before = dst.NewLine
+	body.List = append(body.List, added)
+
+	r := NewRestorerWithImports("main", guess.New())
+	fr := r.FileRestorer()
+	fr.Name = "out.go"
+	buf := &bytes.Buffer{}
+	sm, err := fr.FprintSourceMap(buf, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+
+	tests := []struct {
+		name   string
+		node   dst.Node
+		expect string
+	}{
+		{"file", f, "out.go:1:1-13:2"},
+		{"import", f.Imports[0], "out.go:6:2-6:11"},
+		{"decl", decl, "out.go:10:2-10:30"},
+		{"call", call, "out.go:11:2-11:16"},
+		{"merged", call.Fun, "out.go:11:2-11:13"},
+		{"added", added, "out.go:12:2-12:12"},
+		{"added-merged", added.X.(*dst.CallExpr).Fun, "out.go:12:2-12:9"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			span, ok := sm[test.node]
+			if !ok {
+				t.Fatal("node not found in source map")
+			}
+			compare(t, test.expect, fmt.Sprintf("%s-%d:%d", span.Start, span.End.Line, span.End.Column))
+			compare(t, output[span.Start.Offset:span.End.Offset], outputOf(t, test.node, output, span))
+		})
+	}
+}
+
+// outputOf returns the text expected at the span of n
+func outputOf(t *testing.T, n dst.Node, output string, span Span) string {
+	t.Helper()
+	if span.Start.Offset < 0 || span.End.Offset > len(output) || span.Start.Offset > span.End.Offset {
+		t.Fatalf("invalid offsets %d-%d", span.Start.Offset, span.End.Offset)
+	}
+	switch n := n.(type) {
+	case *dst.ImportSpec:
+		return n.Path.Value
+	case *dst.Ident:
+		if n.Path != "" {
+			return n.Path + "." + n.Name
+		}
+		return n.Name
+	}
+	return output[span.Start.Offset:span.End.Offset]
+}
@@ -0,0 +1,471 @@
+package decorator
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"strings"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/dstutil"
+)
+
+// Template is Go source containing placeholders (holes), which is parsed once and can be
+// instantiated many times with dst nodes. A hole is written as $name, and is replaced by a single
+// node. A list hole is written as $name... (or $*name) and is replaced by a list of nodes. Holes can be used
+// where an identifier is valid in Go syntax:
+//
+//	$x             replaced by a dst.Expr, or by a dst.Stmt if it is used as a statement
+//	func $name()   replaced by a *dst.Ident, or a string which is used as the name
+//	f($args...)    replaced by a []dst.Expr
+//	$stmts...      replaced by a []dst.Stmt when used as a statement
+//	func($p...)    replaced by a []*dst.Field
+//
+// Comments attached to a hole are moved to the node that replaces it. Qualified identifiers in the
+// template are resolved using the imports, so Ident.Path is set and the import management of the
+// Restorer works as usual.
+type Template struct {
+	kind  string // "expr", "stmts" or "decls"
+	nodes []dst.Node
+	holes map[string]bool // name -> true if list hole
+}
+
+// Args are the values used to fill the holes in a Template, by name (without the $ prefix).
+type Args map[string]interface{}
+
+const (
+	holePrefix     = "_dst_hole_"
+	listHolePrefix = "_dst_holes_"
+)
+
+// ParseExprTemplate parses a Template containing a Go expression. The imports are the paths of the
+// packages that can be referenced by the template. The package name is the last element of the
+// path unless it is specified before the path, separated by a space (e.g. "yaml gopkg.in/yaml.v2").
+func ParseExprTemplate(src string, imports ...string) (*Template, error) {
+	d, file, err := NewSnippetDecorator(imports...)
+	if err != nil {
+		return nil, err
+	}
+	return d.ParseExprTemplate(src, file)
+}
+
+// ParseStmtsTemplate parses a Template containing a list of Go statements. See ParseExprTemplate for
+// more details.
+func ParseStmtsTemplate(src string, imports ...string) (*Template, error) {
+	d, file, err := NewSnippetDecorator(imports...)
+	if err != nil {
+		return nil, err
+	}
+	return d.ParseStmtsTemplate(src, file)
+}
+
+// ParseDeclsTemplate parses a Template containing a list of Go declarations. See ParseExprTemplate
+// for more details.
+func ParseDeclsTemplate(src string, imports ...string) (*Template, error) {
+	d, file, err := NewSnippetDecorator(imports...)
+	if err != nil {
+		return nil, err
+	}
+	return d.ParseDeclsTemplate(src, file)
+}
+
+// ParseExprTemplate parses a Template containing a Go expression in the context of the import block
+// of imports. See Decorator.ParseExpr for more details.
+func (d *Decorator) ParseExprTemplate(src string, imports *dst.File) (*Template, error) {
+	replaced, holes, err := ReplaceHoles(src)
+	if err != nil {
+		return nil, err
+	}
+	expr, err := d.ParseExpr(replaced, imports)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{kind: "expr", nodes: []dst.Node{expr}, holes: holes}, nil
+}
+
+// ParseStmtsTemplate parses a Template containing a list of Go statements in the context of the
+// import block of imports. See Decorator.ParseExpr for more details.
+func (d *Decorator) ParseStmtsTemplate(src string, imports *dst.File) (*Template, error) {
+	replaced, holes, err := ReplaceHoles(src)
+	if err != nil {
+		return nil, err
+	}
+	stmts, err := d.ParseStmts(replaced, imports)
+	if err != nil {
+		return nil, err
+	}
+	t := &Template{kind: "stmts", holes: holes}
+	for _, stmt := range stmts {
+		t.nodes = append(t.nodes, stmt)
+	}
+	return t, nil
+}
+
+// ParseDeclsTemplate parses a Template containing a list of Go declarations in the context of the
+// import block of imports. See Decorator.ParseExpr for more details.
+func (d *Decorator) ParseDeclsTemplate(src string, imports *dst.File) (*Template, error) {
+	replaced, holes, err := ReplaceHoles(src)
+	if err != nil {
+		return nil, err
+	}
+	decls, err := d.ParseDecls(replaced, imports)
+	if err != nil {
+		return nil, err
+	}
+	t := &Template{kind: "decls", holes: holes}
+	for _, decl := range decls {
+		t.nodes = append(t.nodes, decl)
+	}
+	return t, nil
+}
+
+// Expr instantiates an expression Template.
+func (t *Template) Expr(args Args) (dst.Expr, error) {
+	if t.kind != "expr" {
+		return nil, fmt.Errorf("template contains %s, not expr", t.kind)
+	}
+	nodes, err := t.instantiate(args)
+	if err != nil {
+		return nil, err
+	}
+	expr, ok := nodes[0].(dst.Expr)
+	if !ok {
+		return nil, fmt.Errorf("template instantiated to %T, not dst.Expr", nodes[0])
+	}
+	return expr, nil
+}
+
+// Stmts instantiates a statements Template.
+func (t *Template) Stmts(args Args) ([]dst.Stmt, error) {
+	if t.kind != "stmts" {
+		return nil, fmt.Errorf("template contains %s, not stmts", t.kind)
+	}
+	nodes, err := t.instantiate(args)
+	if err != nil {
+		return nil, err
+	}
+	var stmts []dst.Stmt
+	for _, n := range nodes {
+		stmts = append(stmts, n.(dst.Stmt))
+	}
+	return stmts, nil
+}
+
+// Decls instantiates a declarations Template.
+func (t *Template) Decls(args Args) ([]dst.Decl, error) {
+	if t.kind != "decls" {
+		return nil, fmt.Errorf("template contains %s, not decls", t.kind)
+	}
+	nodes, err := t.instantiate(args)
+	if err != nil {
+		return nil, err
+	}
+	var decls []dst.Decl
+	for _, n := range nodes {
+		decls = append(decls, n.(dst.Decl))
+	}
+	return decls, nil
+}
+
+func (t *Template) instantiate(args Args) (nodes []dst.Node, err error) {
+
+	for name, list := range t.holes {
+		if _, ok := args[name]; !ok {
+			if list {
+				return nil, fmt.Errorf("no value for hole $%s...", name)
+			}
+			return nil, fmt.Errorf("no value for hole $%s", name)
+		}
+	}
+
+	// Cursor.Replace panics if the replacement has the wrong type
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("instantiating template: %v", r)
+		}
+	}()
+
+	in := &instantiation{args: args, used: map[string]bool{}}
+
+	// the template nodes are wrapped in a BlockStmt so they can be replaced by Apply
+	block := &dst.BlockStmt{}
+	for _, n := range t.nodes {
+		switch n := dst.Clone(n).(type) {
+		case dst.Expr:
+			block.List = append(block.List, &dst.ExprStmt{X: n})
+		case dst.Stmt:
+			block.List = append(block.List, n)
+		case dst.Decl:
+			block.List = append(block.List, &dst.DeclStmt{Decl: n})
+		}
+	}
+
+	dstutil.Apply(block, in.apply, nil)
+	if in.err != nil {
+		return nil, in.err
+	}
+
+	for i, stmt := range block.List {
+		switch {
+		case t.kind == "expr":
+			es, ok := stmt.(*dst.ExprStmt)
+			if !ok || i > 0 {
+				return nil, fmt.Errorf("expression template instantiated to %T", stmt)
+			}
+			nodes = append(nodes, es.X)
+		case t.kind == "decls":
+			nodes = append(nodes, stmt.(*dst.DeclStmt).Decl)
+		default:
+			nodes = append(nodes, stmt)
+		}
+	}
+	return nodes, nil
+}
+
+type instantiation struct {
+	args Args
+	used map[string]bool
+	err  error
+}
+
+// value returns the value for a hole. Nodes are cloned if the hole is used more than once.
+func (in *instantiation) value(name string) interface{} {
+	v := in.args[name]
+	if !in.used[name] {
+		in.used[name] = true
+		return v
+	}
+	switch v := v.(type) {
+	case dst.Node:
+		return dst.Clone(v)
+	case []dst.Expr:
+		out := make([]dst.Expr, len(v))
+		for i, n := range v {
+			out[i] = dst.Clone(n).(dst.Expr)
+		}
+		return out
+	case []dst.Stmt:
+		out := make([]dst.Stmt, len(v))
+		for i, n := range v {
+			out[i] = dst.Clone(n).(dst.Stmt)
+		}
+		return out
+	case []*dst.Field:
+		out := make([]*dst.Field, len(v))
+		for i, n := range v {
+			out[i] = dst.Clone(n).(*dst.Field)
+		}
+		return out
+	case []*dst.Ident:
+		out := make([]*dst.Ident, len(v))
+		for i, n := range v {
+			out[i] = dst.Clone(n).(*dst.Ident)
+		}
+		return out
+	}
+	return v
+}
+
+func (in *instantiation) apply(c *dstutil.Cursor) bool {
+	if in.err != nil {
+		return false
+	}
+	switch n := c.Node().(type) {
+	case *dst.ExprStmt:
+		name, list, ok := HoleName(n.X)
+		if !ok {
+			return true
+		}
+		switch in.args[name].(type) {
+		case []dst.Stmt, dst.Stmt:
+		default:
+			// an expression is replaced when the ident is visited
+			return true
+		}
+		switch v := in.value(name).(type) {
+		case []dst.Stmt:
+			if !list {
+				in.err = fmt.Errorf("hole $%s needs a single node, got %T", name, v)
+				return false
+			}
+			for i, stmt := range v {
+				if i == 0 {
+					moveHoleDecorations(&n.Decs.NodeDecs, stmt.Decorations(), true, len(v) == 1)
+				} else if i == len(v)-1 {
+					moveHoleDecorations(&n.Decs.NodeDecs, stmt.Decorations(), false, true)
+				}
+				c.InsertBefore(stmt)
+			}
+			c.Delete()
+			return false
+		case dst.Stmt:
+			moveHoleDecorations(&n.Decs.NodeDecs, v.Decorations(), true, true)
+			moveHoleDecorations(n.X.Decorations(), v.Decorations(), true, true)
+			c.Replace(v)
+		}
+		return false
+	case *dst.Field:
+		name, list, ok := HoleName(n.Type)
+		if !ok || len(n.Names) > 0 {
+			return true
+		}
+		switch in.args[name].(type) {
+		case []*dst.Field, *dst.Field:
+		default:
+			return true
+		}
+		switch v := in.value(name).(type) {
+		case []*dst.Field:
+			if !list {
+				in.err = fmt.Errorf("hole $%s needs a single node, got %T", name, v)
+				return false
+			}
+			for _, f := range v {
+				c.InsertBefore(f)
+			}
+			c.Delete()
+			return false
+		case *dst.Field:
+			moveHoleDecorations(&n.Decs.NodeDecs, v.Decorations(), true, true)
+			c.Replace(v)
+		}
+		return false
+	case *dst.Ident:
+		name, list, ok := HoleName(n)
+		if !ok {
+			return true
+		}
+		v := in.value(name)
+		if list {
+			if c.Index() < 0 {
+				in.err = fmt.Errorf("hole $%s... is not in a list", name)
+				return false
+			}
+			switch v := v.(type) {
+			case []dst.Expr:
+				for _, e := range v {
+					c.InsertBefore(e)
+				}
+			case []*dst.Ident:
+				for _, id := range v {
+					c.InsertBefore(id)
+				}
+			default:
+				in.err = fmt.Errorf("hole $%s... needs a list of nodes, got %T", name, v)
+				return false
+			}
+			c.Delete()
+			return false
+		}
+		switch v := v.(type) {
+		case string:
+			n.Name = v
+		case dst.Node:
+			moveHoleDecorations(n.Decorations(), v.Decorations(), true, true)
+			c.Replace(v)
+		default:
+			in.err = fmt.Errorf("hole $%s needs a node or a string, got %T", name, v)
+		}
+		return false
+	}
+	return true
+}
+
+// moveHoleDecorations moves the decorations from a hole to the node that replaced it.
+func moveHoleDecorations(from, to *dst.NodeDecs, start, end bool) {
+	if start {
+		to.Start.Prepend(from.Start...)
+		if to.Before == dst.None {
+			to.Before = from.Before
+		}
+	}
+	if end {
+		to.End.Append(from.End...)
+		if to.After == dst.None {
+			to.After = from.After
+		}
+	}
+}
+
+// HoleName returns the name of the hole if n is an identifier that replaced a hole in ReplaceHoles,
+// and whether it is a list hole.
+func HoleName(n dst.Node) (name string, list, ok bool) {
+	id, isIdent := n.(*dst.Ident)
+	if !isIdent || id.Path != "" {
+		return "", false, false
+	}
+	switch {
+	case strings.HasPrefix(id.Name, listHolePrefix):
+		return strings.TrimPrefix(id.Name, listHolePrefix), true, true
+	case strings.HasPrefix(id.Name, holePrefix):
+		return strings.TrimPrefix(id.Name, holePrefix), false, true
+	}
+	return "", false, false
+}
+
+// ReplaceHoles replaces each hole $name in src with an identifier, and each list hole $name... (or
+// $*name) with a list identifier, so src can be parsed as Go. HoleName returns the name of the hole from the
+// identifier. The names of the holes are returned, mapped to true for list holes. The blank hole $_
+// may be used as both a single hole and a list hole. The go scanner is used so $ in strings and
+// comments is ignored.
+func ReplaceHoles(src string) (string, map[string]bool, error) {
+	holes := map[string]bool{}
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, []byte(src), func(token.Position, string) {}, 0)
+
+	type token_ struct {
+		offset int
+		tok    token.Token
+		lit    string
+	}
+	var tokens []token_
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		tokens = append(tokens, token_{file.Offset(pos), tok, lit})
+	}
+
+	sb := &strings.Builder{}
+	cursor := 0
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.tok != token.ILLEGAL || t.lit != "$" {
+			continue
+		}
+		// n is the index of the name, after the * of a $*name list hole
+		star := i+1 < len(tokens) && tokens[i+1].tok == token.MUL && tokens[i+1].offset == t.offset+1
+		n := i + 1
+		if star {
+			n++
+		}
+		if n >= len(tokens) || tokens[n].tok != token.IDENT || tokens[n].offset != t.offset+n-i {
+			return "", nil, fmt.Errorf("%s: $ must be followed by a name", file.Position(file.Pos(t.offset)))
+		}
+		name := tokens[n].lit
+		end := tokens[n].offset + len(name)
+		ellipsis := !star && n+1 < len(tokens) && tokens[n+1].tok == token.ELLIPSIS && tokens[n+1].offset == end
+		list := star || ellipsis
+		if previous, ok := holes[name]; ok && previous != list && name != "_" {
+			return "", nil, fmt.Errorf("hole $%s used as a single hole and a list hole", name)
+		}
+		holes[name] = list
+		sb.WriteString(src[cursor:t.offset])
+		if list {
+			sb.WriteString(listHolePrefix + name)
+		} else {
+			sb.WriteString(holePrefix + name)
+		}
+		if ellipsis {
+			end += len(token.ELLIPSIS.String())
+			n++
+		}
+		cursor = end
+		i = n
+	}
+	sb.WriteString(src[cursor:])
+	return sb.String(), holes, nil
+}
@@ -0,0 +1,240 @@
+package decorator
+
+import (
+	"bytes"
+	"go/token"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator/resolver/guess"
+)
+
+func TestTemplate(t *testing.T) {
+	mustExpr := func(src string) dst.Expr {
+		e, err := ParseExpr(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+	mustStmts := func(src string) []dst.Stmt {
+		s, err := ParseStmts(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	tests := []struct {
+		skip, solo bool
+		name       string
+		kind       string
+		src        string
+		imports    []string
+		args       func() Args
+		expect     string
+	}{
+		{
+			name:    "expr",
+			kind:    "expr",
+			src:     `fmt.Sprint($a, $a /* b */, "$a")`,
+			imports: []string{"fmt"},
+			args:    func() Args { return Args{"a": mustExpr("x + 1")} },
+			expect: `package main
+
+				import "fmt"
+
+				var _ = fmt.Sprint(x+1, x+1 /* b */, "$a")`,
+		},
+		{
+			name: "expr-root",
+			kind: "expr",
+			src:  `/* a */ $x`,
+			args: func() Args { return Args{"x": mustExpr("y")} },
+			expect: `package main
+
+				var _ = /* a */ y`,
+		},
+		{
+			name: "expr-list",
+			kind: "expr",
+			src:  `f(a, $args..., b)`,
+			args: func() Args { return Args{"args": []dst.Expr{mustExpr("1"), mustExpr("2")}} },
+			expect: `package main
+
+				var _ = f(a, 1, 2, b)`,
+		},
+		{
+			name:    "stmts",
+			kind:    "stmts",
+			src:     "if err := $call; err != nil {\n// a\n$body...\n}\n$stmt // b",
+			imports: []string{"yaml gopkg.in/yaml.v2"},
+			args: func() Args {
+				return Args{
+					"call": mustExpr("yaml.Unmarshal(b, &v)"),
+					"body": mustStmts("log(err)\nreturn err"),
+					"stmt": mustStmts("return nil")[0],
+				}
+			},
+			expect: `package main
+
+				func main() {
+					if err := yaml.Unmarshal(b, &v); err != nil {
+						// a
+						log(err)
+						return err
+					}
+					return nil // b
+				}`,
+		},
+		{
+			name:    "decls",
+			kind:    "decls",
+			src:     "// $name does things\nfunc $name($params...) error {\nreturn errors.New(\"$name\")\n}",
+			imports: []string{"errors"},
+			args: func() Args {
+				return Args{
+					"name":   "Foo",
+					"params": []*dst.Field{{Names: []*dst.Ident{dst.NewIdent("a")}, Type: dst.NewIdent("int")}},
+				}
+			},
+			expect: `package main
+
+				import "errors"
+
+				// $name does things
+				func Foo(a int) error {
+					return errors.New("$name")
+				}`,
+		},
+		{
+			name: "qualified-path",
+			kind: "expr",
+			src:  `strings.ToUpper($s)`,
+			imports: []string{
+				"strings",
+			},
+			args: func() Args {
+				return Args{"s": &dst.Ident{Name: "Sprint", Path: "fmt"}}
+			},
+			expect: `package main
+
+				import (
+					"fmt"
+					"strings"
+				)
+
+				var _ = strings.ToUpper(fmt.Sprint)`,
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			if test.skip {
+				t.Skip()
+			}
+			f := &dst.File{Name: dst.NewIdent("main")}
+			switch test.kind {
+			case "expr":
+				tmpl, err := ParseExprTemplate(test.src, test.imports...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expr, err := tmpl.Expr(test.args())
+				if err != nil {
+					t.Fatal(err)
+				}
+				f.Decls = append(f.Decls, &dst.GenDecl{
+					Tok:   token.VAR,
+					Specs: []dst.Spec{&dst.ValueSpec{Names: []*dst.Ident{dst.NewIdent("_")}, Values: []dst.Expr{expr}}},
+				})
+			case "stmts":
+				tmpl, err := ParseStmtsTemplate(test.src, test.imports...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				stmts, err := tmpl.Stmts(test.args())
+				if err != nil {
+					t.Fatal(err)
+				}
+				f.Decls = append(f.Decls, &dst.FuncDecl{
+					Name: dst.NewIdent("main"),
+					Type: &dst.FuncType{},
+					Body: &dst.BlockStmt{List: stmts},
+				})
+			case "decls":
+				tmpl, err := ParseDeclsTemplate(test.src, test.imports...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				decls, err := tmpl.Decls(test.args())
+				if err != nil {
+					t.Fatal(err)
+				}
+				f.Decls = decls
+			}
+			buf := &bytes.Buffer{}
+			if err := NewRestorerWithImports("main", guess.New()).Fprint(buf, f); err != nil {
+				t.Fatal(err)
+			}
+			compare(t, normalize(test.expect), normalize(buf.String()))
+		})
+	}
+
+	t.Run("reuse", func(t *testing.T) {
+		tmpl, err := ParseExprTemplate(`$a + $a`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a := mustExpr("x")
+		expr, err := tmpl.Expr(Args{"a": a})
+		if err != nil {
+			t.Fatal(err)
+		}
+		be := expr.(*dst.BinaryExpr)
+		if be.X != a || be.Y == a {
+			t.Error("expected the first use to be the arg, and the second use to be a clone")
+		}
+		// the template is not modified, so it can be instantiated again
+		expr, err = tmpl.Expr(Args{"a": dst.NewIdent("y")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expr.(*dst.BinaryExpr).Y.(*dst.Ident).Name != "y" {
+			t.Error("expected second instantiation to use new args")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := ParseExprTemplate(`$ a`); err == nil {
+			t.Error("expected error for $ without name")
+		}
+		if _, err := ParseExprTemplate(`f($a, $a...)`); err == nil {
+			t.Error("expected error for hole used as single and list")
+		}
+		tmpl, err := ParseExprTemplate(`f($a)`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tmpl.Expr(Args{}); err == nil {
+			t.Error("expected error for missing arg")
+		}
+		if _, err := tmpl.Expr(Args{"a": 1}); err == nil {
+			t.Error("expected error for invalid arg")
+		}
+		if _, err := tmpl.Expr(Args{"a": mustStmts("return")[0]}); err == nil {
+			t.Error("expected error for stmt in expr position")
+		}
+		if _, err := tmpl.Stmts(Args{"a": mustExpr("x")}); err == nil {
+			t.Error("expected error for wrong template kind")
+		}
+	})
+}
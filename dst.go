@@ -163,6 +163,14 @@ type (
 		Decs  IndexExprDecorations
 	}
 
+	// An IndexListExpr node represents an expression followed by multiple
+	// indices.
+	IndexListExpr struct {
+		X       Expr   // expression
+		Indices []Expr // index expressions
+		Decs    IndexListExprDecorations
+	}
+
 	// An SliceExpr node represents an expression followed by slice indices.
 	SliceExpr struct {
 		X      Expr // expression
@@ -258,10 +266,11 @@ type (
 
 	// A FuncType node represents a function type.
 	FuncType struct {
-		Func    bool
-		Params  *FieldList // (incoming) parameters; non-nil
-		Results *FieldList // (outgoing) results; or nil
-		Decs    FuncTypeDecorations
+		Func       bool
+		TypeParams *FieldList // type parameters; or nil
+		Params     *FieldList // (incoming) parameters; non-nil
+		Results    *FieldList // (outgoing) results; or nil
+		Decs       FuncTypeDecorations
 	}
 
 	// An InterfaceType node represents an interface type.
@@ -298,6 +307,7 @@ func (*CompositeLit) exprNode()   {}
 func (*ParenExpr) exprNode()      {}
 func (*SelectorExpr) exprNode()   {}
 func (*IndexExpr) exprNode()      {}
+func (*IndexListExpr) exprNode()  {}
 func (*SliceExpr) exprNode()      {}
 func (*TypeAssertExpr) exprNode() {}
 func (*CallExpr) exprNode()       {}
@@ -569,10 +579,11 @@ type (
 
 	// A TypeSpec node represents a type declaration (TypeSpec production).
 	TypeSpec struct {
-		Name   *Ident // type name
-		Assign bool   // position of '=', if any
-		Type   Expr   // *Ident, *ParenExpr, *SelectorExpr, *StarExpr, or any of the *XxxTypes
-		Decs   TypeSpecDecorations
+		Name       *Ident     // type name
+		TypeParams *FieldList // type parameters; or nil
+		Assign     bool       // position of '=', if any
+		Type       Expr       // *Ident, *ParenExpr, *SelectorExpr, *StarExpr, or any of the *XxxTypes
+		Decs       TypeSpecDecorations
 	}
 )
 
@@ -620,7 +631,7 @@ type (
 	FuncDecl struct {
 		Recv *FieldList // receiver (methods); or nil (functions)
 		Name *Ident     // function/method name
-		Type *FuncType  // function signature: parameters, results, and position of "func" keyword
+		Type *FuncType  // function signature: type and value parameters, results, and position of "func" keyword
 		Body *BlockStmt // function body; or nil for external (non-Go) function
 		Decs FuncDeclDecorations
 	}
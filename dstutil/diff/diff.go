@@ -0,0 +1,471 @@
+// Package diff compares two dst trees and reports the nodes that were inserted, deleted, moved or
+// modified, rather than the lines of text that changed. It is intended for reviewing the output of
+// codemods - e.g. comparing a file with a modified Clone of itself.
+//
+// Lists (statements, declarations, arguments etc.) are compared using the longest common
+// subsequence of structurally equal elements. Between two unchanged elements, an element that was
+// removed and an element that was added at the same position with the same type are treated as a
+// modification of one node, and compared recursively. A removed node that is structurally equal to
+// an added node elsewhere in the tree is reported as a move.
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dave/dst"
+)
+
+// Kind is the kind of a Change.
+type Kind int
+
+const (
+	// Insert is a node that only exists in the new tree.
+	Insert Kind = iota
+	// Delete is a node that only exists in the old tree.
+	Delete
+	// Move is a node that was moved to a different position. The node may have different
+	// decorations, which are reported by separate Decorate changes.
+	Move
+	// Modify is a node with different fields (e.g. the Name of an Ident or the Tok of an AssignStmt)
+	// or a node that was replaced by a node of a different type.
+	Modify
+	// Decorate is a node with different decorations or spacing.
+	Decorate
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Insert:
+		return "insert"
+	case Delete:
+		return "delete"
+	case Move:
+		return "move"
+	case Modify:
+		return "modify"
+	case Decorate:
+		return "decorate"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Change is a difference between the old and the new tree.
+type Change struct {
+	Kind Kind
+
+	// Old and New are the node in the old and new tree. Old is nil for Insert, and New is nil for
+	// Delete.
+	Old, New dst.Node
+
+	// OldPath and NewPath are the field paths of the node from the root of the old and new tree
+	// (e.g. "Decls[0].Body.List[2]"). The path of the root is "".
+	OldPath, NewPath string
+
+	// Fields lists the fields that are different. For Modify, these are the fields of the node
+	// that are not nodes (e.g. "Name" or "Tok"), and Fields is empty if the node was replaced by a
+	// node of a different type. For Decorate, these are the decoration points (e.g. "Start" or
+	// "After").
+	Fields []string
+}
+
+// String returns a one line description of the change.
+func (c Change) String() string {
+	switch c.Kind {
+	case Insert:
+		return fmt.Sprintf("insert %s %s", path(c.NewPath), describe(c.New))
+	case Delete:
+		return fmt.Sprintf("delete %s %s", path(c.OldPath), describe(c.Old))
+	case Move:
+		return fmt.Sprintf("move %s -> %s %s", path(c.OldPath), path(c.NewPath), describe(c.New))
+	case Modify:
+		if len(c.Fields) == 0 {
+			return fmt.Sprintf("modify %s %s -> %s", path(c.NewPath), describe(c.Old), describe(c.New))
+		}
+		var fields []string
+		for _, name := range c.Fields {
+			old := reflect.ValueOf(c.Old).Elem().FieldByName(name).Interface()
+			new := reflect.ValueOf(c.New).Elem().FieldByName(name).Interface()
+			fields = append(fields, fmt.Sprintf("%s %s -> %s", name, format(old), format(new)))
+		}
+		return fmt.Sprintf("modify %s %T: %s", path(c.NewPath), c.New, strings.Join(fields, ", "))
+	case Decorate:
+		return fmt.Sprintf("decorate %s %s: %s", path(c.NewPath), describe(c.New), strings.Join(c.Fields, ", "))
+	}
+	return c.Kind.String()
+}
+
+// Changes is the list of changes between two trees, in the order they are found walking the trees.
+type Changes []Change
+
+// String returns a description of each change, one per line.
+func (c Changes) String() string {
+	var sb strings.Builder
+	for _, change := range c {
+		sb.WriteString(change.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Summary returns the number of changes of each kind, e.g. "1 inserted, 2 modified".
+func (c Changes) Summary() string {
+	counts := map[Kind]int{}
+	for _, change := range c {
+		counts[change.Kind]++
+	}
+	var parts []string
+	for _, kind := range []struct {
+		Kind
+		verb string
+	}{{Insert, "inserted"}, {Delete, "deleted"}, {Move, "moved"}, {Modify, "modified"}, {Decorate, "decorated"}} {
+		if counts[kind.Kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[kind.Kind], kind.verb))
+		}
+	}
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Files compares two files.
+func Files(old, new *dst.File) Changes {
+	return Nodes(old, new)
+}
+
+// Nodes compares two trees. The roots may be any type of node.
+func Nodes(old, new dst.Node) Changes {
+	d := &differ{}
+	d.node(nilNode(old), nilNode(new), "", "")
+	d.moves()
+	return d.changes
+}
+
+// loose is used to find unchanged and moved nodes. Decorations are compared separately, and clones
+// have no objects.
+var loose = dst.EqualOptions{IgnoreDecorations: true, IgnoreSpacing: true, IgnoreObjects: true}
+
+var nodeType = reflect.TypeOf((*dst.Node)(nil)).Elem()
+
+type differ struct {
+	changes Changes
+}
+
+func (d *differ) add(c Change) {
+	d.changes = append(d.changes, c)
+}
+
+func (d *differ) node(old, new dst.Node, oldPath, newPath string) {
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		d.add(Change{Kind: Insert, New: new, NewPath: newPath})
+		return
+	case new == nil:
+		d.add(Change{Kind: Delete, Old: old, OldPath: oldPath})
+		return
+	case reflect.TypeOf(old) != reflect.TypeOf(new):
+		d.add(Change{Kind: Modify, Old: old, New: new, OldPath: oldPath, NewPath: newPath})
+		return
+	case dst.Equal(old, new, dst.EqualOptions{IgnoreObjects: true}):
+		return
+	}
+
+	ov := reflect.ValueOf(old).Elem()
+	nv := reflect.ValueOf(new).Elem()
+	start := len(d.changes)
+	var modified, decorated []string
+	for i := 0; i < ov.NumField(); i++ {
+		field := ov.Type().Field(i)
+		of, nf := ov.Field(i), nv.Field(i)
+		join := func(p string) string {
+			if p == "" {
+				return field.Name
+			}
+			return p + "." + field.Name
+		}
+		switch {
+		case field.Name == "Decs":
+			decorated = decorations(of, nf)
+		case skip(ov.Type(), field):
+			// ignore
+		case field.Type.Implements(nodeType):
+			d.node(value(of), value(nf), join(oldPath), join(newPath))
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Implements(nodeType):
+			d.list(values(of), values(nf), join(oldPath), join(newPath))
+		case field.Type.Kind() == reflect.Map && field.Type.Elem().Implements(nodeType):
+			d.files(of, nf, join(oldPath), join(newPath))
+		default:
+			if !reflect.DeepEqual(of.Interface(), nf.Interface()) {
+				modified = append(modified, field.Name)
+			}
+		}
+	}
+	// report the changes to the node before the changes to its children
+	var changes Changes
+	if len(modified) > 0 {
+		changes = append(changes, Change{Kind: Modify, Old: old, New: new, OldPath: oldPath, NewPath: newPath, Fields: modified})
+	}
+	if len(decorated) > 0 {
+		changes = append(changes, Change{Kind: Decorate, Old: old, New: new, OldPath: oldPath, NewPath: newPath, Fields: decorated})
+	}
+	d.changes = append(d.changes[:start], append(changes, d.changes[start:]...)...)
+}
+
+func (d *differ) list(old, new []dst.Node, oldPath, newPath string) {
+	index := func(p string, i int) string {
+		return fmt.Sprintf("%s[%d]", p, i)
+	}
+	oldHashes := hashes(old)
+	newHashes := hashes(new)
+	same := func(i, j int) bool {
+		return oldHashes[i] == newHashes[j] && dst.Equal(old[i], new[j], loose)
+	}
+
+	// lengths[i][j] is the length of the longest common subsequence of old[i:] and new[j:]
+	lengths := make([][]int, len(old)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(new)+1)
+	}
+	for i := len(old) - 1; i >= 0; i-- {
+		for j := len(new) - 1; j >= 0; j-- {
+			if same(i, j) {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	// gap compares the elements between two unchanged elements
+	gap := func(i0, i1, j0, j1 int) {
+		for k := 0; i0+k < i1 || j0+k < j1; k++ {
+			i, j := i0+k, j0+k
+			switch {
+			case i < i1 && j < j1 && reflect.TypeOf(old[i]) == reflect.TypeOf(new[j]):
+				d.node(old[i], new[j], index(oldPath, i), index(newPath, j))
+			default:
+				if i < i1 {
+					d.add(Change{Kind: Delete, Old: old[i], OldPath: index(oldPath, i)})
+				}
+				if j < j1 {
+					d.add(Change{Kind: Insert, New: new[j], NewPath: index(newPath, j)})
+				}
+			}
+		}
+	}
+
+	var i, j, i0, j0 int
+	for i < len(old) && j < len(new) {
+		switch {
+		case same(i, j):
+			gap(i0, i, j0, j)
+			// unchanged apart from the decorations
+			d.node(old[i], new[j], index(oldPath, i), index(newPath, j))
+			i++
+			j++
+			i0, j0 = i, j
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	gap(i0, len(old), j0, len(new))
+}
+
+// files compares the Files of two packages by name.
+func (d *differ) files(old, new reflect.Value, oldPath, newPath string) {
+	keys := map[string]bool{}
+	for _, k := range old.MapKeys() {
+		keys[k.String()] = true
+	}
+	for _, k := range new.MapKeys() {
+		keys[k.String()] = true
+	}
+	var names []string
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		k := reflect.ValueOf(name)
+		d.node(value(old.MapIndex(k)), value(new.MapIndex(k)), fmt.Sprintf("%s[%q]", oldPath, name), fmt.Sprintf("%s[%q]", newPath, name))
+	}
+}
+
+// moves pairs deleted nodes with equal inserted nodes.
+func (d *differ) moves() {
+	inserted := map[uint64][]int{}
+	for i, c := range d.changes {
+		if c.Kind == Insert {
+			h := dst.Hash(c.New, loose)
+			inserted[h] = append(inserted[h], i)
+		}
+	}
+	if len(inserted) == 0 {
+		return
+	}
+	moved := map[int]bool{}
+	var changes Changes
+	for i, c := range d.changes {
+		if moved[i] {
+			continue
+		}
+		if c.Kind != Delete {
+			changes = append(changes, c)
+			continue
+		}
+		h := dst.Hash(c.Old, loose)
+		found := -1
+		for _, j := range inserted[h] {
+			if !moved[j] && dst.Equal(c.Old, d.changes[j].New, loose) {
+				found = j
+				break
+			}
+		}
+		if found == -1 {
+			changes = append(changes, c)
+			continue
+		}
+		moved[found] = true
+		ins := d.changes[found]
+		changes = append(changes, Change{Kind: Move, Old: c.Old, New: ins.New, OldPath: c.OldPath, NewPath: ins.NewPath})
+
+		// report the decoration changes of the moved node
+		inner := &differ{}
+		inner.node(c.Old, ins.New, c.OldPath, ins.NewPath)
+		changes = append(changes, inner.changes...)
+	}
+	d.changes = changes
+}
+
+// decorations returns the names of the decoration points that are different.
+func decorations(old, new reflect.Value) []string {
+	var names []string
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		if field.Anonymous {
+			// NodeDecs
+			names = append(names, decorations(old.Field(i), new.Field(i))...)
+			continue
+		}
+		switch o := old.Field(i).Interface().(type) {
+		case dst.SpaceType:
+			if o != new.Field(i).Interface().(dst.SpaceType) {
+				names = append(names, field.Name)
+			}
+		case dst.Decorations:
+			n := new.Field(i).Interface().(dst.Decorations)
+			if len(o) != len(n) {
+				names = append(names, field.Name)
+				continue
+			}
+			for j := range o {
+				if o[j] != n[j] {
+					names = append(names, field.Name)
+					break
+				}
+			}
+		}
+	}
+	return names
+}
+
+// skip returns true for fields that are not compared: objects and scopes, and lists that duplicate
+// nodes in the tree (File.Imports and File.Unresolved).
+func skip(parent reflect.Type, field reflect.StructField) bool {
+	switch field.Type {
+	case reflect.TypeOf((*dst.Object)(nil)), reflect.TypeOf((*dst.Scope)(nil)), reflect.TypeOf(map[string]*dst.Object{}):
+		return true
+	}
+	return parent == reflect.TypeOf(dst.File{}) && (field.Name == "Imports" || field.Name == "Unresolved")
+}
+
+func hashes(nodes []dst.Node) []uint64 {
+	h := make([]uint64, len(nodes))
+	for i, n := range nodes {
+		h[i] = dst.Hash(n, loose)
+	}
+	return h
+}
+
+func value(v reflect.Value) dst.Node {
+	if !v.IsValid() || v.IsNil() {
+		return nil
+	}
+	return v.Interface().(dst.Node)
+}
+
+func values(v reflect.Value) []dst.Node {
+	nodes := make([]dst.Node, v.Len())
+	for i := range nodes {
+		nodes[i] = value(v.Index(i))
+	}
+	return nodes
+}
+
+func nilNode(n dst.Node) dst.Node {
+	if v := reflect.ValueOf(n); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	return n
+}
+
+func path(p string) string {
+	if p == "" {
+		return "."
+	}
+	return p
+}
+
+// describe returns the type of the node, and the name or value for nodes that have one.
+func describe(n dst.Node) string {
+	var name string
+	switch n := n.(type) {
+	case *dst.Ident:
+		name = n.Name
+	case *dst.BasicLit:
+		name = n.Value
+	case *dst.FuncDecl:
+		name = n.Name.Name
+	case *dst.TypeSpec:
+		name = n.Name.Name
+	case *dst.ImportSpec:
+		name = n.Path.Value
+	case *dst.ValueSpec:
+		name = identNames(n.Names)
+	case *dst.Field:
+		name = identNames(n.Names)
+	case *dst.GenDecl:
+		if len(n.Specs) > 0 {
+			name = n.Tok.String() + " " + strings.TrimPrefix(describe(n.Specs[0]), fmt.Sprintf("%T ", n.Specs[0]))
+		}
+	}
+	if name == "" {
+		return fmt.Sprintf("%T", n)
+	}
+	return fmt.Sprintf("%T %s", n, name)
+}
+
+func identNames(idents []*dst.Ident) string {
+	var names []string
+	for _, id := range idents {
+		names = append(names, id.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func format(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
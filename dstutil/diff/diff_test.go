@@ -0,0 +1,155 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/dstutil/diff"
+)
+
+func TestFiles(t *testing.T) {
+	tests := []struct {
+		skip, solo bool
+		name       string
+		old, new   string
+		expect     string
+		summary    string
+	}{
+		{
+			name:    "same",
+			old:     "package a\n\nfunc f() { g(1) }",
+			new:     "package a\n\nfunc f() { g(1) }",
+			summary: "no changes",
+		},
+		{
+			name:    "value",
+			old:     "package a\n\nfunc f() { g(1) }",
+			new:     "package a\n\nfunc f() { g(2) }",
+			expect:  `modify Decls[0].Body.List[0].X.Args[0] *dst.BasicLit: Value "1" -> "2"`,
+			summary: "1 modified",
+		},
+		{
+			name:    "token",
+			old:     "package a\n\nfunc f() { a = b }",
+			new:     "package a\n\nfunc f() { a := b }",
+			expect:  "modify Decls[0].Body.List[0] *dst.AssignStmt: Tok = -> :=",
+			summary: "1 modified",
+		},
+		{
+			name:   "replace",
+			old:    "package a\n\nvar a = b.c",
+			new:    "package a\n\nvar a = (*b).c",
+			expect: "modify Decls[0].Specs[0].Values[0].X *dst.Ident b -> *dst.ParenExpr",
+		},
+		{
+			name:    "insert",
+			old:     "package a\n\nfunc f() {\n\ta()\n\tc()\n}",
+			new:     "package a\n\nfunc f() {\n\ta()\n\tb()\n\tc()\n}",
+			expect:  "insert Decls[0].Body.List[1] *dst.ExprStmt",
+			summary: "1 inserted",
+		},
+		{
+			name:    "delete",
+			old:     "package a\n\nvar a int\n\nfunc f() {}",
+			new:     "package a\n\nfunc f() {}",
+			expect:  "delete Decls[0] *dst.GenDecl var a",
+			summary: "1 deleted",
+		},
+		{
+			name: "move",
+			old:  "package a\n\nfunc f() {}\n\nfunc g() {}",
+			new:  "package a\n\nfunc g() {}\n\nfunc f() {}",
+			expect: `move Decls[0] -> Decls[1] *dst.FuncDecl f
+decorate Decls[1] *dst.FuncDecl f: After
+decorate Decls[0] *dst.FuncDecl g: After`,
+			summary: "1 moved, 2 decorated",
+		},
+		{
+			name:   "move between blocks",
+			old:    "package a\n\nfunc f() {\n\ta()\n\tb()\n}\n\nfunc g() {}",
+			new:    "package a\n\nfunc f() {\n\ta()\n}\n\nfunc g() {\n\tb()\n}",
+			expect: "move Decls[0].Body.List[1] -> Decls[1].Body.List[0] *dst.ExprStmt",
+		},
+		{
+			name:    "comment",
+			old:     "package a\n\nfunc f() {}",
+			new:     "package a\n\n// f does nothing\nfunc f() {}",
+			expect:  "decorate Decls[0] *dst.FuncDecl f: Start",
+			summary: "1 decorated",
+		},
+		{
+			name:   "spacing",
+			old:    "package a\n\nfunc f() {\n\ta()\n\tb()\n}",
+			new:    "package a\n\nfunc f() {\n\ta()\n\n\tb()\n}",
+			expect: "decorate Decls[0].Body.List[0] *dst.ExprStmt: After\ndecorate Decls[0].Body.List[1] *dst.ExprStmt: Before",
+		},
+		{
+			name: "rename",
+			old:  "package a\n\n// f\nfunc f() {}",
+			new:  "package a\n\n// g\nfunc g() {}",
+			expect: `decorate Decls[0] *dst.FuncDecl g: Start
+modify Decls[0].Name *dst.Ident: Name "f" -> "g"`,
+			summary: "1 modified, 1 decorated",
+		},
+		{
+			name: "mixed",
+			old:  "package a\n\nfunc f() {\n\ta(1)\n\tb()\n\tc()\n}",
+			new:  "package a\n\nfunc f() {\n\ta(2)\n\td := 1\n\tc()\n}",
+			expect: `modify Decls[0].Body.List[0].X.Args[0] *dst.BasicLit: Value "1" -> "2"
+delete Decls[0].Body.List[1] *dst.ExprStmt
+insert Decls[0].Body.List[1] *dst.AssignStmt`,
+			summary: "1 inserted, 1 deleted, 1 modified",
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		if test.skip {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			old, err := decorator.Parse(test.old)
+			if err != nil {
+				t.Fatal(err)
+			}
+			new, err := decorator.Parse(test.new)
+			if err != nil {
+				t.Fatal(err)
+			}
+			changes := diff.Files(old, new)
+			if found := strings.TrimSpace(changes.String()); found != strings.TrimSpace(test.expect) {
+				t.Errorf("expected:\n%s\nfound:\n%s", strings.TrimSpace(test.expect), found)
+			}
+			if test.summary != "" && changes.Summary() != test.summary {
+				t.Errorf("expected summary %q, found %q", test.summary, changes.Summary())
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	f, err := decorator.Parse("package a\n\nfunc f() {\n\ta()\n}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := dst.Clone(f).(*dst.File)
+	if changes := diff.Files(f, c); len(changes) != 0 {
+		t.Fatalf("expected no changes, found:\n%s", changes)
+	}
+	body := c.Decls[0].(*dst.FuncDecl).Body
+	body.List = append(body.List, &dst.ReturnStmt{})
+	changes := diff.Files(f, c)
+	if len(changes) != 1 || changes[0].Kind != diff.Insert || changes[0].New != body.List[1] {
+		t.Fatalf("expected insert of return statement, found:\n%s", changes)
+	}
+}
@@ -0,0 +1,81 @@
+package dstutil
+
+import (
+	"go/token"
+
+	"github.com/dave/dst"
+)
+
+// Positions reports the original source positions of dst nodes. It is implemented by
+// *decorator.Decorator, using the ast nodes in its Map and the positions in its Fset.
+type Positions interface {
+	// Pos returns the start and end positions of the node that n was decorated from. ok is false
+	// if n has no position (e.g. it was created after decoration).
+	Pos(n dst.Node) (start, end token.Pos, ok bool)
+}
+
+// PathEnclosingInterval returns the node that encloses the source interval [start, end), and all
+// its ancestors up to the root. The first element of path is the innermost node and the last is
+// root. It is the dst equivalent of astutil.PathEnclosingInterval, and positions are the original
+// positions of the nodes reported by p (e.g. a *decorator.Decorator).
+//
+// Nodes that were merged during decoration are treated as a single node - e.g. a qualified
+// identifier (a *dst.Ident with Path set) encloses the whole of the selector expression it was
+// decorated from. Nodes with no position (e.g. nodes added after decoration) never enclose the
+// interval, but their children are searched.
+//
+// exact is true if the interval is exactly the range of the innermost node. An empty interval is
+// treated as the character that follows it. If the interval is not within root, path contains only
+// root.
+func PathEnclosingInterval(p Positions, root dst.Node, start, end token.Pos) (path []dst.Node, exact bool) {
+	if start > end {
+		start, end = end, start
+	}
+	s, e, ok := p.Pos(root)
+	if start == end && (!ok || end < e) {
+		// like astutil, an empty interval is treated as the character that follows it
+		end++
+	}
+	if ok && (start < s || end > e) {
+		return []dst.Node{root}, false
+	}
+
+	var visit func(n dst.Node) bool
+	visit = func(n dst.Node) bool {
+		s, e, ok := p.Pos(n)
+		if ok && (start < s || end > e) {
+			return false
+		}
+		for _, child := range children(n) {
+			if visit(child) {
+				path = append(path, n)
+				return true
+			}
+		}
+		if !ok {
+			return false
+		}
+		path = append(path, n)
+		exact = start == s && end == e
+		return true
+	}
+	if !visit(root) {
+		return []dst.Node{root}, false
+	}
+	return path, exact
+}
+
+// children returns the direct children of n in the order they are walked by dst.Inspect.
+func children(n dst.Node) []dst.Node {
+	var nodes []dst.Node
+	dst.Inspect(n, func(c dst.Node) bool {
+		if c == n {
+			return true
+		}
+		if c != nil {
+			nodes = append(nodes, c)
+		}
+		return false
+	})
+	return nodes
+}
@@ -0,0 +1,144 @@
+package dstutil_test
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/decorator/resolver/goast"
+	"github.com/dave/dst/dstutil"
+)
+
+func TestPathEnclosingInterval(t *testing.T) {
+	src := `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("a", b+c)
+}
+`
+	tests := []struct {
+		skip, solo bool
+		name       string
+		substr     string // interval is the first occurrence of substr, or the position of "|" in it
+		expect     string
+		exact      bool
+	}{
+		{
+			name:   "literal",
+			substr: `"a"`,
+			expect: `BasicLit CallExpr ExprStmt BlockStmt FuncDecl File`,
+			exact:  true,
+		},
+		{
+			name:   "qualified-ident",
+			substr: `Println`,
+			expect: `Ident(fmt.Println) CallExpr ExprStmt BlockStmt FuncDecl File`,
+		},
+		{
+			name:   "qualified-ident-package",
+			substr: `fmt.Println`,
+			expect: `Ident(fmt.Println) CallExpr ExprStmt BlockStmt FuncDecl File`,
+			exact:  true,
+		},
+		{
+			name:   "point",
+			substr: `b|+`,
+			expect: `BinaryExpr CallExpr ExprStmt BlockStmt FuncDecl File`,
+		},
+		{
+			name:   "spanning",
+			substr: `"a", b`,
+			expect: `CallExpr ExprStmt BlockStmt FuncDecl File`,
+		},
+		{
+			name:   "import",
+			substr: `"fmt"`,
+			expect: `BasicLit ImportSpec GenDecl File`,
+			exact:  true,
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			if test.skip {
+				t.Skip()
+			}
+			d := decorator.NewDecoratorWithImports(token.NewFileSet(), "main", goast.New())
+			f, err := d.Parse(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var start, end int
+			if i := strings.Index(test.substr, "|"); i >= 0 {
+				start = strings.Index(src, strings.Replace(test.substr, "|", "", 1)) + i
+				end = start
+			} else {
+				start = strings.Index(src, test.substr)
+				end = start + len(test.substr)
+			}
+			path, exact, err := d.PathEnclosingInterval(f, start, end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var found []string
+			for _, n := range path {
+				if id, ok := n.(*dst.Ident); ok {
+					found = append(found, fmt.Sprintf("Ident(%s.%s)", id.Path, id.Name))
+					continue
+				}
+				found = append(found, strings.TrimPrefix(fmt.Sprintf("%T", n), "*dst."))
+			}
+			if strings.Join(found, " ") != test.expect {
+				t.Errorf("expect %s, found %s", test.expect, strings.Join(found, " "))
+			}
+			if exact != test.exact {
+				t.Errorf("expect exact %v, found %v", test.exact, exact)
+			}
+		})
+	}
+
+	t.Run("synthetic", func(t *testing.T) {
+		d := decorator.NewDecorator(token.NewFileSet())
+		f, err := d.Parse(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// wrap the call in a new node: it has no position, but the call inside it is still found
+		body := f.Decls[1].(*dst.FuncDecl).Body
+		body.List[0] = &dst.BlockStmt{List: []dst.Stmt{body.List[0]}}
+		offset := strings.Index(src, `"a"`)
+		path, _, err := d.PathEnclosingInterval(f, offset, offset+3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(path) != 7 || path[3] != body.List[0] {
+			t.Errorf("unexpected path %v", path)
+		}
+		// an interval outside the root returns only the root
+		fd := f.Decls[1]
+		start, end, _ := d.Pos(f.Decls[0])
+		if path, exact := dstutil.PathEnclosingInterval(d, fd, start, end); len(path) != 1 || path[0] != fd || exact {
+			t.Errorf("unexpected path %v", path)
+		}
+		if _, _, err := d.PathEnclosingInterval(&dst.File{}, 0, 0); err != decorator.ErrSyntheticNode {
+			t.Errorf("expected ErrSyntheticNode, found %v", err)
+		}
+		if _, _, err := d.PathEnclosingInterval(f, 0, len(src)+1); err == nil {
+			t.Error("expected error for interval outside the file")
+		}
+	})
+}
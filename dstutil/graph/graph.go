@@ -0,0 +1,194 @@
+// Package graph renders dst trees for debugging and teaching: as a Graphviz DOT graph, or as a
+// self-contained HTML page. Each node is shown with its type, the field of the parent that contains
+// it, its values (e.g. the Name of an Ident), its spacing, and every decoration attachment point
+// reported by dstutil.Decorations with its decorations.
+package graph
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/dstutil"
+)
+
+// item is a node in the rendered tree.
+type item struct {
+	ID       int
+	Field    string // field of the parent that contains the node, e.g. "Decls[0]"
+	Type     string
+	Values   []value
+	Before   dst.SpaceType
+	After    dst.SpaceType
+	Points   []dstutil.DecorationPoint
+	Children []*item
+}
+
+type value struct {
+	Name, Value string
+}
+
+// build walks the tree rooted at n.
+func build(n dst.Node) *item {
+	var root *item
+	var stack []*item
+	var id int
+	dstutil.Apply(n, func(c *dstutil.Cursor) bool {
+		if c.Node() == nil {
+			return true
+		}
+		it := &item{ID: id, Type: fmt.Sprintf("%T", c.Node()), Values: values(c.Node())}
+		id++
+		it.Before, it.After, it.Points = dstutil.Decorations(c.Node())
+		if len(stack) == 0 {
+			root = it
+		} else {
+			it.Field = c.Name()
+			if c.Index() >= 0 {
+				it.Field = fmt.Sprintf("%s[%d]", c.Name(), c.Index())
+			}
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, it)
+		}
+		stack = append(stack, it)
+		return true
+	}, func(c *dstutil.Cursor) bool {
+		if c.Node() == nil {
+			return true
+		}
+		stack = stack[:len(stack)-1]
+		return true
+	})
+	return root
+}
+
+var (
+	nodeType   = reflect.TypeOf((*dst.Node)(nil)).Elem()
+	objectType = reflect.TypeOf((*dst.Object)(nil))
+	scopeType  = reflect.TypeOf((*dst.Scope)(nil))
+)
+
+// values returns the fields of n that are not nodes, decorations, objects or scopes, and are not
+// empty.
+func values(n dst.Node) []value {
+	var values []value
+	v := reflect.ValueOf(n).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		f := v.Field(i)
+		switch {
+		case field.Name == "Decs",
+			field.Type.Implements(nodeType),
+			field.Type == objectType,
+			field.Type == scopeType,
+			field.Type.Kind() == reflect.Slice,
+			field.Type.Kind() == reflect.Map,
+			f.IsZero():
+			continue
+		}
+		var s string
+		switch x := f.Interface().(type) {
+		case string:
+			s = strconv.Quote(x)
+		case token.Token:
+			s = x.String()
+		default:
+			s = fmt.Sprint(x)
+		}
+		values = append(values, value{field.Name, s})
+	}
+	return values
+}
+
+// label returns the text used to describe a list of decorations.
+func label(decs []string) []string {
+	var out []string
+	for _, d := range decs {
+		if d == "\n" {
+			d = `"\n"`
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// Dot writes the tree rooted at n as a Graphviz DOT graph, e.g. to be rendered with
+// "dot -Tsvg". Each node is a table with its type, values, spacing and decoration points, and
+// the edges are labelled with the fields of the parent.
+func Dot(w io.Writer, n dst.Node) error {
+	root := build(n)
+	ew := &errWriter{w: w}
+	ew.printf("digraph dst {\n")
+	ew.printf("\tnode [shape=plaintext fontname=\"monospace\"];\n")
+	var visit func(it *item)
+	visit = func(it *item) {
+		ew.printf("\tn%d [label=<<table border=\"0\" cellborder=\"1\" cellspacing=\"0\">", it.ID)
+		ew.printf("<tr><td colspan=\"2\" bgcolor=\"lightgrey\"><b>%s</b></td></tr>", escape(it.Type))
+		for _, v := range it.Values {
+			ew.printf("<tr><td>%s</td><td>%s</td></tr>", escape(v.Name), escape(v.Value))
+		}
+		if it.Type != "*dst.Package" {
+			ew.printf("<tr><td><i>Before</i></td><td>%s</td></tr>", it.Before)
+		}
+		for _, p := range it.Points {
+			ew.printf("<tr><td><i>%s</i></td><td align=\"left\">", escape(p.Name))
+			for i, d := range label(p.Decs) {
+				if i > 0 {
+					ew.printf("<br align=\"left\"/>")
+				}
+				ew.printf("%s", escape(d))
+			}
+			ew.printf("</td></tr>")
+		}
+		if it.Type != "*dst.Package" {
+			ew.printf("<tr><td><i>After</i></td><td>%s</td></tr>", it.After)
+		}
+		ew.printf("</table>>];\n")
+		for _, child := range it.Children {
+			visit(child)
+			ew.printf("\tn%d -> n%d [label=%q];\n", it.ID, child.ID, child.Field)
+		}
+	}
+	if root != nil {
+		visit(root)
+	}
+	ew.printf("}\n")
+	return ew.err
+}
+
+// escape escapes text for a DOT HTML-like label.
+func escape(s string) string {
+	var out []byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '&':
+			out = append(out, "&amp;"...)
+		case '<':
+			out = append(out, "&lt;"...)
+		case '>':
+			out = append(out, "&gt;"...)
+		case '"':
+			out = append(out, "&quot;"...)
+		case '\n':
+			out = append(out, "<br/>"...)
+		default:
+			out = append(out, c)
+		}
+	}
+	return string(out)
+}
+
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) printf(format string, args ...interface{}) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = fmt.Fprintf(e.w, format, args...)
+}
@@ -0,0 +1,67 @@
+package graph_test
+
+import (
+	"bytes"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/dstutil/graph"
+)
+
+func TestDot(t *testing.T) {
+	n := &dst.BinaryExpr{
+		X:  dst.NewIdent("a"),
+		Op: token.ADD,
+		Y:  &dst.BasicLit{Kind: token.STRING, Value: `"<b>"`},
+	}
+	n.Decs.Before = dst.NewLine
+	n.Decs.X.Append("/* a & b */")
+	buf := &bytes.Buffer{}
+	if err := graph.Dot(buf, n); err != nil {
+		t.Fatal(err)
+	}
+	expect := `digraph dst {
+	node [shape=plaintext fontname="monospace"];
+	n0 [label=<<table border="0" cellborder="1" cellspacing="0"><tr><td colspan="2" bgcolor="lightgrey"><b>*dst.BinaryExpr</b></td></tr><tr><td>Op</td><td>+</td></tr><tr><td><i>Before</i></td><td>NewLine</td></tr><tr><td><i>Start</i></td><td align="left"></td></tr><tr><td><i>X</i></td><td align="left">/* a &amp; b */</td></tr><tr><td><i>Op</i></td><td align="left"></td></tr><tr><td><i>End</i></td><td align="left"></td></tr><tr><td><i>After</i></td><td>None</td></tr></table>>];
+	n1 [label=<<table border="0" cellborder="1" cellspacing="0"><tr><td colspan="2" bgcolor="lightgrey"><b>*dst.Ident</b></td></tr><tr><td>Name</td><td>&quot;a&quot;</td></tr><tr><td><i>Before</i></td><td>None</td></tr><tr><td><i>Start</i></td><td align="left"></td></tr><tr><td><i>X</i></td><td align="left"></td></tr><tr><td><i>End</i></td><td align="left"></td></tr><tr><td><i>After</i></td><td>None</td></tr></table>>];
+	n0 -> n1 [label="X"];
+	n2 [label=<<table border="0" cellborder="1" cellspacing="0"><tr><td colspan="2" bgcolor="lightgrey"><b>*dst.BasicLit</b></td></tr><tr><td>Kind</td><td>STRING</td></tr><tr><td>Value</td><td>&quot;\&quot;&lt;b&gt;\&quot;&quot;</td></tr><tr><td><i>Before</i></td><td>None</td></tr><tr><td><i>Start</i></td><td align="left"></td></tr><tr><td><i>End</i></td><td align="left"></td></tr><tr><td><i>After</i></td><td>None</td></tr></table>>];
+	n0 -> n2 [label="Y"];
+}
+`
+	if buf.String() != expect {
+		t.Fatalf("expected:\n%s\nfound:\n%s", expect, buf.String())
+	}
+}
+
+func TestHTML(t *testing.T) {
+	f, err := decorator.Parse(`package a
+
+// f is <b>bold</b>
+func f() {
+	a()
+
+	b()
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if err := graph.HTML(buf, f); err != nil {
+		t.Fatal(err)
+	}
+	for _, expect := range []string{
+		`<span class="field">Decls[0]:</span> <span class="type">*dst.FuncDecl</span>`,
+		`<tr class="decorated"><td class="point">Start</td><td><pre>// f is &lt;b&gt;bold&lt;/b&gt;</pre></td></tr>`,
+		`<span class="field">List[1]:</span> <span class="type">*dst.ExprStmt</span>`,
+		`<tr><td class="point">Before</td><td>EmptyLine</td></tr>`,
+		`<span class="type">*dst.Ident</span> <span class="value">Name=&#34;b&#34;</span>`,
+	} {
+		if !strings.Contains(buf.String(), expect) {
+			t.Errorf("expected output to contain %s", expect)
+		}
+	}
+}
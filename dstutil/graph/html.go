@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/dave/dst"
+)
+
+// HTML writes the tree rooted at n as a self-contained HTML page. The tree is rendered as nested
+// collapsible elements, each showing the field of the parent, the type, values, spacing and
+// decoration points of the node. Decoration points with decorations are highlighted.
+func HTML(w io.Writer, n dst.Node) error {
+	return htmlTemplate.Execute(w, build(n))
+}
+
+var htmlTemplate = template.Must(template.New("page").Funcs(template.FuncMap{"label": label}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ if . }}{{ .Type }}{{ end }}</title>
+<style>
+body { font-family: monospace; font-size: 13px; }
+details { margin-left: 1.5em; border-left: 1px dotted #ccc; padding-left: 0.5em; }
+summary { cursor: pointer; }
+.field { color: #888; }
+.type { font-weight: bold; }
+.value { color: #05a; }
+table { border-collapse: collapse; margin: 0.2em 0 0.4em 1.5em; }
+td { border: 1px solid #ddd; padding: 0 0.4em; vertical-align: top; }
+td.point { font-style: italic; color: #888; }
+tr.decorated td { background: #ffd; color: #000; }
+pre { margin: 0; }
+</style>
+</head>
+<body>
+{{ if . }}{{ template "node" . }}{{ end }}
+</body>
+</html>
+{{ define "node" -}}
+<details open>
+<summary>{{ if .Field }}<span class="field">{{ .Field }}:</span> {{ end }}<span class="type">{{ .Type }}</span>{{ range .Values }} <span class="value">{{ .Name }}={{ .Value }}</span>{{ end }}</summary>
+<table>
+{{- if ne .Type "*dst.Package" }}
+<tr><td class="point">Before</td><td>{{ .Before }}</td></tr>
+{{- end }}
+{{- range .Points }}
+<tr{{ if .Decs }} class="decorated"{{ end }}><td class="point">{{ .Name }}</td><td>{{ range label .Decs }}<pre>{{ . }}</pre>{{ end }}</td></tr>
+{{- end }}
+{{- if ne .Type "*dst.Package" }}
+<tr><td class="point">After</td><td>{{ .After }}</td></tr>
+{{- end }}
+</table>
+{{- range .Children }}
+{{ template "node" . }}
+{{- end }}
+</details>
+{{- end }}`))
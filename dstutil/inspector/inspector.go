@@ -0,0 +1,242 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package inspector provides helper functions for traversal over the
+// syntax trees of a package, including node filtering by type, and
+// materialization of the traversal stack.
+//
+// During construction, the inspector does a complete traversal and
+// builds a list of push/pop events and their node type. Subsequent
+// method calls that request a traversal scan this list, rather than walk
+// the tree, so running many analyses over the same trees is much faster
+// than calling dst.Inspect for each one.
+//
+// The event list is rebuilt before the next traversal if the trees have
+// been modified by the Cursor methods of dstutil.Apply. Trees that are
+// modified in other ways must be followed by a call to Invalidate.
+//
+// This package is a fork of golang.org/x/tools/go/ast/inspector.
+package inspector
+
+import (
+	"sync"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/dstutil"
+)
+
+// An Inspector provides methods for inspecting
+// (traversing) the syntax trees of a package.
+type Inspector struct {
+	roots []dst.Node
+
+	m       sync.Mutex
+	events  []event
+	watcher *dstutil.Watcher // detects modifications of the nodes in events by dstutil.Apply
+	stale   bool
+}
+
+// New returns an Inspector for the specified syntax trees.
+func New(files []*dst.File) *Inspector {
+	in := &Inspector{stale: true}
+	for _, f := range files {
+		in.roots = append(in.roots, f)
+	}
+	in.list()
+	return in
+}
+
+// NewPackages returns an Inspector for the syntax trees of the specified packages.
+func NewPackages(pkgs ...*decorator.Package) *Inspector {
+	var files []*dst.File
+	for _, p := range pkgs {
+		files = append(files, p.Syntax...)
+	}
+	return New(files)
+}
+
+// An event represents a push or a pop
+// of an dst.Node during a traversal.
+type event struct {
+	node  dst.Node
+	typ   uint64 // typeOf(node) on push event, or union of typ strictly between push and pop events on pop events
+	index int    // index of corresponding push or pop event
+}
+
+// Invalidate discards the event list, so it is rebuilt before the next
+// traversal. It must be called after the trees are modified other than
+// by the Cursor methods of dstutil.Apply.
+func (in *Inspector) Invalidate() {
+	in.m.Lock()
+	defer in.m.Unlock()
+	in.stale = true
+}
+
+// list returns the event list, rebuilding it if it may be stale.
+func (in *Inspector) list() []event {
+	in.m.Lock()
+	defer in.m.Unlock()
+	if in.stale || in.watcher.Modified() {
+		in.events = traverse(in.roots)
+		nodes := make([]dst.Node, 0, len(in.events)/2)
+		for i, ev := range in.events {
+			if ev.index > i {
+				nodes = append(nodes, ev.node)
+			}
+		}
+		in.watcher = dstutil.Watch(nodes)
+		in.stale = false
+	}
+	return in.events
+}
+
+// Preorder visits all the nodes of the trees in depth-first
+// order. It calls f(n) for each node n before it visits n's children.
+//
+// The complete traversal sequence is determined by dst.Inspect.
+// The types argument, if non-empty, enables type-based filtering of
+// events. The function f is called only for nodes whose type
+// matches an element of the types slice.
+func (in *Inspector) Preorder(types []dst.Node, f func(dst.Node)) {
+	// Because it avoids postorder calls to f, and the pruning
+	// check, Preorder is almost twice as fast as Nodes. The two
+	// features seem to contribute similar slowdowns (~1.4x each).
+
+	mask := maskOf(types)
+	events := in.list()
+	for i := 0; i < len(events); {
+		ev := events[i]
+		if ev.index > i {
+			// push
+			if ev.typ&mask != 0 {
+				f(ev.node)
+			}
+			pop := ev.index
+			if events[pop].typ&mask == 0 {
+				// Subtrees do not contain types: skip them and pop.
+				i = pop + 1
+				continue
+			}
+		}
+		i++
+	}
+}
+
+// Nodes visits the nodes of the trees in depth-first order. It
+// calls f(n, true) for each node n before it visits n's children. If
+// f returns true, Nodes invokes f recursively for each of the non-nil
+// children of the node, followed by a call of f(n, false).
+//
+// The complete traversal sequence is determined by dst.Inspect.
+// The types argument, if non-empty, enables type-based filtering of
+// events. The function f if is called only for nodes whose type
+// matches an element of the types slice.
+func (in *Inspector) Nodes(types []dst.Node, f func(n dst.Node, push bool) (proceed bool)) {
+	mask := maskOf(types)
+	events := in.list()
+	for i := 0; i < len(events); {
+		ev := events[i]
+		if ev.index > i {
+			// push
+			pop := ev.index
+			if ev.typ&mask != 0 {
+				if !f(ev.node, true) {
+					i = pop + 1 // jump to corresponding pop + 1
+					continue
+				}
+			}
+			if events[pop].typ&mask == 0 {
+				// Subtrees do not contain types: skip them.
+				i = pop
+				continue
+			}
+		} else {
+			// pop
+			push := ev.index
+			if events[push].typ&mask != 0 {
+				f(ev.node, false)
+			}
+		}
+		i++
+	}
+}
+
+// WithStack visits nodes in a similar manner to Nodes, but it
+// supplies each call to f an additional argument, the current
+// traversal stack. The stack's first element is the outermost node,
+// a *dst.File; its last is the innermost, n.
+func (in *Inspector) WithStack(types []dst.Node, f func(n dst.Node, push bool, stack []dst.Node) (proceed bool)) {
+	mask := maskOf(types)
+	events := in.list()
+	var stack []dst.Node
+	for i := 0; i < len(events); {
+		ev := events[i]
+		if ev.index > i {
+			// push
+			pop := ev.index
+			stack = append(stack, ev.node)
+			if ev.typ&mask != 0 {
+				if !f(ev.node, true, stack) {
+					i = pop + 1
+					stack = stack[:len(stack)-1]
+					continue
+				}
+			}
+			if events[pop].typ&mask == 0 {
+				// Subtrees does not contain types: skip them.
+				i = pop
+				continue
+			}
+		} else {
+			// pop
+			push := ev.index
+			if events[push].typ&mask != 0 {
+				f(ev.node, false, stack)
+			}
+			stack = stack[:len(stack)-1]
+		}
+		i++
+	}
+}
+
+// traverse builds the table of events representing a traversal.
+func traverse(roots []dst.Node) []event {
+	// This estimate is based on the net/http package.
+	events := make([]event, 0, len(roots)*4096)
+
+	var stack []event
+	stack = append(stack, event{}) // include an extra event so file nodes have a parent
+	for _, root := range roots {
+		dst.Inspect(root, func(n dst.Node) bool {
+			if n != nil {
+				// push
+				ev := event{
+					node:  n,
+					typ:   0,           // temporarily used to accumulate type bits of subtree
+					index: len(events), // push event temporarily holds own index
+				}
+				stack = append(stack, ev)
+				events = append(events, ev)
+			} else {
+				// pop
+				top := len(stack) - 1
+				ev := stack[top]
+				typ := typeOf(ev.node)
+				push := ev.index
+				parent := top - 1
+
+				events[push].typ = typ            // set type of push
+				stack[parent].typ |= typ | ev.typ // parent's typ contains push and pop's typs.
+				events[push].index = len(events)  // make push refer to pop
+
+				stack = stack[:top]
+				events = append(events, ev)
+			}
+			return true
+		})
+	}
+
+	return events
+}
@@ -0,0 +1,250 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inspector_test
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/dstutil"
+	"github.com/dave/dst/dstutil/inspector"
+)
+
+// netFiles decorates the source of the dst package, which is large enough to exercise the
+// inspector.
+func netFiles(t testing.TB) []*dst.File {
+	var files []*dst.File
+	d := decorator.NewDecorator(token.NewFileSet())
+	for _, name := range []string{"../../dst.go", "../../walk.go", "../../clone-generated.go"} {
+		f, err := d.ParseFile(name, nil, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+// TestInspectAllNodes compares the results of dst.Inspect and Inspector.Nodes.
+func TestInspectAllNodes(t *testing.T) {
+	files := netFiles(t)
+	inspect := inspector.New(files)
+
+	var nodesA []dst.Node
+	inspect.Nodes(nil, func(n dst.Node, push bool) bool {
+		if push {
+			nodesA = append(nodesA, n)
+		}
+		return true
+	})
+	var nodesB []dst.Node
+	for _, f := range files {
+		dst.Inspect(f, func(n dst.Node) bool {
+			if n != nil {
+				nodesB = append(nodesB, n)
+			}
+			return true
+		})
+	}
+	compare(t, nodesA, nodesB)
+}
+
+// TestPruning compares Inspector against dst.Inspect,
+// pruning descent within dst.BlockStmt.
+func TestInspectPruning(t *testing.T) {
+	files := netFiles(t)
+	inspect := inspector.New(files)
+
+	var nodesA []dst.Node
+	inspect.Nodes(nil, func(n dst.Node, push bool) bool {
+		if push {
+			nodesA = append(nodesA, n)
+			_, isBlock := n.(*dst.BlockStmt)
+			return !isBlock // descend only if not a block
+		}
+		return true
+	})
+	var nodesB []dst.Node
+	for _, f := range files {
+		dst.Inspect(f, func(n dst.Node) bool {
+			if n != nil {
+				nodesB = append(nodesB, n)
+				_, isBlock := n.(*dst.BlockStmt)
+				return !isBlock // descend only if not a block
+			}
+			return false
+		})
+	}
+	compare(t, nodesA, nodesB)
+}
+
+func TestPreorder(t *testing.T) {
+	files := netFiles(t)
+	inspect := inspector.New(files)
+
+	types := []dst.Node{(*dst.CallExpr)(nil), (*dst.FuncDecl)(nil)}
+
+	var nodesA []dst.Node
+	inspect.Preorder(types, func(n dst.Node) {
+		nodesA = append(nodesA, n)
+	})
+	var nodesB []dst.Node
+	for _, f := range files {
+		dst.Inspect(f, func(n dst.Node) bool {
+			switch n.(type) {
+			case *dst.CallExpr, *dst.FuncDecl:
+				nodesB = append(nodesB, n)
+			}
+			return true
+		})
+	}
+	compare(t, nodesA, nodesB)
+}
+
+func TestWithStack(t *testing.T) {
+	files := netFiles(t)
+	inspect := inspector.New(files)
+
+	// the stack of each ident matches the stack built by dst.Inspect
+	var stacksA [][]dst.Node
+	inspect.WithStack([]dst.Node{(*dst.Ident)(nil)}, func(n dst.Node, push bool, stack []dst.Node) bool {
+		if push {
+			stacksA = append(stacksA, append([]dst.Node(nil), stack...))
+		}
+		return true
+	})
+	var stacksB [][]dst.Node
+	for _, f := range files {
+		var stack []dst.Node
+		dst.Inspect(f, func(n dst.Node) bool {
+			if n == nil {
+				stack = stack[:len(stack)-1]
+				return true
+			}
+			stack = append(stack, n)
+			if _, ok := n.(*dst.Ident); ok {
+				stacksB = append(stacksB, append([]dst.Node(nil), stack...))
+			}
+			return true
+		})
+	}
+	if len(stacksA) != len(stacksB) {
+		t.Fatalf("got %d stacks, want %d", len(stacksA), len(stacksB))
+	}
+	for i := range stacksA {
+		if !reflect.DeepEqual(stacksA[i], stacksB[i]) {
+			t.Fatalf("stack %d differs", i)
+		}
+		if _, ok := stacksA[i][0].(*dst.File); !ok {
+			t.Fatalf("stack %d starts with %T", i, stacksA[i][0])
+		}
+	}
+}
+
+func TestInvalidation(t *testing.T) {
+	f, err := decorator.Parse(`package p
+
+		func a() {
+			b()
+		}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inspect := inspector.New([]*dst.File{f})
+	calls := func() (names []string) {
+		inspect.Preorder([]dst.Node{(*dst.CallExpr)(nil)}, func(n dst.Node) {
+			names = append(names, n.(*dst.CallExpr).Fun.(*dst.Ident).Name)
+		})
+		return names
+	}
+	if found := calls(); !reflect.DeepEqual(found, []string{"b"}) {
+		t.Fatalf("unexpected calls %v", found)
+	}
+
+	// mutations by dstutil.Apply are detected
+	dstutil.Apply(f, func(c *dstutil.Cursor) bool {
+		if _, ok := c.Node().(*dst.ExprStmt); ok {
+			c.InsertAfter(&dst.ExprStmt{X: &dst.CallExpr{Fun: dst.NewIdent("c")}})
+		}
+		return true
+	}, nil)
+	if found := calls(); !reflect.DeepEqual(found, []string{"b", "c"}) {
+		t.Fatalf("unexpected calls after Apply %v", found)
+	}
+
+	// direct mutations need Invalidate
+	body := f.Decls[0].(*dst.FuncDecl).Body
+	body.List = body.List[:1]
+	if found := calls(); !reflect.DeepEqual(found, []string{"b", "c"}) {
+		t.Fatalf("unexpected calls before Invalidate %v", found)
+	}
+
+	// mutations of an unrelated tree don't rebuild the event list
+	g, err := decorator.Parse(`package q
+
+		func d() {
+			e()
+		}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dstutil.Apply(g, func(c *dstutil.Cursor) bool {
+		if _, ok := c.Node().(*dst.ExprStmt); ok {
+			c.Delete()
+		}
+		return true
+	}, nil)
+	if found := calls(); !reflect.DeepEqual(found, []string{"b", "c"}) {
+		t.Fatalf("unexpected calls after unrelated Apply %v", found)
+	}
+
+	inspect.Invalidate()
+	if found := calls(); !reflect.DeepEqual(found, []string{"b"}) {
+		t.Fatalf("unexpected calls after Invalidate %v", found)
+	}
+}
+
+func compare(t *testing.T, nodesA, nodesB []dst.Node) {
+	if len(nodesA) != len(nodesB) {
+		t.Errorf("inconsistent node lists: %d vs %d", len(nodesA), len(nodesB))
+	} else {
+		for i := range nodesA {
+			if a, b := nodesA[i], nodesB[i]; a != b {
+				t.Errorf("node %d is inconsistent: %T, %T", i, a, b)
+			}
+		}
+	}
+}
+
+func BenchmarkNewInspector(b *testing.B) {
+	files := netFiles(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		inspector.New(files)
+	}
+}
+
+func BenchmarkInspect(b *testing.B) {
+	b.StopTimer()
+	inspect := inspector.New(netFiles(b))
+	b.StartTimer()
+
+	// Measure marginal cost of traversal.
+	var ndecls, nlits int
+	for i := 0; i < b.N; i++ {
+		inspect.Preorder([]dst.Node{(*dst.FuncDecl)(nil), (*dst.FuncLit)(nil)}, func(n dst.Node) {
+			switch n.(type) {
+			case *dst.FuncDecl:
+				ndecls++
+			case *dst.FuncLit:
+				nlits++
+			}
+		})
+	}
+}
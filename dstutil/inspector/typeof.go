@@ -0,0 +1,204 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inspector
+
+// This file defines func typeOf(dst.Node) uint64.
+
+import (
+	"math"
+
+	"github.com/dave/dst"
+)
+
+const (
+	nArrayType = iota
+	nAssignStmt
+	nBadDecl
+	nBadExpr
+	nBadStmt
+	nBasicLit
+	nBinaryExpr
+	nBlockStmt
+	nBranchStmt
+	nCallExpr
+	nCaseClause
+	nChanType
+	nCommClause
+	nCompositeLit
+	nDeclStmt
+	nDeferStmt
+	nEllipsis
+	nEmptyStmt
+	nExprStmt
+	nField
+	nFieldList
+	nFile
+	nForStmt
+	nFuncDecl
+	nFuncLit
+	nFuncType
+	nGenDecl
+	nGoStmt
+	nIdent
+	nIfStmt
+	nImportSpec
+	nIncDecStmt
+	nIndexExpr
+	nIndexListExpr
+	nInterfaceType
+	nKeyValueExpr
+	nLabeledStmt
+	nMapType
+	nPackage
+	nParenExpr
+	nRangeStmt
+	nReturnStmt
+	nSelectStmt
+	nSelectorExpr
+	nSendStmt
+	nSliceExpr
+	nStarExpr
+	nStructType
+	nSwitchStmt
+	nTypeAssertExpr
+	nTypeSpec
+	nTypeSwitchStmt
+	nUnaryExpr
+	nValueSpec
+)
+
+// typeOf returns a distinct single-bit value that represents the type of n.
+//
+// The compiler's switch statement is faster than a map or a search over a
+// list of types, as it produces a binary tree in code with constant
+// conditions and good branch prediction.
+func typeOf(n dst.Node) uint64 {
+	// Fast path: nearly half of all nodes are identifiers.
+	if _, ok := n.(*dst.Ident); ok {
+		return 1 << nIdent
+	}
+
+	// These cases include all nodes encountered by dst.Inspect.
+	switch n.(type) {
+	case *dst.ArrayType:
+		return 1 << nArrayType
+	case *dst.AssignStmt:
+		return 1 << nAssignStmt
+	case *dst.BadDecl:
+		return 1 << nBadDecl
+	case *dst.BadExpr:
+		return 1 << nBadExpr
+	case *dst.BadStmt:
+		return 1 << nBadStmt
+	case *dst.BasicLit:
+		return 1 << nBasicLit
+	case *dst.BinaryExpr:
+		return 1 << nBinaryExpr
+	case *dst.BlockStmt:
+		return 1 << nBlockStmt
+	case *dst.BranchStmt:
+		return 1 << nBranchStmt
+	case *dst.CallExpr:
+		return 1 << nCallExpr
+	case *dst.CaseClause:
+		return 1 << nCaseClause
+	case *dst.ChanType:
+		return 1 << nChanType
+	case *dst.CommClause:
+		return 1 << nCommClause
+	case *dst.CompositeLit:
+		return 1 << nCompositeLit
+	case *dst.DeclStmt:
+		return 1 << nDeclStmt
+	case *dst.DeferStmt:
+		return 1 << nDeferStmt
+	case *dst.Ellipsis:
+		return 1 << nEllipsis
+	case *dst.EmptyStmt:
+		return 1 << nEmptyStmt
+	case *dst.ExprStmt:
+		return 1 << nExprStmt
+	case *dst.Field:
+		return 1 << nField
+	case *dst.FieldList:
+		return 1 << nFieldList
+	case *dst.File:
+		return 1 << nFile
+	case *dst.ForStmt:
+		return 1 << nForStmt
+	case *dst.FuncDecl:
+		return 1 << nFuncDecl
+	case *dst.FuncLit:
+		return 1 << nFuncLit
+	case *dst.FuncType:
+		return 1 << nFuncType
+	case *dst.GenDecl:
+		return 1 << nGenDecl
+	case *dst.GoStmt:
+		return 1 << nGoStmt
+	case *dst.IfStmt:
+		return 1 << nIfStmt
+	case *dst.ImportSpec:
+		return 1 << nImportSpec
+	case *dst.IncDecStmt:
+		return 1 << nIncDecStmt
+	case *dst.IndexExpr:
+		return 1 << nIndexExpr
+	case *dst.IndexListExpr:
+		return 1 << nIndexListExpr
+	case *dst.InterfaceType:
+		return 1 << nInterfaceType
+	case *dst.KeyValueExpr:
+		return 1 << nKeyValueExpr
+	case *dst.LabeledStmt:
+		return 1 << nLabeledStmt
+	case *dst.MapType:
+		return 1 << nMapType
+	case *dst.Package:
+		return 1 << nPackage
+	case *dst.ParenExpr:
+		return 1 << nParenExpr
+	case *dst.RangeStmt:
+		return 1 << nRangeStmt
+	case *dst.ReturnStmt:
+		return 1 << nReturnStmt
+	case *dst.SelectStmt:
+		return 1 << nSelectStmt
+	case *dst.SelectorExpr:
+		return 1 << nSelectorExpr
+	case *dst.SendStmt:
+		return 1 << nSendStmt
+	case *dst.SliceExpr:
+		return 1 << nSliceExpr
+	case *dst.StarExpr:
+		return 1 << nStarExpr
+	case *dst.StructType:
+		return 1 << nStructType
+	case *dst.SwitchStmt:
+		return 1 << nSwitchStmt
+	case *dst.TypeAssertExpr:
+		return 1 << nTypeAssertExpr
+	case *dst.TypeSpec:
+		return 1 << nTypeSpec
+	case *dst.TypeSwitchStmt:
+		return 1 << nTypeSwitchStmt
+	case *dst.UnaryExpr:
+		return 1 << nUnaryExpr
+	case *dst.ValueSpec:
+		return 1 << nValueSpec
+	}
+	return 0
+}
+
+func maskOf(nodes []dst.Node) uint64 {
+	if nodes == nil {
+		return math.MaxUint64 // match all node types
+	}
+	var mask uint64
+	for _, n := range nodes {
+		mask |= typeOf(n)
+	}
+	return mask
+}
@@ -0,0 +1,336 @@
+// Package match finds and rewrites dst nodes using patterns written in Go syntax with wildcards,
+// in the style of gogrep.
+//
+// A pattern is a Go expression, statement or declaration. Wildcards can be used where an identifier
+// is valid in Go syntax:
+//
+//	$x     matches any single node, and binds it to x
+//	$x...  matches a list of zero or more nodes (arguments, statements, fields etc.), and may
+//	       also be written $*x
+//	$_     matches any single node without binding it ($_... matches any list)
+//
+// The wildcards use the hole syntax of decorator.Template, so a rewrite template can use the names
+// bound by the pattern. When a statement consists only of a wildcard, it matches any statement. If
+// a name is used more than once in a pattern, all the nodes it matches must be equal. Decorations
+// are ignored when matching.
+//
+// Qualified identifiers in the pattern are resolved using the imports, so they match identifiers
+// with the same Path (e.g. a tree decorated by a Decorator with a Resolver). Selector expressions
+// that don't refer to one of the imports are matched as selector expressions.
+package match
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+)
+
+// Pattern is a compiled pattern.
+type Pattern struct {
+	src     string
+	imports []string
+	kind    string // "expr", "stmt" or "decl"
+	node    dst.Node
+	wild    map[dst.Node]bool // nodes in the pattern that are or contain a wildcard
+}
+
+// Match is a node that matched a Pattern, and the nodes bound to the named wildcards.
+type Match struct {
+	Node     dst.Node
+	Bindings map[string]dst.Node   // Nodes bound by $x wildcards
+	Lists    map[string][]dst.Node // Nodes bound by $x... wildcards
+
+	listTypes map[string]reflect.Type // The type of the slice each list was matched in
+}
+
+// Compile compiles a pattern. The imports are the paths of the packages that can be referenced by
+// the pattern. The package name is the last element of the path unless it is specified before the
+// path, separated by a space (e.g. "yaml gopkg.in/yaml.v2").
+func Compile(src string, imports ...string) (*Pattern, error) {
+	replaced, _, err := decorator.ReplaceHoles(src)
+	if err != nil {
+		return nil, err
+	}
+	d, file, err := decorator.NewSnippetDecorator(imports...)
+	if err != nil {
+		return nil, err
+	}
+	p := &Pattern{src: src, imports: imports}
+	if expr, err := d.ParseExpr(replaced, file); err == nil {
+		p.kind, p.node = "expr", expr
+	} else if stmts, stmtsErr := d.ParseStmts(replaced, file); stmtsErr == nil {
+		if len(stmts) != 1 {
+			return nil, fmt.Errorf("pattern must be a single statement, found %d", len(stmts))
+		}
+		p.kind, p.node = "stmt", stmts[0]
+	} else if decls, err := d.ParseDecls(replaced, file); err == nil {
+		if len(decls) != 1 {
+			return nil, fmt.Errorf("pattern must be a single declaration, found %d", len(decls))
+		}
+		p.kind, p.node = "decl", decls[0]
+	} else {
+		return nil, stmtsErr
+	}
+	p.wild = wildcards(p.node)
+	return p, nil
+}
+
+// wildcards returns the nodes in the tree rooted at root that are or contain a wildcard.
+func wildcards(root dst.Node) map[dst.Node]bool {
+	wild := map[dst.Node]bool{}
+	var stack []dst.Node
+	dst.Inspect(root, func(n dst.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return false
+		}
+		if _, _, ok := wildcard(n); ok {
+			wild[n] = true
+			for _, a := range stack {
+				wild[a] = true
+			}
+		}
+		stack = append(stack, n)
+		return true
+	})
+	return wild
+}
+
+// MustCompile is like Compile but panics if the pattern can't be compiled.
+func MustCompile(src string, imports ...string) *Pattern {
+	p, err := Compile(src, imports...)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the source of the pattern.
+func (p *Pattern) String() string {
+	return p.src
+}
+
+// Match matches the pattern against n.
+func (p *Pattern) Match(n dst.Node) (*Match, bool) {
+	if n == nil {
+		return nil, false
+	}
+	m := &matcher{
+		wild:      p.wild,
+		bindings:  map[string]dst.Node{},
+		lists:     map[string][]dst.Node{},
+		listTypes: map[string]reflect.Type{},
+	}
+	if !m.node(p.node, n) {
+		return nil, false
+	}
+	return &Match{Node: n, Bindings: m.bindings, Lists: m.lists, listTypes: m.listTypes}, true
+}
+
+// Find returns all the nodes in the tree rooted at root that match the pattern, in depth-first
+// order.
+func (p *Pattern) Find(root dst.Node) []*Match {
+	var matches []*Match
+	dst.Inspect(root, func(n dst.Node) bool {
+		if m, ok := p.Match(n); ok {
+			matches = append(matches, m)
+		}
+		return true
+	})
+	return matches
+}
+
+type matcher struct {
+	wild      map[dst.Node]bool
+	bindings  map[string]dst.Node
+	lists     map[string][]dst.Node
+	listTypes map[string]reflect.Type
+}
+
+func (m *matcher) node(pattern, n dst.Node) bool {
+	if name, list, ok := wildcard(pattern); ok {
+		if _, isStmt := pattern.(*dst.ExprStmt); isStmt {
+			if _, ok := n.(dst.Stmt); !ok {
+				return false
+			}
+		}
+		if list {
+			// a list wildcard outside of a list matches a single node
+			return m.bindList(name, []dst.Node{n}, reflect.TypeOf([]dst.Node(nil)))
+		}
+		return m.bind(name, n)
+	}
+	if !m.wild[pattern] {
+		return equal(pattern, n)
+	}
+	return m.value(reflect.ValueOf(pattern), reflect.ValueOf(n))
+}
+
+func (m *matcher) bind(name string, n dst.Node) bool {
+	if name == "_" {
+		return true
+	}
+	if bound, ok := m.bindings[name]; ok {
+		return equal(bound, n)
+	}
+	m.bindings[name] = n
+	return true
+}
+
+func (m *matcher) bindList(name string, nodes []dst.Node, typ reflect.Type) bool {
+	if name == "_" {
+		return true
+	}
+	if bound, ok := m.lists[name]; ok {
+		if len(bound) != len(nodes) {
+			return false
+		}
+		for i := range bound {
+			if !equal(bound[i], nodes[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	m.lists[name] = nodes
+	m.listTypes[name] = typ
+	return true
+}
+
+func (m *matcher) value(p, v reflect.Value) bool {
+	if p.Kind() != v.Kind() {
+		return false
+	}
+	switch p.Kind() {
+	case reflect.Interface:
+		if p.IsNil() || v.IsNil() {
+			return p.IsNil() == v.IsNil()
+		}
+		return m.value(p.Elem(), v.Elem())
+	case reflect.Ptr:
+		if p.IsNil() || v.IsNil() {
+			return p.IsNil() == v.IsNil()
+		}
+		if pn, ok := p.Interface().(dst.Node); ok {
+			if _, _, ok := wildcard(pn); ok || !m.wild[pn] {
+				vn, ok := v.Interface().(dst.Node)
+				return ok && m.node(pn, vn)
+			}
+		}
+		if p.Type() != v.Type() {
+			return false
+		}
+		switch p.Interface().(type) {
+		case *dst.Object, *dst.Scope:
+			return true
+		}
+		return m.value(p.Elem(), v.Elem())
+	case reflect.Struct:
+		if p.Type() != v.Type() {
+			return false
+		}
+		for i := 0; i < p.NumField(); i++ {
+			if p.Type().Field(i).Name == "Decs" {
+				continue
+			}
+			if !m.value(p.Field(i), v.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		if p.Type() != v.Type() {
+			return false
+		}
+		return m.list(p, 0, v, 0)
+	case reflect.Map:
+		// only *dst.Package has maps, and packages are not matched
+		return p.Len() == 0 && v.Len() == 0
+	default:
+		return p.Interface() == v.Interface()
+	}
+}
+
+// list matches the pattern slice p from index i against the slice v from index j, backtracking over
+// the number of items consumed by list wildcards.
+func (m *matcher) list(p reflect.Value, i int, v reflect.Value, j int) bool {
+	if i == p.Len() {
+		return j == v.Len()
+	}
+	if pn, ok := p.Index(i).Interface().(dst.Node); ok {
+		if name, list, ok := wildcard(pn); ok && list {
+			for k := j; k <= v.Len(); k++ {
+				var nodes []dst.Node
+				for l := j; l < k; l++ {
+					nodes = append(nodes, v.Index(l).Interface().(dst.Node))
+				}
+				saved := m.save()
+				if m.bindList(name, nodes, v.Type()) && m.list(p, i+1, v, k) {
+					return true
+				}
+				m.restore(saved)
+			}
+			return false
+		}
+	}
+	if j == v.Len() {
+		return false
+	}
+	saved := m.save()
+	if m.value(p.Index(i), v.Index(j)) && m.list(p, i+1, v, j+1) {
+		return true
+	}
+	m.restore(saved)
+	return false
+}
+
+type state struct {
+	bindings map[string]dst.Node
+	lists    map[string][]dst.Node
+}
+
+func (m *matcher) save() state {
+	s := state{bindings: map[string]dst.Node{}, lists: map[string][]dst.Node{}}
+	for k, v := range m.bindings {
+		s.bindings[k] = v
+	}
+	for k, v := range m.lists {
+		s.lists[k] = v
+	}
+	return s
+}
+
+func (m *matcher) restore(s state) {
+	m.bindings = s.bindings
+	m.lists = s.lists
+	for k := range m.listTypes {
+		if _, ok := s.lists[k]; !ok {
+			delete(m.listTypes, k)
+		}
+	}
+}
+
+// equal returns true if a and b are structurally equal, ignoring decorations.
+func equal(a, b dst.Node) bool {
+	return dst.Equal(a, b, dst.EqualOptions{IgnoreDecorations: true, IgnoreSpacing: true, IgnoreObjects: true})
+}
+
+// wildcard returns the name of the wildcard if n is a wildcard identifier, a statement containing
+// only a wildcard, or a field containing only a list wildcard.
+func wildcard(n dst.Node) (name string, list, ok bool) {
+	switch n := n.(type) {
+	case *dst.Ident:
+		return decorator.HoleName(n)
+	case *dst.ExprStmt:
+		return wildcard(n.X)
+	case *dst.Field:
+		if len(n.Names) == 0 && n.Tag == nil {
+			if name, list, ok := wildcard(n.Type); ok && list {
+				return name, list, ok
+			}
+		}
+	}
+	return "", false, false
+}
@@ -0,0 +1,354 @@
+package match
+
+import (
+	"bytes"
+	"go/token"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/decorator/resolver/goast"
+	"github.com/dave/dst/decorator/resolver/guess"
+)
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		skip, solo bool
+		name       string
+		pattern    string
+		imports    []string
+		src        string
+		expect     []string // bindings for each match
+	}{
+		{
+			name:    "method-call",
+			pattern: `$x.Close()`,
+			src: `f.Close()
+				g.h.Close()
+				f.Open()`,
+			expect: []string{"x=f", "x=g.h"},
+		},
+		{
+			name:    "repeated",
+			pattern: `$x = $x`,
+			src: `a = a
+				a = b
+				a.b = a.b`,
+			expect: []string{"x=a", "x=a.b"},
+		},
+		{
+			name:    "list",
+			pattern: `f($before..., 1, $after...)`,
+			src: `f(1)
+				f(0, 1, 2, 3)
+				f(2)`,
+			expect: []string{"after= before=", "after=2,3 before=0"},
+		},
+		{
+			name:    "any",
+			pattern: `fmt.Sprintf($_, $_...)`,
+			src: `fmt.Sprintf("%d", 1)
+				fmt.Sprintf("")
+				fmt.Sprint()`,
+			expect: []string{"", ""},
+		},
+		{
+			name:    "stmt",
+			pattern: `if $err != nil { return $_... }`,
+			src: `if err != nil { return nil, err }
+				if e := f(); e != nil { return }
+				if err != nil { log(err); return err }`,
+			expect: []string{"err=err"},
+		},
+		{
+			name:    "stmt-star",
+			pattern: `if $err != nil { return $*_ }`,
+			src: `if err != nil { return nil, err }
+				if e := f(); e != nil { return }
+				if err != nil { log(err); return err }`,
+			expect: []string{"err=err"},
+		},
+		{
+			name:    "list-star",
+			pattern: `f($*args)`,
+			src: `f(1, 2)
+				g(1)`,
+			expect: []string{"args=1,2"},
+		},
+		{
+			name:    "stmt-wildcard",
+			pattern: `for { $s }`,
+			src: `for { a() }
+				for { if b { c() } }
+				for { a(); b() }`,
+			expect: []string{"s=a()", "s=if b {\n\tc()\n}"},
+		},
+		{
+			name:    "decl",
+			pattern: `func $name($_...) error { $_... }`,
+			src: `func a() error { return nil }
+				func b(i int) error { return nil }
+				func c() {}`,
+			expect: []string{"name=a", "name=b"},
+		},
+		{
+			name:    "qualified",
+			pattern: `errors.New($s)`,
+			imports: []string{"errors"},
+			src: `errors.New("a") // errors is not imported
+				stderrs.New("b")`,
+			expect: []string{`s="b"`},
+		},
+		{
+			name:    "qualified-alias",
+			pattern: `e.New($s)`,
+			imports: []string{"e errors"},
+			src: `stderrs.New("a")
+				New("b")`,
+			expect: []string{`s="a"`},
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			if test.skip {
+				t.Skip()
+			}
+			p, err := Compile(test.pattern, test.imports...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			src := "package main\n\nimport stderrs \"errors\"\n\n"
+			if strings.HasPrefix(test.pattern, "func") {
+				src += test.src
+			} else {
+				src += "func main() {\n" + test.src + "\n}"
+			}
+			f, err := parse(src, len(test.imports) > 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var found []string
+			for _, m := range p.Find(f) {
+				found = append(found, bindings(t, m))
+			}
+			if strings.Join(found, "\n") != strings.Join(test.expect, "\n") {
+				t.Errorf("expect:\n%s\nfound:\n%s", strings.Join(test.expect, "\n"), strings.Join(found, "\n"))
+			}
+		})
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	tests := []struct {
+		skip, solo        bool
+		name              string
+		pattern, template string
+		imports           []string
+		src, expect       string
+		count             int
+	}{
+		{
+			name:     "expr",
+			pattern:  `ioutil.ReadAll($r)`,
+			template: `io.ReadAll($r)`,
+			imports:  []string{"io", "io/ioutil"},
+			src: `package main
+
+				import "io/ioutil"
+
+				func main() {
+					ioutil.ReadAll(r /* a */) // b
+				}`,
+			expect: `package main
+
+				import "io"
+
+				func main() {
+					io.ReadAll(r /* a */) // b
+				}`,
+			count: 1,
+		},
+		{
+			name:     "nested",
+			pattern:  `len($x) == 0`,
+			template: `$x == ""`,
+			src: `package main
+
+				func main() {
+					_ = len(a) == 0 && len(b /* c */) == 0
+				}`,
+			expect: `package main
+
+				func main() {
+					_ = a == "" && b /* c */ == ""
+				}`,
+			count: 2,
+		},
+		{
+			name:     "stmt-list",
+			pattern:  `if $x { $body... }`,
+			template: `if !$x { return }; $body...`,
+			src: `package main
+
+				func main() {
+					// a
+					if ok {
+						b() // b
+						c()
+					}
+					d()
+				}`,
+			expect: `package main
+
+				func main() {
+					// a
+					if !ok {
+						return
+					}
+					b() // b
+					c()
+					d()
+				}`,
+			count: 1,
+		},
+		{
+			name:     "decl",
+			pattern:  `func $name($params...) { $body... }`,
+			template: `func $name($params...) error { $body...; return nil }`,
+			src: `package main
+
+				// A does a
+				func A(i int) {
+					println(i)
+				}`,
+			expect: `package main
+
+				// A does a
+				func A(i int) error {
+					println(i)
+					return nil
+				}`,
+			count: 1,
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			if test.skip {
+				t.Skip()
+			}
+			r, err := NewRule(test.pattern, test.template, test.imports...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := parse(test.src, len(test.imports) > 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, count, err := r.Apply(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != test.count {
+				t.Errorf("expect %d rewrites, found %d", test.count, count)
+			}
+			buf := &bytes.Buffer{}
+			if err := decorator.NewRestorerWithImports("main", guess.New()).Fprint(buf, f); err != nil {
+				t.Fatal(err)
+			}
+			if normalize(buf.String()) != normalize(test.expect) {
+				t.Errorf("expect:\n%s\nfound:\n%s", normalize(test.expect), normalize(buf.String()))
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, src := range []string{`$`, `$ x`, `$*`, `$* x`, `f($*x, $x)`, `f($x`, `a(); b()`} {
+		if _, err := Compile(src); err == nil {
+			t.Errorf("%q: expected error", src)
+		}
+	}
+}
+
+func parse(src string, imports bool) (*dst.File, error) {
+	if !imports {
+		return decorator.Parse(src)
+	}
+	return decorator.NewDecoratorWithImports(token.NewFileSet(), "main", goast.New()).Parse(src)
+}
+
+func bindings(t *testing.T, m *Match) string {
+	t.Helper()
+	var out []string
+	for name, n := range m.Bindings {
+		out = append(out, name+"="+format(t, n))
+	}
+	for name, nodes := range m.Lists {
+		var items []string
+		for _, n := range nodes {
+			items = append(items, format(t, n))
+		}
+		out = append(out, name+"="+strings.Join(items, ","))
+	}
+	sort.Strings(out)
+	return strings.Join(out, " ")
+}
+
+// format prints a node by wrapping it in a file.
+func format(t *testing.T, n dst.Node) string {
+	t.Helper()
+	n = dst.Clone(n)
+	var stmt dst.Stmt
+	switch n := n.(type) {
+	case dst.Expr:
+		stmt = &dst.ExprStmt{X: n}
+	case dst.Stmt:
+		stmt = n
+	default:
+		t.Fatalf("can't format %T", n)
+	}
+	stmt.Decorations().Before, stmt.Decorations().After = dst.NewLine, dst.NewLine
+	f := &dst.File{
+		Name:  dst.NewIdent("p"),
+		Decls: []dst.Decl{&dst.FuncDecl{Name: dst.NewIdent("f"), Type: &dst.FuncType{}, Body: &dst.BlockStmt{List: []dst.Stmt{stmt}}}},
+	}
+	buf := &bytes.Buffer{}
+	if err := decorator.NewRestorerWithImports("p", guess.New()).Fprint(buf, f); err != nil {
+		t.Fatal(err)
+	}
+	s := strings.TrimSpace(buf.String())
+	s = strings.TrimPrefix(s, "package p\n\nfunc f() {\n\t")
+	s = strings.TrimSuffix(s, "\n}")
+	return strings.Replace(s, "\n\t", "\n", -1)
+}
+
+// normalize removes the indentation so the expected output can be indented in the test source.
+func normalize(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,175 @@
+package match
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/dstutil"
+)
+
+// Rule rewrites the nodes that match a Pattern using a template. The template uses the same syntax
+// as the pattern, and its wildcards are substituted by the nodes bound by the match. Bound nodes
+// are moved into the result (or cloned if they are used more than once), so their decorations are
+// preserved. The decorations of the matched node are moved to the node that replaces it.
+type Rule struct {
+	Pattern  *Pattern
+	template *decorator.Template
+	src      string
+}
+
+// NewRule compiles a rewrite rule. The imports are used for both the pattern and the template. See
+// Compile for more details.
+func NewRule(pattern, template string, imports ...string) (*Rule, error) {
+	p, err := Compile(pattern, imports...)
+	if err != nil {
+		return nil, err
+	}
+	r := &Rule{Pattern: p, src: template}
+	switch p.kind {
+	case "expr":
+		r.template, err = decorator.ParseExprTemplate(template, imports...)
+	case "stmt":
+		r.template, err = decorator.ParseStmtsTemplate(template, imports...)
+	case "decl":
+		r.template, err = decorator.ParseDeclsTemplate(template, imports...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// MustNewRule is like NewRule but panics if the rule can't be compiled.
+func MustNewRule(pattern, template string, imports ...string) *Rule {
+	r, err := NewRule(pattern, template, imports...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// String returns the source of the rule.
+func (r *Rule) String() string {
+	return r.Pattern.String() + " -> " + r.src
+}
+
+// Rewrite returns the nodes that replace the match. Expression and declaration rules return a
+// single node. Statement rules may return any number of statements.
+func (r *Rule) Rewrite(m *Match) ([]dst.Node, error) {
+	args, err := m.args()
+	if err != nil {
+		return nil, err
+	}
+	var nodes []dst.Node
+	switch r.Pattern.kind {
+	case "expr":
+		expr, err := r.template.Expr(args)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, expr)
+	case "stmt":
+		stmts, err := r.template.Stmts(args)
+		if err != nil {
+			return nil, err
+		}
+		for _, stmt := range stmts {
+			nodes = append(nodes, stmt)
+		}
+	case "decl":
+		decls, err := r.template.Decls(args)
+		if err != nil {
+			return nil, err
+		}
+		if len(decls) != 1 {
+			return nil, fmt.Errorf("declaration rule must produce a single declaration, found %d", len(decls))
+		}
+		nodes = append(nodes, decls[0])
+	}
+	if len(nodes) > 0 {
+		first, last := nodes[0].Decorations(), nodes[len(nodes)-1].Decorations()
+		decs := m.Node.Decorations()
+		first.Start.Prepend(decs.Start...)
+		if first.Before == dst.None {
+			first.Before = decs.Before
+		}
+		last.End.Append(decs.End...)
+		if last.After == dst.None {
+			last.After = decs.After
+		}
+	}
+	return nodes, nil
+}
+
+// Apply rewrites all the nodes in the tree rooted at root that match the rule, and returns the
+// (possibly replaced) root and the number of rewrites. The tree is rewritten bottom-up using
+// dstutil.Apply, so matches inside bound nodes are rewritten before the nodes that contain them.
+func (r *Rule) Apply(root dst.Node) (result dst.Node, count int, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("rewriting: %v", rec)
+		}
+	}()
+	result = dstutil.Apply(root, nil, func(c *dstutil.Cursor) bool {
+		m, ok := r.Pattern.Match(c.Node())
+		if !ok {
+			return true
+		}
+		nodes, rerr := r.Rewrite(m)
+		if rerr != nil {
+			err = rerr
+			return false
+		}
+		switch {
+		case len(nodes) == 1:
+			c.Replace(nodes[0])
+		case c.Index() >= 0:
+			for _, n := range nodes {
+				c.InsertBefore(n)
+			}
+			c.Delete()
+		default:
+			err = fmt.Errorf("can't replace %T with %d nodes", c.Node(), len(nodes))
+			return false
+		}
+		count++
+		return true
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return result, count, nil
+}
+
+// args converts the bindings to template arguments. Lists are converted to the slice type they were
+// matched in.
+func (m *Match) args() (decorator.Args, error) {
+	args := decorator.Args{}
+	for name, n := range m.Bindings {
+		args[name] = n
+	}
+	for name, nodes := range m.Lists {
+		typ := m.listTypes[name]
+		if typ == reflect.TypeOf([]dst.Node(nil)) {
+			if len(nodes) != 1 {
+				return nil, fmt.Errorf("list $%s... has no type", name)
+			}
+			switch n := nodes[0].(type) {
+			case dst.Stmt:
+				typ = reflect.TypeOf([]dst.Stmt(nil))
+			case dst.Expr:
+				typ = reflect.TypeOf([]dst.Expr(nil))
+			default:
+				return nil, fmt.Errorf("list $%s... can't contain %T", name, n)
+			}
+		}
+		v := reflect.MakeSlice(typ, 0, len(nodes))
+		for _, n := range nodes {
+			v = reflect.Append(v, reflect.ValueOf(n))
+		}
+		args[name] = v.Interface()
+	}
+	return args, nil
+}
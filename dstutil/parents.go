@@ -0,0 +1,66 @@
+package dstutil
+
+import (
+	"reflect"
+
+	"github.com/dave/dst"
+)
+
+// Parents maps each node in a tree to its position in its parent. The root of the tree is not
+// in the map. Parents is not updated when the tree is modified, so it should be rebuilt with
+// NewParents after a modification.
+type Parents map[dst.Node]PathElem
+
+// NewParents builds the Parents map for the tree rooted at root.
+func NewParents(root dst.Node) Parents {
+	p := Parents{}
+	Apply(root, func(c *Cursor) bool {
+		if c.Node() == nil {
+			return true
+		}
+		if path := c.Path(); len(path) > 0 {
+			p[c.Node()] = path[0]
+		}
+		return true
+	}, nil)
+	return p
+}
+
+// Parent returns the parent of n, or nil if n is the root or isn't in the tree.
+func (p Parents) Parent(n dst.Node) dst.Node {
+	return p[n].Parent
+}
+
+// Path returns the positions of n and each of its ancestors, starting with n. See Cursor.Path.
+func (p Parents) Path(n dst.Node) []PathElem {
+	var path []PathElem
+	for {
+		elem, ok := p[n]
+		if !ok {
+			return path
+		}
+		path = append(path, elem)
+		n = elem.Parent
+	}
+}
+
+// Ancestors returns the ancestors of n, starting with the parent and ending with the root.
+func (p Parents) Ancestors(n dst.Node) []dst.Node {
+	var ancestors []dst.Node
+	for _, elem := range p.Path(n) {
+		ancestors = append(ancestors, elem.Parent)
+	}
+	return ancestors
+}
+
+// Enclosing returns the innermost ancestor of n that has the same type as typ, e.g.
+// Enclosing(n, (*dst.FuncDecl)(nil)) returns the FuncDecl that contains n. It returns nil if
+// there is no such ancestor.
+func (p Parents) Enclosing(n dst.Node, typ dst.Node) dst.Node {
+	for _, ancestor := range p.Ancestors(n) {
+		if reflect.TypeOf(ancestor) == reflect.TypeOf(typ) {
+			return ancestor
+		}
+	}
+	return nil
+}
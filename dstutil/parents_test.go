@@ -0,0 +1,55 @@
+package dstutil_test
+
+import (
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/dstutil"
+)
+
+func TestParents(t *testing.T) {
+	f, err := decorator.Parse(`package p
+
+func f() {
+	a()
+	if b {
+		g(x)
+	}
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd := f.Decls[0].(*dst.FuncDecl)
+	is := fd.Body.List[1].(*dst.IfStmt)
+	call := is.Body.List[0].(*dst.ExprStmt).X.(*dst.CallExpr)
+	x := call.Args[0]
+
+	parents := dstutil.NewParents(f)
+
+	if _, ok := parents[f]; ok {
+		t.Error("expected root not to be in the map")
+	}
+	if p := parents.Parent(x); p != call {
+		t.Errorf("expected parent to be the call, found %T", p)
+	}
+	if elem := parents[is]; elem.Parent != fd.Body || elem.Name != "List" || elem.Index != 1 {
+		t.Errorf("unexpected position of if statement %#v", elem)
+	}
+	if n := parents.Enclosing(x, (*dst.FuncDecl)(nil)); n != fd {
+		t.Errorf("expected enclosing FuncDecl, found %T", n)
+	}
+	if n := parents.Enclosing(x, (*dst.BlockStmt)(nil)); n != is.Body {
+		t.Errorf("expected innermost BlockStmt, found %T", n)
+	}
+	if n := parents.Enclosing(x, (*dst.FuncLit)(nil)); n != nil {
+		t.Errorf("expected nil, found %T", n)
+	}
+	ancestors := parents.Ancestors(x)
+	if len(ancestors) != 7 || ancestors[0] != call || ancestors[6] != f {
+		t.Errorf("unexpected ancestors %v", ancestors)
+	}
+	if path := parents.Path(x); len(path) != 7 || path[0].Name != "Args" || path[0].Index != 0 {
+		t.Errorf("unexpected path %v", path)
+	}
+}
@@ -0,0 +1,426 @@
+package refactor
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+)
+
+// Move moves the top-level declarations decls from the package from to the end of file, which is in
+// the package to. The declarations keep their decorations (e.g. doc comments), and the methods of
+// the types that are moved are moved with them. The Path of every reference to the moved objects
+// in pkgs and their imports is updated, as is the Path of every reference from the moved
+// declarations to the objects that stay in from. The import blocks are updated by the Restorer
+// when the packages are saved. The packages that were modified are returned.
+//
+// An error is returned, and nothing is modified, if:
+//
+//   - a declaration is not a top-level declaration of from, or is an import declaration
+//   - a method is moved without its receiver type
+//   - a moved object has the same name as an object or import in to
+//   - the moved declarations use an unexported object that stays in from
+//   - an unexported object that is moved is used outside the moved declarations
+//   - a reference that is no longer qualified after the move would refer to a local declaration
+//   - the move would create an import cycle
+//
+// Import cycles are detected using the imports of the loaded packages, and imports that would no
+// longer be needed after the move are not removed first, so the check is conservative.
+func Move(pkgs []*decorator.Package, from *decorator.Package, decls []dst.Decl, to *decorator.Package, file *dst.File) ([]*decorator.Package, error) {
+	if from.PkgPath == to.PkgPath {
+		return nil, errors.New("can't move declarations to the same package")
+	}
+	var found bool
+	for _, f := range to.Syntax {
+		if f == file {
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("file is not in package %s", to.PkgPath)
+	}
+	m := &mover{
+		pkgs:   withSyntax(append([]*decorator.Package{from, to}, pkgs...)),
+		all:    reachable(append([]*decorator.Package{from, to}, pkgs...)),
+		from:   from,
+		to:     to,
+		file:   file,
+		moved:  newObjectSet(),
+		decls:  map[dst.Decl]bool{},
+		inside: map[dst.Node]bool{},
+	}
+	if err := m.collect(decls); err != nil {
+		return nil, err
+	}
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	return m.apply(), nil
+}
+
+type mover struct {
+	pkgs     []*decorator.Package // packages with syntax
+	all      []*decorator.Package // all loaded packages
+	from, to *decorator.Package
+	file     *dst.File
+	moved    *objectSet        // package level objects declared by the moved declarations
+	decls    map[dst.Decl]bool // declarations to move
+	inside   map[dst.Node]bool // nodes in the declarations to move
+	ranges   [][2]token.Pos    // positions of the declarations to move in from
+	edges    [][2]string       // imports that will be added by the move
+}
+
+// collect finds the declarations to move, the objects they declare and the methods of the types.
+func (m *mover) collect(decls []dst.Decl) error {
+	top := map[dst.Decl]bool{}
+	for _, f := range m.from.Syntax {
+		for _, d := range f.Decls {
+			top[d] = true
+		}
+	}
+	for _, d := range decls {
+		if !top[d] {
+			return fmt.Errorf("not a top-level declaration of package %s", m.from.PkgPath)
+		}
+		if gd, ok := d.(*dst.GenDecl); ok && gd.Tok == token.IMPORT {
+			return errors.New("can't move import declarations")
+		}
+		m.decls[d] = true
+		for _, id := range declNames(d) {
+			if obj := m.from.ObjectOf(id); obj != nil && id.Name != "_" {
+				m.moved.add(obj)
+			}
+		}
+	}
+
+	// methods are moved with their receiver type
+	for _, f := range m.from.Syntax {
+		for _, d := range f.Decls {
+			fd, ok := d.(*dst.FuncDecl)
+			if !ok || fd.Recv == nil || len(fd.Recv.List) == 0 {
+				continue
+			}
+			recv := receiver(m.from, fd)
+			switch {
+			case m.moved.has(recv):
+				m.decls[d] = true
+			case m.decls[d]:
+				return fmt.Errorf("can't move method %s without its receiver type", fd.Name.Name)
+			}
+		}
+	}
+
+	for d := range m.decls {
+		dst.Inspect(d, func(n dst.Node) bool {
+			if n != nil {
+				m.inside[n] = true
+			}
+			return true
+		})
+		if an, ok := m.from.Map.Ast.Nodes[d]; ok {
+			m.ranges = append(m.ranges, [2]token.Pos{an.Pos(), an.End()})
+		}
+	}
+	return nil
+}
+
+// declNames returns the identifiers of the package level objects declared by a declaration.
+func declNames(d dst.Decl) []*dst.Ident {
+	var names []*dst.Ident
+	switch d := d.(type) {
+	case *dst.FuncDecl:
+		if d.Recv == nil {
+			names = append(names, d.Name)
+		}
+	case *dst.GenDecl:
+		for _, spec := range d.Specs {
+			switch spec := spec.(type) {
+			case *dst.TypeSpec:
+				names = append(names, spec.Name)
+			case *dst.ValueSpec:
+				names = append(names, spec.Names...)
+			}
+		}
+	}
+	return names
+}
+
+// receiver returns the type name of the receiver of a method.
+func receiver(p *decorator.Package, fd *dst.FuncDecl) types.Object {
+	typ := fd.Recv.List[0].Type
+	if star, ok := typ.(*dst.StarExpr); ok {
+		typ = star.X
+	}
+	switch t := typ.(type) {
+	case *dst.IndexExpr:
+		typ = t.X
+	case *dst.IndexListExpr:
+		typ = t.X
+	}
+	id, ok := typ.(*dst.Ident)
+	if !ok {
+		return nil
+	}
+	return p.ObjectOf(id)
+}
+
+// declaredInside reports whether the object is declared in the declarations to move.
+func (m *mover) declaredInside(obj types.Object) bool {
+	if obj == nil || obj.Pkg() == nil || obj.Pkg().Path() != m.from.PkgPath {
+		return false
+	}
+	if m.moved.has(obj) {
+		return true
+	}
+	for _, r := range m.ranges {
+		if obj.Pos() >= r[0] && obj.Pos() < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// packageLevel reports whether the object is declared in the package scope.
+func packageLevel(obj types.Object) bool {
+	return obj != nil && obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope()
+}
+
+// check returns an error if the move would break the program.
+func (m *mover) check() error {
+	for _, obj := range m.moved.list {
+		if existing := m.to.Types.Scope().Lookup(obj.Name()); existing != nil {
+			return fmt.Errorf("can't move %s: %s is already declared in package %s", obj.Name(), obj.Name(), m.to.PkgPath)
+		}
+		for _, f := range m.to.Syntax {
+			for _, imp := range f.Imports {
+				if name := m.importName(f, imp); name == obj.Name() {
+					return fmt.Errorf("can't move %s: %s is imported in %s", obj.Name(), obj.Name(), m.to.Decorator.Filenames[f])
+				}
+			}
+		}
+	}
+
+	// the moved declarations must only use exported objects that stay in from
+	for _, f := range m.from.Syntax {
+		for _, d := range f.Decls {
+			if !m.decls[d] {
+				continue
+			}
+			var err error
+			dst.Inspect(d, func(n dst.Node) bool {
+				id, ok := n.(*dst.Ident)
+				if !ok || err != nil {
+					return err == nil
+				}
+				obj := m.from.ObjectOf(id)
+				if obj == nil || obj.Pkg() == nil || m.declaredInside(obj) {
+					return true
+				}
+				if _, ok := obj.(*types.PkgName); ok {
+					return true
+				}
+				path := obj.Pkg().Path()
+				if path == m.from.PkgPath && !obj.Exported() {
+					err = fmt.Errorf("can't move: %s: %s is unexported and is not moved", pos(m.from, id), obj.Name())
+					return false
+				}
+				if packageLevel(obj) && path != m.to.PkgPath {
+					m.edges = append(m.edges, [2]string{m.to.PkgPath, path})
+				}
+				if packageLevel(obj) && path == m.to.PkgPath {
+					// the reference will be unqualified
+					if found := shadowed(m.from, id, obj.Name()); found != nil {
+						err = fmt.Errorf("can't move: %s: the reference to %s would refer to %s", pos(m.from, id), obj, found)
+						return false
+					}
+				}
+				return true
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// unexported objects that are moved must only be used in the moved declarations
+	for _, p := range m.pkgs {
+		for _, f := range p.Syntax {
+			var err error
+			dst.Inspect(f, func(n dst.Node) bool {
+				if err != nil || m.inside[n] {
+					return false
+				}
+				id, ok := n.(*dst.Ident)
+				if !ok {
+					return true
+				}
+				obj := p.ObjectOf(id)
+				if !m.moved.has(obj) && !(p == m.from && m.declaredInside(obj)) {
+					return true
+				}
+				if !obj.Exported() && p.PkgPath != m.to.PkgPath {
+					err = fmt.Errorf("can't move: %s: %s is unexported and is used by package %s", pos(p, id), obj.Name(), p.PkgPath)
+					return false
+				}
+				if p.PkgPath != m.to.PkgPath {
+					m.edges = append(m.edges, [2]string{p.PkgPath, m.to.PkgPath})
+				} else if found := shadowed(p, id, obj.Name()); found != nil {
+					// the reference will be unqualified
+					err = fmt.Errorf("can't move: %s: the reference to %s would refer to %s", pos(p, id), obj, found)
+					return false
+				}
+				return true
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if cycle := m.cycle(); cycle != nil {
+		return fmt.Errorf("can't move: import cycle %s", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
+
+// shadowed returns the local object that an unqualified reference to name at the position of id
+// would refer to, or nil if there is none.
+func shadowed(p *decorator.Package, id *dst.Ident, name string) types.Object {
+	an, ok := p.Map.Ast.Nodes[id]
+	if !ok {
+		return nil
+	}
+	inner := p.Types.Scope().Innermost(an.Pos())
+	if inner == nil {
+		return nil
+	}
+	s, obj := inner.LookupParent(name, an.Pos())
+	if obj == nil || !isLocal(p, s) {
+		return nil
+	}
+	return obj
+}
+
+// importName returns the name of an imported package in the file.
+func (m *mover) importName(f *dst.File, imp *dst.ImportSpec) string {
+	if imp.Name != nil {
+		return imp.Name.Name
+	}
+	if obj := m.to.Implicits()[imp]; obj != nil {
+		return obj.Name()
+	}
+	return ""
+}
+
+// cycle returns the packages in an import cycle that the move would create, or nil if there is
+// none. All the imports that are added involve to, so any new cycle includes to.
+func (m *mover) cycle() []string {
+	graph := map[string]map[string]bool{}
+	add := func(from, to string) {
+		if graph[from] == nil {
+			graph[from] = map[string]bool{}
+		}
+		graph[from][to] = true
+	}
+	for _, p := range m.all {
+		for _, imp := range p.Imports {
+			add(p.PkgPath, imp.PkgPath)
+		}
+	}
+	for _, e := range m.edges {
+		add(e[0], e[1])
+	}
+	seen := map[string]bool{}
+	var visit func(path string, stack []string) []string
+	visit = func(path string, stack []string) []string {
+		stack = append(stack, path)
+		for next := range graph[path] {
+			if next == m.to.PkgPath {
+				return append(stack, next)
+			}
+			if seen[next] {
+				continue
+			}
+			seen[next] = true
+			if cycle := visit(next, stack); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return visit(m.to.PkgPath, nil)
+}
+
+// apply moves the declarations and updates the references.
+func (m *mover) apply() []*decorator.Package {
+	changed := []*decorator.Package{m.from, m.to}
+	seen := map[*decorator.Package]bool{m.from: true, m.to: true}
+
+	// references in the moved declarations to objects that stay in from are qualified, and
+	// references to objects in to are not
+	for d := range m.decls {
+		dst.Inspect(d, func(n dst.Node) bool {
+			id, ok := n.(*dst.Ident)
+			if !ok {
+				return true
+			}
+			obj := m.from.ObjectOf(id)
+			if !packageLevel(obj) || m.declaredInside(obj) {
+				return true
+			}
+			switch obj.Pkg().Path() {
+			case m.from.PkgPath:
+				id.Path = m.from.PkgPath
+			case m.to.PkgPath:
+				id.Path = ""
+			}
+			return true
+		})
+	}
+
+	// references to the moved objects
+	for _, p := range m.pkgs {
+		for _, f := range p.Syntax {
+			dst.Inspect(f, func(n dst.Node) bool {
+				if m.inside[n] {
+					return false
+				}
+				id, ok := n.(*dst.Ident)
+				if !ok || !m.moved.has(p.ObjectOf(id)) {
+					return true
+				}
+				if p.PkgPath == m.to.PkgPath {
+					id.Path = ""
+				} else {
+					id.Path = m.to.PkgPath
+				}
+				if !seen[p] {
+					seen[p] = true
+					changed = append(changed, p)
+				}
+				return true
+			})
+		}
+	}
+
+	// move the declarations, in the order they were declared
+	for _, f := range m.from.Syntax {
+		var keep []dst.Decl
+		for _, d := range f.Decls {
+			if !m.decls[d] {
+				keep = append(keep, d)
+				continue
+			}
+			if d.Decorations().Before == dst.None {
+				d.Decorations().Before = dst.EmptyLine
+			}
+			m.file.Decls = append(m.file.Decls, d)
+		}
+		f.Decls = keep
+	}
+	return changed
+}
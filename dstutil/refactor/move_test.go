@@ -0,0 +1,276 @@
+package refactor_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/dstutil/refactor"
+)
+
+const moveA = `package a
+
+import "strings"
+
+// Upper returns s in upper case.
+func Upper(s string) string { return strings.ToUpper(s) + suffix }
+
+var suffix = "!"
+
+// T is a type.
+type T struct{ Name string }
+
+// Upper returns the name in upper case.
+func (t T) Upper() string { return Upper(t.Name) }
+
+func Make() T { return T{} }
+
+var V = 1
+`
+
+const moveB = `package b
+
+import "root/a"
+
+var Result = a.Upper("b")
+`
+
+const moveC = `package c
+
+var V = 2
+`
+
+func TestMove(t *testing.T) {
+	tests := []struct {
+		skip, solo bool
+		name       string
+		decls      []int // indexes of the declarations in a.go
+		to         string
+		expect     map[string]string
+		err        string
+	}{
+		{
+			name:  "func-and-var",
+			decls: []int{1, 2},
+			to:    "root/c",
+			expect: map[string]string{
+				"a/a.go": `package a
+
+import "root/c"
+
+// T is a type.
+type T struct{ Name string }
+
+// Upper returns the name in upper case.
+func (t T) Upper() string { return c.Upper(t.Name) }
+
+func Make() T { return T{} }
+
+var V = 1
+`,
+				"b/b.go": `package b
+
+import "root/c"
+
+var Result = c.Upper("b")
+`,
+				"c/c.go": `package c
+
+import "strings"
+
+var V = 2
+
+// Upper returns s in upper case.
+func Upper(s string) string { return strings.ToUpper(s) + suffix }
+
+var suffix = "!"
+`,
+			},
+		},
+		{
+			name:  "unexported-used",
+			decls: []int{2},
+			to:    "root/c",
+			err:   "suffix is unexported and is used by package root/a",
+		},
+		{
+			name:  "unexported-not-moved",
+			decls: []int{1},
+			to:    "root/c",
+			err:   "suffix is unexported and is not moved",
+		},
+		{
+			name:  "method",
+			decls: []int{4},
+			to:    "root/c",
+			err:   "can't move method Upper without its receiver type",
+		},
+		{
+			name:  "already-declared",
+			decls: []int{6},
+			to:    "root/c",
+			err:   "V is already declared in package root/c",
+		},
+		{
+			name:  "cycle",
+			decls: []int{3},
+			to:    "root/c",
+			err:   "import cycle root/c -> root/a -> root/c",
+		},
+		{
+			name:  "type-with-methods",
+			decls: []int{3, 5},
+			to:    "root/b",
+			expect: map[string]string{
+				"a/a.go": `package a
+
+import "strings"
+
+// Upper returns s in upper case.
+func Upper(s string) string { return strings.ToUpper(s) + suffix }
+
+var suffix = "!"
+
+var V = 1
+`,
+				"b/b.go": `package b
+
+import "root/a"
+
+var Result = a.Upper("b")
+
+// T is a type.
+type T struct{ Name string }
+
+// Upper returns the name in upper case.
+func (t T) Upper() string { return a.Upper(t.Name) }
+
+func Make() T { return T{} }
+`,
+			},
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			if test.skip {
+				t.Skip()
+			}
+			dir, pkgs := load(t, map[string]string{
+				"go.mod": "module root\n\ngo 1.18",
+				"a/a.go": moveA,
+				"b/b.go": moveB,
+				"c/c.go": moveC,
+			})
+			packages := map[string]*decorator.Package{}
+			for _, p := range pkgs {
+				packages[p.PkgPath] = p
+			}
+			from, to := packages["root/a"], packages[test.to]
+			var decls []dst.Decl
+			for _, i := range test.decls {
+				decls = append(decls, from.Syntax[0].Decls[i])
+			}
+			changed, err := refactor.Move(pkgs, from, decls, to, to.Syntax[0])
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, found %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(changed) != len(test.expect) {
+				t.Fatalf("expected %d changed packages, found %d", len(test.expect), len(changed))
+			}
+			for _, p := range changed {
+				if err := p.Save(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for fpath, expect := range test.expect {
+				found, err := ioutil.ReadFile(filepath.Join(dir, fpath))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(found) != expect {
+					t.Errorf("%s: expected:\n%s\nfound:\n%s", fpath, expect, found)
+				}
+			}
+		})
+	}
+}
+
+func TestMoveShadowed(t *testing.T) {
+	tests := []struct {
+		skip, solo bool
+		name       string
+		files      map[string]string
+		decl       int // index of the declaration in a.go
+		err        string
+	}{
+		{
+			name: "reference-in-to",
+			files: map[string]string{
+				"a/a.go": "package a\n\nvar X = 1\n",
+				"c/c.go": "package c\n\nimport \"root/a\"\n\nfunc F() int {\n\tX := 2\n\treturn X + a.X\n}\n",
+			},
+			decl: 0,
+			err:  "the reference to var root/a.X int would refer to var X int",
+		},
+		{
+			name: "reference-in-moved",
+			files: map[string]string{
+				"a/a.go": "package a\n\nimport \"root/c\"\n\nfunc F() int {\n\tY := 1\n\treturn Y + c.Y\n}\n",
+				"c/c.go": "package c\n\nvar Y = 2\n",
+			},
+			decl: 1,
+			err:  "the reference to var root/c.Y int would refer to var Y int",
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			if test.skip {
+				t.Skip()
+			}
+			files := map[string]string{"go.mod": "module root\n\ngo 1.18"}
+			for fpath, src := range test.files {
+				files[fpath] = src
+			}
+			_, pkgs := load(t, files)
+			packages := map[string]*decorator.Package{}
+			for _, p := range pkgs {
+				packages[p.PkgPath] = p
+			}
+			from, to := packages["root/a"], packages["root/c"]
+			decls := []dst.Decl{from.Syntax[0].Decls[test.decl]}
+			_, err := refactor.Move(pkgs, from, decls, to, to.Syntax[0])
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected error containing %q, found %v", test.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Package refactor implements refactorings of the packages returned by decorator.Load. The type
+// information of the packages is used to find the nodes to change, and the dst trees are modified in
+// place, so the decorations are preserved. The modified packages can be written with Package.Save,
+// or type checked again with Package.Check before the next refactoring.
+//
+// Only the packages passed to the refactorings and their imports are searched, so the packages
+// should be loaded with a pattern that includes every package that could be affected, e.g. "./...".
+package refactor
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"golang.org/x/tools/go/types/objectpath"
+)
+
+// reachable returns the packages in pkgs and their imports, recursively, in a stable order.
+func reachable(pkgs []*decorator.Package) []*decorator.Package {
+	seen := map[*decorator.Package]bool{}
+	var out []*decorator.Package
+	var add func(p *decorator.Package)
+	add = func(p *decorator.Package) {
+		if seen[p] {
+			return
+		}
+		seen[p] = true
+		out = append(out, p)
+		var paths []string
+		for path := range p.Imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			add(p.Imports[path])
+		}
+	}
+	for _, p := range pkgs {
+		add(p)
+	}
+	return out
+}
+
+// withSyntax returns the packages in pkgs and their imports that were loaded with syntax, and so can
+// be modified.
+func withSyntax(pkgs []*decorator.Package) []*decorator.Package {
+	var out []*decorator.Package
+	for _, p := range reachable(pkgs) {
+		if len(p.Syntax) > 0 && p.TypesInfo != nil {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// find returns the package with the path, or nil if it isn't in pkgs.
+func find(pkgs []*decorator.Package, path string) *decorator.Package {
+	for _, p := range pkgs {
+		if p.PkgPath == path {
+			return p
+		}
+	}
+	return nil
+}
+
+// objectSet is a set of objects. The packages may have been type checked separately (e.g. an import
+// loaded from export data, or a package that was type checked again with Check), so objects that
+// can be reached from the package scope are matched by package path and object path, and other
+// objects are matched by identity.
+type objectSet struct {
+	list    []types.Object
+	objects map[types.Object]bool
+	keys    map[string]bool
+}
+
+func newObjectSet() *objectSet {
+	return &objectSet{objects: map[types.Object]bool{}, keys: map[string]bool{}}
+}
+
+func (s *objectSet) add(obj types.Object) bool {
+	obj = origin(obj)
+	if s.has(obj) {
+		return false
+	}
+	s.list = append(s.list, obj)
+	s.objects[obj] = true
+	if k := key(obj); k != "" {
+		s.keys[k] = true
+	}
+	return true
+}
+
+func (s *objectSet) has(obj types.Object) bool {
+	if obj == nil {
+		return false
+	}
+	obj = origin(obj)
+	if s.objects[obj] {
+		return true
+	}
+	k := key(obj)
+	return k != "" && s.keys[k]
+}
+
+// origin returns the generic object that an object of an instantiated type was created from.
+func origin(obj types.Object) types.Object {
+	switch o := obj.(type) {
+	case *types.Var:
+		return o.Origin()
+	case *types.Func:
+		return o.Origin()
+	}
+	return obj
+}
+
+// key returns a string that identifies the object across type checks, or "" if there is none.
+func key(obj types.Object) string {
+	if obj.Pkg() == nil {
+		return ""
+	}
+	path, err := objectpath.For(obj)
+	if err != nil {
+		return ""
+	}
+	return obj.Pkg().Path() + " " + string(path)
+}
+
+// isMember reports whether the object is a field or a method, which are referred to by selector
+// expressions and are not declared in a scope.
+func isMember(obj types.Object) bool {
+	switch o := obj.(type) {
+	case *types.Var:
+		return o.IsField()
+	case *types.Func:
+		return o.Type().(*types.Signature).Recv() != nil
+	}
+	return false
+}
+
+// astIdent returns the ast identifier of an unqualified identifier, or nil if the identifier was
+// decorated from a qualified identifier.
+func astIdent(p *decorator.Package, id *dst.Ident) *ast.Ident {
+	an, _ := p.Map.Ast.Nodes[id].(*ast.Ident)
+	return an
+}
+
+// pos returns the position of a node in the files of the package, for error messages.
+func pos(p *decorator.Package, n dst.Node) token.Position {
+	if an, ok := p.Map.Ast.Nodes[n]; ok && p.Fset != nil {
+		return p.Fset.Position(an.Pos())
+	}
+	return token.Position{}
+}
+
+// selectors returns the identifiers that are the Sel of a SelectorExpr in the file, mapped to the
+// SelectorExpr.
+func selectors(file *dst.File) map[*dst.Ident]*dst.SelectorExpr {
+	m := map[*dst.Ident]*dst.SelectorExpr{}
+	dst.Inspect(file, func(n dst.Node) bool {
+		if s, ok := n.(*dst.SelectorExpr); ok {
+			m[s.Sel] = s
+		}
+		return true
+	})
+	return m
+}
@@ -0,0 +1,459 @@
+package refactor
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+)
+
+// Rename renames the object denoted by the identifier id, which is in a file of pkg, to name. The
+// declaration and every reference in pkgs and their imports are renamed, including qualified
+// identifiers in importing packages, fields and methods selected through embedded fields, and
+// dot-imports. The packages that were modified are returned.
+//
+// Some objects must be renamed together:
+//
+//   - renaming a type also renames the fields that embed it
+//   - renaming a method of an interface also renames the method of the types that implement it
+//   - renaming a method of a type also renames the method of the interfaces it implements
+//
+// Generic types and interfaces implement an interface if one of their instantiations in the loaded
+// packages does.
+//
+// An error is returned, and nothing is modified, if the rename would change the meaning of the
+// program: if the new name conflicts with another declaration in the same scope, struct or method
+// set, if a reference to the object would be shadowed by another declaration, if a reference to
+// another object would be shadowed by the object, if an exported object that is used by another
+// package would become unexported, or if a method is required by an interface in a package that
+// can't be modified.
+func Rename(pkgs []*decorator.Package, pkg *decorator.Package, id *dst.Ident, name string) ([]*decorator.Package, error) {
+	if !token.IsIdentifier(name) || name == "_" {
+		return nil, fmt.Errorf("invalid identifier %q", name)
+	}
+	obj := pkg.ObjectOf(id)
+	if obj == nil {
+		return nil, fmt.Errorf("no object found for %s", id.Name)
+	}
+	switch o := obj.(type) {
+	case *types.PkgName:
+		return nil, errors.New("renaming imports is not supported")
+	case *types.Label:
+		return nil, errors.New("renaming labels is not supported")
+	case *types.Var:
+		if o.Embedded() {
+			// the name of an embedded field is the name of the type
+			if tn := embeddedType(o); tn != nil {
+				obj = tn
+			}
+		}
+	}
+	if obj.Pkg() == nil {
+		return nil, fmt.Errorf("can't rename %s: it's declared in the universe scope", obj.Name())
+	}
+	if obj.Name() == name {
+		return nil, nil
+	}
+
+	r := &renamer{
+		pkgs:    withSyntax(append([]*decorator.Package{pkg}, pkgs...)),
+		all:     reachable(append([]*decorator.Package{pkg}, pkgs...)),
+		from:    obj.Name(),
+		to:      name,
+		targets: newObjectSet(),
+	}
+	r.decl = find(r.pkgs, obj.Pkg().Path())
+	if r.decl == nil {
+		return nil, fmt.Errorf("can't rename %s: package %s was not loaded with syntax", obj.Name(), obj.Pkg().Path())
+	}
+	r.targets.add(obj)
+	if err := r.methods(); err != nil {
+		return nil, err
+	}
+	r.embedded()
+	r.references()
+	if err := r.check(); err != nil {
+		return nil, err
+	}
+
+	var changed []*decorator.Package
+	seen := map[*decorator.Package]bool{}
+	for _, ref := range r.refs {
+		ref.id.Name = name
+		if !seen[ref.pkg] {
+			seen[ref.pkg] = true
+			changed = append(changed, ref.pkg)
+		}
+	}
+	return changed, nil
+}
+
+type renamer struct {
+	pkgs     []*decorator.Package // packages with syntax
+	all      []*decorator.Package // all loaded packages
+	decl     *decorator.Package   // package that declares the object
+	from, to string
+	targets  *objectSet // objects to rename
+	refs     []reference
+}
+
+// reference is an identifier that refers to (or declares) one of the objects being renamed.
+type reference struct {
+	pkg *decorator.Package
+	id  *dst.Ident
+	sel *dst.SelectorExpr // SelectorExpr if id is the Sel of a selector expression
+}
+
+// methods adds the methods that must be renamed together with the methods being renamed, so that the
+// types still implement the same interfaces. Generic types are checked using their instantiations.
+func (r *renamer) methods() error {
+	instances := r.instances()
+	var named, interfaces []types.Type
+	for _, p := range r.all {
+		if p.Types == nil {
+			continue
+		}
+		scope := p.Types.Scope()
+		for _, n := range scope.Names() {
+			tn, ok := scope.Lookup(n).(*types.TypeName)
+			if !ok || tn.IsAlias() {
+				continue
+			}
+			t, ok := tn.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+			if types.IsInterface(t) {
+				interfaces = append(interfaces, instantiated(t, instances)...)
+			} else if find(r.pkgs, p.PkgPath) != nil {
+				named = append(named, instantiated(t, instances)...)
+			}
+		}
+	}
+	for i := 0; i < len(r.targets.list); i++ {
+		m, ok := r.targets.list[i].(*types.Func)
+		if !ok {
+			continue
+		}
+		recv := m.Type().(*types.Signature).Recv()
+		if recv == nil {
+			continue
+		}
+		if types.IsInterface(recv.Type()) {
+			ifaces := []types.Type{recv.Type()}
+			if t, ok := recv.Type().(*types.Named); ok {
+				ifaces = instantiated(t.Origin(), instances)
+			}
+			for _, it := range ifaces {
+				iface := it.Underlying().(*types.Interface)
+				for _, t := range named {
+					for _, typ := range []types.Type{t, types.NewPointer(t)} {
+						if !types.Implements(typ, iface) {
+							continue
+						}
+						if obj, _, _ := types.LookupFieldOrMethod(typ, false, m.Pkg(), m.Name()); obj != nil {
+							r.targets.add(obj)
+						}
+						break
+					}
+				}
+			}
+			continue
+		}
+		typ := recv.Type()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		t, ok := typ.(*types.Named)
+		if !ok {
+			continue
+		}
+		for _, typ := range instantiated(t.Origin(), instances) {
+			for _, it := range interfaces {
+				iface := it.Underlying().(*types.Interface)
+				var method *types.Func
+				for j := 0; j < iface.NumMethods(); j++ {
+					if iface.Method(j).Name() == m.Name() {
+						method = iface.Method(j)
+					}
+				}
+				if method == nil || r.targets.has(method) {
+					continue
+				}
+				if !types.Implements(typ, iface) && !types.Implements(types.NewPointer(typ), iface) {
+					continue
+				}
+				if method.Pkg() == nil || find(r.pkgs, method.Pkg().Path()) == nil {
+					return fmt.Errorf("can't rename %s: it's required by %s, which was not loaded with syntax", m.Name(), it)
+				}
+				r.targets.add(method)
+			}
+		}
+	}
+	return nil
+}
+
+// instances returns the instantiations of the generic types in the loaded packages, keyed by the
+// generic type.
+func (r *renamer) instances() map[*types.Named][]types.Type {
+	instances := map[*types.Named][]types.Type{}
+	for _, p := range r.all {
+		if p.TypesInfo == nil {
+			continue
+		}
+		for _, inst := range p.TypesInfo.Instances {
+			if t, ok := inst.Type.(*types.Named); ok {
+				instances[t.Origin()] = append(instances[t.Origin()], t)
+			}
+		}
+	}
+	return instances
+}
+
+// instantiated returns the named type, or its instantiations if it's generic.
+func instantiated(t *types.Named, instances map[*types.Named][]types.Type) []types.Type {
+	if t.TypeParams().Len() == 0 {
+		return []types.Type{t}
+	}
+	return instances[t]
+}
+
+// embedded adds the fields that embed the types being renamed.
+func (r *renamer) embedded() {
+	for _, p := range r.pkgs {
+		for _, obj := range p.TypesInfo.Defs {
+			v, ok := obj.(*types.Var)
+			if !ok || !v.Embedded() {
+				continue
+			}
+			if tn := embeddedType(v); tn != nil && r.targets.has(tn) {
+				r.targets.add(v)
+			}
+		}
+	}
+}
+
+// embeddedType returns the type name of an embedded field.
+func embeddedType(v *types.Var) *types.TypeName {
+	t := v.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	switch t := t.(type) {
+	case *types.Named:
+		return t.Origin().Obj()
+	case *types.Alias:
+		return t.Obj()
+	}
+	return nil
+}
+
+// references finds the identifiers that refer to the objects being renamed.
+func (r *renamer) references() {
+	for _, p := range r.pkgs {
+		for _, file := range p.Syntax {
+			sels := selectors(file)
+			dst.Inspect(file, func(n dst.Node) bool {
+				id, ok := n.(*dst.Ident)
+				if !ok {
+					return true
+				}
+				if r.targets.has(p.ObjectOf(id)) {
+					r.refs = append(r.refs, reference{pkg: p, id: id, sel: sels[id]})
+				}
+				return true
+			})
+		}
+	}
+}
+
+// check returns an error if the rename would change the meaning of the program.
+func (r *renamer) check() error {
+	conflict := func(p *decorator.Package, n dst.Node, format string, args ...interface{}) error {
+		return fmt.Errorf("can't rename %s to %s: %s: %s", r.from, r.to, pos(p, n), fmt.Sprintf(format, args...))
+	}
+
+	// exported objects that are used by other packages must stay exported
+	if token.IsExported(r.from) && !token.IsExported(r.to) {
+		for _, ref := range r.refs {
+			if ref.pkg.PkgPath != r.decl.PkgPath {
+				return conflict(ref.pkg, ref.id, "%s is used by package %s", r.from, ref.pkg.PkgPath)
+			}
+		}
+	}
+
+	for _, obj := range r.targets.list {
+		p := find(r.pkgs, obj.Pkg().Path())
+		if p == nil {
+			continue
+		}
+		switch {
+		case isMember(obj):
+			if err := r.checkMember(p, obj); err != nil {
+				return err
+			}
+		case obj.Parent() == p.Types.Scope():
+			if existing := p.Types.Scope().Lookup(r.to); existing != nil {
+				return fmt.Errorf("can't rename %s to %s: %s is already declared in package %s at %s", r.from, r.to, r.to, p.PkgPath, p.Fset.Position(existing.Pos()))
+			}
+			for _, file := range p.Syntax {
+				af, ok := p.Map.Ast.Nodes[file].(*ast.File)
+				if !ok {
+					continue
+				}
+				if scope := p.TypesInfo.Scopes[af]; scope != nil && scope.Lookup(r.to) != nil {
+					return conflict(p, file, "%s is imported in %s", r.to, p.Decorator.Filenames[file])
+				}
+			}
+		case obj.Parent() != nil:
+			if existing := obj.Parent().Lookup(r.to); existing != nil {
+				return fmt.Errorf("can't rename %s to %s: %s is already declared in the same block at %s", r.from, r.to, r.to, p.Fset.Position(existing.Pos()))
+			}
+		}
+	}
+
+	// references to the objects must not be shadowed by other declarations
+	for _, ref := range r.refs {
+		if ref.sel != nil {
+			sel := ref.pkg.Selection(ref.sel)
+			if sel == nil {
+				continue
+			}
+			obj, _, _ := types.LookupFieldOrMethod(sel.Recv(), true, ref.pkg.Types, r.to)
+			if obj != nil && !r.targets.has(obj) {
+				return conflict(ref.pkg, ref.id, "the selection would refer to %s", obj)
+			}
+			continue
+		}
+		obj := ref.pkg.ObjectOf(ref.id)
+		an := astIdent(ref.pkg, ref.id)
+		if an == nil || isMember(obj) {
+			continue
+		}
+		for s := ref.pkg.Types.Scope().Innermost(an.Pos()); s != nil && s != obj.Parent(); s = s.Parent() {
+			found := s.Lookup(r.to)
+			if found == nil || r.targets.has(found) || isLocal(ref.pkg, s) && found.Pos() > an.Pos() {
+				continue
+			}
+			return conflict(ref.pkg, ref.id, "the reference would refer to %s", found)
+		}
+	}
+
+	// references to other objects must not be shadowed by the objects
+	for _, obj := range r.targets.list {
+		if isMember(obj) || obj.Parent() == nil {
+			continue
+		}
+		p := find(r.pkgs, obj.Pkg().Path())
+		if p == nil {
+			continue
+		}
+		for _, file := range p.Syntax {
+			sels := selectors(file)
+			var err error
+			dst.Inspect(file, func(n dst.Node) bool {
+				id, ok := n.(*dst.Ident)
+				if !ok || err != nil || id.Name != r.to || sels[id] != nil {
+					return err == nil
+				}
+				other := p.ObjectOf(id)
+				an := astIdent(p, id)
+				if other == nil || an == nil || isMember(other) || p.TypesInfo.Defs[an] != nil {
+					return true
+				}
+				if isLocal(p, obj.Parent()) && obj.Pos() > an.Pos() {
+					return true
+				}
+				for s := p.Types.Scope().Innermost(an.Pos()); s != nil; s = s.Parent() {
+					if s.Lookup(r.to) == other {
+						break
+					}
+					if s == obj.Parent() {
+						err = conflict(p, id, "the reference to %s would refer to %s", other, r.from)
+						break
+					}
+				}
+				return err == nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkMember returns an error if a field or method named r.to already exists where obj is declared.
+func (r *renamer) checkMember(p *decorator.Package, obj types.Object) error {
+	if f, ok := obj.(*types.Func); ok {
+		recv := f.Type().(*types.Signature).Recv()
+		if existing, _, _ := types.LookupFieldOrMethod(recv.Type(), true, f.Pkg(), r.to); existing != nil && !r.targets.has(existing) {
+			return fmt.Errorf("can't rename %s to %s: %s already has %s", r.from, r.to, recv.Type(), existing)
+		}
+		return nil
+	}
+	st, tn := declaringStruct(p, obj)
+	if st == nil {
+		return nil
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		if f := st.Field(i); f.Name() == r.to && !r.targets.has(f) {
+			return fmt.Errorf("can't rename %s to %s: the struct already has field %s at %s", r.from, r.to, r.to, p.Fset.Position(f.Pos()))
+		}
+	}
+	if tn != nil {
+		if existing, _, _ := types.LookupFieldOrMethod(tn.Type(), true, p.Types, r.to); existing != nil && !r.targets.has(existing) {
+			return fmt.Errorf("can't rename %s to %s: %s already has %s", r.from, r.to, tn.Type(), existing)
+		}
+	}
+	return nil
+}
+
+// declaringStruct returns the struct type that declares the field, and the type name if the struct
+// is the type of a type declaration. Only the nodes that enclose the position of the field are
+// visited.
+func declaringStruct(p *decorator.Package, field types.Object) (*types.Struct, *types.TypeName) {
+	for _, file := range p.Syntax {
+		af, ok := p.Map.Ast.Nodes[file].(*ast.File)
+		if !ok || field.Pos() < af.Pos() || field.Pos() >= af.End() {
+			continue
+		}
+		var spec *ast.TypeSpec
+		var expr *ast.StructType
+		ast.Inspect(af, func(n ast.Node) bool {
+			if n == nil || field.Pos() < n.Pos() || field.Pos() >= n.End() {
+				return false
+			}
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				spec = n
+			case *ast.StructType:
+				expr = n
+			}
+			return true
+		})
+		if expr == nil {
+			return nil, nil
+		}
+		st, ok := p.TypesInfo.TypeOf(expr).(*types.Struct)
+		if !ok {
+			return nil, nil
+		}
+		if spec != nil && spec.Type == expr {
+			tn, _ := p.TypesInfo.Defs[spec.Name].(*types.TypeName)
+			return st, tn
+		}
+		return st, nil
+	}
+	return nil, nil
+}
+
+// isLocal reports whether the scope is a function scope, where objects are only visible after they
+// are declared.
+func isLocal(p *decorator.Package, s *types.Scope) bool {
+	return s != types.Universe && s != p.Types.Scope() && s.Parent() != p.Types.Scope()
+}
@@ -0,0 +1,338 @@
+package refactor_test
+
+import (
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/dstutil/refactor"
+	"golang.org/x/tools/go/packages"
+)
+
+const renameA = `package a
+
+// T is a type.
+type T struct {
+	X int // X is a field.
+}
+
+// M returns X.
+func (t T) M() int { return t.X }
+
+type I interface{ M() int }
+
+var Count = 1
+
+func New() T { return T{X: Count} }
+
+func G() int {
+	n := 1
+	return n + Count
+}
+
+func H() int { return len("") }
+`
+
+const renameB = `package b
+
+import "root/a"
+
+type S struct {
+	a.T
+}
+
+func F(s S, i a.I) int {
+	var t a.T = a.New()
+	return s.M() + s.T.X + t.M() + i.M()
+}
+`
+
+func TestRename(t *testing.T) {
+	tests := []struct {
+		skip, solo bool
+		name       string
+		pkg        string // package containing the identifier
+		find       func(f *dst.File) *dst.Ident
+		to         string
+		expect     map[string]string // expected source of the changed files
+		err        string
+	}{
+		{
+			name: "type",
+			pkg:  "root/a",
+			find: func(f *dst.File) *dst.Ident { return f.Decls[0].(*dst.GenDecl).Specs[0].(*dst.TypeSpec).Name },
+			to:   "U",
+			expect: map[string]string{
+				"a/a.go": strings.NewReplacer("type T", "type U", "(t T)", "(t U)", "New() T", "New() U", "T{", "U{").Replace(renameA),
+				"b/b.go": strings.NewReplacer("a.T", "a.U", "s.T.X", "s.U.X").Replace(renameB),
+			},
+		},
+		{
+			name: "interface-method",
+			pkg:  "root/a",
+			find: func(f *dst.File) *dst.Ident {
+				return f.Decls[2].(*dst.GenDecl).Specs[0].(*dst.TypeSpec).Type.(*dst.InterfaceType).Methods.List[0].Names[0]
+			},
+			to: "N",
+			expect: map[string]string{
+				"a/a.go": strings.NewReplacer(") M()", ") N()", "{ M()", "{ N()").Replace(renameA),
+				"b/b.go": strings.NewReplacer("s.M()", "s.N()", "t.M()", "t.N()", "i.M()", "i.N()").Replace(renameB),
+			},
+		},
+		{
+			name: "field",
+			pkg:  "root/b",
+			find: func(f *dst.File) *dst.Ident {
+				ret := f.Decls[2].(*dst.FuncDecl).Body.List[1].(*dst.ReturnStmt)
+				return ret.Results[0].(*dst.BinaryExpr).X.(*dst.BinaryExpr).X.(*dst.BinaryExpr).Y.(*dst.SelectorExpr).Sel
+			},
+			to: "Y",
+			expect: map[string]string{
+				"a/a.go": strings.NewReplacer("\tX int // X", "\tY int // X", "t.X", "t.Y", "{X:", "{Y:").Replace(renameA),
+				"b/b.go": strings.Replace(renameB, "s.T.X", "s.T.Y", 1),
+			},
+		},
+		{
+			name: "unexport",
+			pkg:  "root/a",
+			find: func(f *dst.File) *dst.Ident { return f.Decls[4].(*dst.FuncDecl).Name },
+			to:   "newT",
+			err:  "New is used by package root/b",
+		},
+		{
+			name: "already-declared",
+			pkg:  "root/a",
+			find: func(f *dst.File) *dst.Ident { return f.Decls[4].(*dst.FuncDecl).Name },
+			to:   "Count",
+			err:  "Count is already declared in package root/a",
+		},
+		{
+			name: "method-conflict",
+			pkg:  "root/a",
+			find: func(f *dst.File) *dst.Ident { return f.Decls[1].(*dst.FuncDecl).Name },
+			to:   "X",
+			err:  "already has field",
+		},
+		{
+			name: "field-conflict",
+			pkg:  "root/a",
+			find: func(f *dst.File) *dst.Ident {
+				return f.Decls[0].(*dst.GenDecl).Specs[0].(*dst.TypeSpec).Type.(*dst.StructType).Fields.List[0].Names[0]
+			},
+			to:  "M",
+			err: "root/a.T already has func (root/a.T).M() int",
+		},
+		{
+			name: "shadowed",
+			pkg:  "root/a",
+			find: func(f *dst.File) *dst.Ident { return f.Decls[3].(*dst.GenDecl).Specs[0].(*dst.ValueSpec).Names[0] },
+			to:   "n",
+			err:  "the reference would refer to var n int",
+		},
+		{
+			name: "shadows-universe",
+			pkg:  "root/a",
+			find: func(f *dst.File) *dst.Ident { return f.Decls[3].(*dst.GenDecl).Specs[0].(*dst.ValueSpec).Names[0] },
+			to:   "len",
+			err:  "the reference to builtin len would refer to Count",
+		},
+		{
+			name: "local",
+			pkg:  "root/a",
+			find: func(f *dst.File) *dst.Ident {
+				return f.Decls[5].(*dst.FuncDecl).Body.List[0].(*dst.AssignStmt).Lhs[0].(*dst.Ident)
+			},
+			to: "m",
+			expect: map[string]string{
+				"a/a.go": strings.NewReplacer("n := 1", "m := 1", "return n", "return m").Replace(renameA),
+			},
+		},
+		{
+			name: "shadows",
+			pkg:  "root/a",
+			find: func(f *dst.File) *dst.Ident {
+				return f.Decls[5].(*dst.FuncDecl).Body.List[0].(*dst.AssignStmt).Lhs[0].(*dst.Ident)
+			},
+			to:  "Count",
+			err: "the reference to var root/a.Count int would refer to n",
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			if test.skip {
+				t.Skip()
+			}
+			dir, pkgs := load(t, map[string]string{
+				"go.mod": "module root\n\ngo 1.18",
+				"a/a.go": renameA,
+				"b/b.go": renameB,
+			})
+			var pkg *decorator.Package
+			for _, p := range pkgs {
+				if p.PkgPath == test.pkg {
+					pkg = p
+				}
+			}
+			changed, err := refactor.Rename(pkgs, pkg, test.find(pkg.Syntax[0]), test.to)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, found %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(changed) != len(test.expect) {
+				t.Fatalf("expected %d changed packages, found %d", len(test.expect), len(changed))
+			}
+			for _, p := range changed {
+				if err := p.Save(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for fpath, expect := range test.expect {
+				found, err := ioutil.ReadFile(filepath.Join(dir, fpath))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(found) != expect {
+					t.Errorf("%s: expected:\n%s\nfound:\n%s", fpath, expect, found)
+				}
+			}
+		})
+	}
+}
+
+// load writes the files to a temporary directory and loads all the packages in it.
+func load(t *testing.T, files map[string]string) (string, []*decorator.Package) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	for fpath, src := range files {
+		if strings.HasSuffix(fpath, ".go") {
+			b, err := format.Source([]byte(src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			src = string(b)
+		}
+		fpath = filepath.Join(dir, fpath)
+		if err := os.MkdirAll(filepath.Dir(fpath), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(fpath, []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pkgs, err := decorator.Load(&packages.Config{Mode: packages.LoadSyntax, Dir: dir}, "./...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, pkgs
+}
+
+const renameGeneric = `package g
+
+type I interface{ M() int }
+
+type G[T any] struct{}
+
+func (G[T]) M() int { return 0 }
+
+var _ I = G[int]{}
+
+type J[T any] interface{ K() T }
+
+type H struct{}
+
+func (H) K() string { return "" }
+
+var _ J[string] = H{}
+`
+
+func TestRenameGeneric(t *testing.T) {
+	tests := []struct {
+		skip, solo bool
+		name       string
+		find       func(f *dst.File) *dst.Ident
+		to         string
+		expect     string
+	}{
+		{
+			name: "interface-method",
+			find: func(f *dst.File) *dst.Ident {
+				return f.Decls[0].(*dst.GenDecl).Specs[0].(*dst.TypeSpec).Type.(*dst.InterfaceType).Methods.List[0].Names[0]
+			},
+			to:     "N",
+			expect: strings.NewReplacer("{ M()", "{ N()", ") M()", ") N()").Replace(renameGeneric),
+		},
+		{
+			name:   "generic-type-method",
+			find:   func(f *dst.File) *dst.Ident { return f.Decls[2].(*dst.FuncDecl).Name },
+			to:     "N",
+			expect: strings.NewReplacer("{ M()", "{ N()", ") M()", ") N()").Replace(renameGeneric),
+		},
+		{
+			name:   "generic-interface",
+			find:   func(f *dst.File) *dst.Ident { return f.Decls[6].(*dst.FuncDecl).Name },
+			to:     "L",
+			expect: strings.NewReplacer("{ K()", "{ L()", ") K()", ") L()").Replace(renameGeneric),
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			if test.skip {
+				t.Skip()
+			}
+			dir, pkgs := load(t, map[string]string{
+				"go.mod": "module root\n\ngo 1.18",
+				"g/g.go": renameGeneric,
+			})
+			changed, err := refactor.Rename(pkgs, pkgs[0], test.find(pkgs[0].Syntax[0]), test.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range changed {
+				if err := p.Save(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			found, err := ioutil.ReadFile(filepath.Join(dir, "g/g.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(found) != test.expect {
+				t.Errorf("expected:\n%s\nfound:\n%s", test.expect, found)
+			}
+		})
+	}
+}
@@ -51,6 +51,7 @@ func Apply(root dst.Node, pre, post ApplyFunc) (result dst.Node) {
 		result = parent.Node
 	}()
 	a := &application{pre: pre, post: post}
+	a.cursor.ancestors = &a.ancestors
 	a.apply(parent, "Node", nil, root)
 	return
 }
@@ -71,10 +72,19 @@ var abort = new(int) // singleton, to signal termination of Apply
 // The methods Replace, Delete, InsertBefore, and InsertAfter
 // can be used to change the AST without disrupting Apply.
 type Cursor struct {
-	parent dst.Node
-	name   string
-	iter   *iterator // valid if non-nil
-	node   dst.Node
+	parent    dst.Node
+	name      string
+	iter      *iterator // valid if non-nil
+	node      dst.Node
+	ancestors *[]PathElem // positions of the ancestors of the current Node, outermost first
+}
+
+// A PathElem describes the position of a node in its parent: the field that contains it,
+// and its index if the field is a slice.
+type PathElem struct {
+	Parent dst.Node
+	Name   string // See Cursor.Name
+	Index  int    // See Cursor.Index
 }
 
 // Node returns the current Node.
@@ -99,6 +109,37 @@ func (c *Cursor) Index() int {
 	return -1
 }
 
+// Path returns the positions of the current Node and each of its ancestors, starting with the
+// current Node, so Path()[0] is the Parent, Name and Index of the current Node. The last element
+// is the position of the child of the root passed to Apply. Path returns nil for the root.
+func (c *Cursor) Path() []PathElem {
+	if c.ancestors == nil || len(*c.ancestors) == 0 {
+		// the current node is the root
+		return nil
+	}
+	ancestors := *c.ancestors
+	path := make([]PathElem, 0, len(ancestors))
+	path = append(path, PathElem{Parent: c.parent, Name: c.name, Index: c.Index()})
+	for i := len(ancestors) - 1; i > 0; i-- {
+		path = append(path, ancestors[i])
+	}
+	return path
+}
+
+// Ancestors returns the ancestors of the current Node, starting with the Parent and ending with
+// the root passed to Apply. Ancestors returns nil for the root.
+func (c *Cursor) Ancestors() []dst.Node {
+	path := c.Path()
+	if path == nil {
+		return nil
+	}
+	ancestors := make([]dst.Node, len(path))
+	for i, p := range path {
+		ancestors[i] = p.Parent
+	}
+	return ancestors
+}
+
 // field returns the current node's parent field value.
 func (c *Cursor) field() reflect.Value {
 	return reflect.Indirect(reflect.ValueOf(c.parent)).FieldByName(c.name)
@@ -107,6 +148,7 @@ func (c *Cursor) field() reflect.Value {
 // Replace replaces the current Node with n.
 // The replacement node is not walked by Apply.
 func (c *Cursor) Replace(n dst.Node) {
+	modify(c.parent)
 	if _, ok := c.node.(*dst.File); ok {
 		file, ok := n.(*dst.File)
 		if !ok {
@@ -128,6 +170,7 @@ func (c *Cursor) Replace(n dst.Node) {
 // As a special case, if the current node is a package file,
 // Delete removes it from the package's Files map.
 func (c *Cursor) Delete() {
+	modify(c.parent)
 	if _, ok := c.node.(*dst.File); ok {
 		delete(c.parent.(*dst.Package).Files, c.name)
 		return
@@ -149,6 +192,7 @@ func (c *Cursor) Delete() {
 // If the current Node is not part of a slice, InsertAfter panics.
 // Apply does not walk n.
 func (c *Cursor) InsertAfter(n dst.Node) {
+	modify(c.parent)
 	i := c.Index()
 	if i < 0 {
 		panic("InsertAfter node not contained in slice")
@@ -165,6 +209,7 @@ func (c *Cursor) InsertAfter(n dst.Node) {
 // If the current Node is not part of a slice, InsertBefore panics.
 // Apply will not walk n.
 func (c *Cursor) InsertBefore(n dst.Node) {
+	modify(c.parent)
 	i := c.Index()
 	if i < 0 {
 		panic("InsertBefore node not contained in slice")
@@ -182,6 +227,7 @@ type application struct {
 	pre, post ApplyFunc
 	cursor    Cursor
 	iter      iterator
+	ancestors []PathElem // the position of each node being walked, outermost first
 }
 
 func (a *application) apply(parent dst.Node, name string, iter *iterator, n dst.Node) {
@@ -202,6 +248,10 @@ func (a *application) apply(parent dst.Node, name string, iter *iterator, n dst.
 		return
 	}
 
+	// record the position of the current node while its children are walked (the index is copied
+	// because the iterator is reused by the children)
+	a.ancestors = append(a.ancestors, PathElem{Parent: parent, Name: name, Index: a.cursor.Index()})
+
 	// walk children
 	// (the order of the cases matches the order of the corresponding node types in go/ast)
 	switch n := n.(type) {
@@ -242,6 +292,10 @@ func (a *application) apply(parent dst.Node, name string, iter *iterator, n dst.
 		a.apply(n, "X", nil, n.X)
 		a.apply(n, "Index", nil, n.Index)
 
+	case *dst.IndexListExpr:
+		a.apply(n, "X", nil, n.X)
+		a.applyList(n, "Indices")
+
 	case *dst.SliceExpr:
 		a.apply(n, "X", nil, n.X)
 		a.apply(n, "Low", nil, n.Low)
@@ -279,6 +333,7 @@ func (a *application) apply(parent dst.Node, name string, iter *iterator, n dst.
 		a.apply(n, "Fields", nil, n.Fields)
 
 	case *dst.FuncType:
+		a.apply(n, "TypeParams", nil, n.TypeParams)
 		a.apply(n, "Params", nil, n.Params)
 		a.apply(n, "Results", nil, n.Results)
 
@@ -386,6 +441,7 @@ func (a *application) apply(parent dst.Node, name string, iter *iterator, n dst.
 
 	case *dst.TypeSpec:
 		a.apply(n, "Name", nil, n.Name)
+		a.apply(n, "TypeParams", nil, n.TypeParams)
 		a.apply(n, "Type", nil, n.Type)
 
 	case *dst.BadDecl:
@@ -422,6 +478,8 @@ func (a *application) apply(parent dst.Node, name string, iter *iterator, n dst.
 		panic(fmt.Sprintf("Apply: unexpected node type %T", n))
 	}
 
+	a.ancestors = a.ancestors[:len(a.ancestors)-1]
+
 	if a.post != nil && !a.post(&a.cursor) {
 		panic(abort)
 	}
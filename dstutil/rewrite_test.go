@@ -6,10 +6,12 @@ package dstutil_test
 
 import (
 	"bytes"
+	"fmt"
 
 	"go/format"
 	"go/parser"
 	"go/token"
+	"strings"
 	"testing"
 
 	"github.com/dave/dst"
@@ -262,3 +264,59 @@ func BenchmarkRewrite(b *testing.B) {
 		})
 	}
 }
+
+func TestCursorPath(t *testing.T) {
+	f, err := decorator.Parse(`package p
+
+func f() {
+	a()
+	if b {
+		g(x)
+	}
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	format := func(path []dstutil.PathElem) string {
+		var s []string
+		for _, elem := range path {
+			if elem.Index >= 0 {
+				s = append(s, fmt.Sprintf("%T.%s[%d]", elem.Parent, elem.Name, elem.Index))
+			} else {
+				s = append(s, fmt.Sprintf("%T.%s", elem.Parent, elem.Name))
+			}
+		}
+		return strings.Join(s, " ")
+	}
+	expect := "*dst.CallExpr.Args[0] *dst.ExprStmt.X *dst.BlockStmt.List[0] *dst.IfStmt.Body *dst.BlockStmt.List[2] *dst.FuncDecl.Body *dst.File.Decls[0]"
+	var pre, post string
+	var ancestors []dst.Node
+	dstutil.Apply(f, func(c *dstutil.Cursor) bool {
+		if c.Node() == f && c.Path() != nil {
+			t.Errorf("expected nil path for root, found %v", c.Path())
+		}
+		if es, ok := c.Node().(*dst.ExprStmt); ok && es.X.(*dst.CallExpr).Fun.(*dst.Ident).Name == "a" {
+			// the index of the if statement includes the inserted statement
+			c.InsertBefore(&dst.ExprStmt{X: &dst.CallExpr{Fun: dst.NewIdent("z")}})
+		}
+		if id, ok := c.Node().(*dst.Ident); ok && id.Name == "x" {
+			pre = format(c.Path())
+			ancestors = c.Ancestors()
+		}
+		return true
+	}, func(c *dstutil.Cursor) bool {
+		if id, ok := c.Node().(*dst.Ident); ok && id.Name == "x" {
+			post = format(c.Path())
+		}
+		return true
+	})
+	if pre != expect {
+		t.Errorf("pre: expect %s, found %s", expect, pre)
+	}
+	if post != expect {
+		t.Errorf("post: expect %s, found %s", expect, post)
+	}
+	if len(ancestors) != 7 || ancestors[len(ancestors)-1] != f {
+		t.Errorf("unexpected ancestors %v", ancestors)
+	}
+}
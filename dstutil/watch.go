@@ -0,0 +1,63 @@
+package dstutil
+
+import (
+	"sync"
+	"sync/atomic"
+	"weak"
+
+	"github.com/dave/dst"
+)
+
+// A Watcher records whether Apply has modified any of a set of nodes, so caches of tree data (e.g.
+// the inspector package) can detect when they may be stale. A node is modified when one of its
+// fields is changed by the Replace, Delete, InsertBefore or InsertAfter methods of the Cursor.
+// Modifications made in other ways are not detected. Modifications of other trees don't affect the
+// Watcher.
+type Watcher struct {
+	nodes    map[dst.Node]bool
+	modified atomic.Bool
+}
+
+// watchers holds the Watchers that Apply notifies. Watchers that are no longer referenced are
+// removed when the next Watcher is created.
+var watchers struct {
+	sync.Mutex
+	list []weak.Pointer[Watcher]
+}
+
+// Watch returns a Watcher for the nodes.
+func Watch(nodes []dst.Node) *Watcher {
+	w := &Watcher{nodes: make(map[dst.Node]bool, len(nodes))}
+	for _, n := range nodes {
+		w.nodes[n] = true
+	}
+	watchers.Lock()
+	defer watchers.Unlock()
+	live := watchers.list[:0]
+	for _, p := range watchers.list {
+		if p.Value() != nil {
+			live = append(live, p)
+		}
+	}
+	for i := len(live); i < len(watchers.list); i++ {
+		watchers.list[i] = weak.Pointer[Watcher]{}
+	}
+	watchers.list = append(live, weak.Make(w))
+	return w
+}
+
+// Modified reports whether Apply has modified any of the nodes since the Watcher was created.
+func (w *Watcher) Modified() bool {
+	return w.modified.Load()
+}
+
+// modify notifies the Watchers of n that it has been modified.
+func modify(n dst.Node) {
+	watchers.Lock()
+	defer watchers.Unlock()
+	for _, p := range watchers.list {
+		if w := p.Value(); w != nil && w.nodes[n] {
+			w.modified.Store(true)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package dstutil_test
+
+import (
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/dstutil"
+)
+
+func TestWatch(t *testing.T) {
+	parse := func(src string) *dst.File {
+		f, err := decorator.Parse(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+	nodes := func(root dst.Node) (nodes []dst.Node) {
+		dst.Inspect(root, func(n dst.Node) bool {
+			if n != nil {
+				nodes = append(nodes, n)
+			}
+			return true
+		})
+		return nodes
+	}
+	deleteStmts := func(c *dstutil.Cursor) bool {
+		if _, ok := c.Node().(*dst.ExprStmt); ok {
+			c.Delete()
+		}
+		return true
+	}
+
+	f := parse("package p\n\nfunc a() { b() }")
+	g := parse("package q\n\nfunc c() { d() }")
+	w := dstutil.Watch(nodes(f))
+
+	// walking without modifying
+	dstutil.Apply(f, func(c *dstutil.Cursor) bool { return true }, nil)
+	if w.Modified() {
+		t.Fatal("unexpected modification after walking the tree")
+	}
+
+	// modifying another tree
+	dstutil.Apply(g, deleteStmts, nil)
+	if w.Modified() {
+		t.Fatal("unexpected modification after modifying another tree")
+	}
+
+	// modifying a subtree of the watched tree
+	dstutil.Apply(f.Decls[0].(*dst.FuncDecl).Body, deleteStmts, nil)
+	if !w.Modified() {
+		t.Fatal("expected modification after modifying a subtree")
+	}
+}
@@ -0,0 +1,4634 @@
+package dst
+
+import (
+	"fmt"
+	"sort"
+)
+
+// equal returns true if a and b are structurally equal. See Equal.
+func equal(a, b Node, opts *EqualOptions) bool {
+	a = nilNode(a)
+	b = nilNode(b)
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	switch a := a.(type) {
+	case *ArrayType:
+		b, ok := b.(*ArrayType)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Lbrack
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Lbrack, b.Decs.Lbrack) {
+			return false
+		}
+
+		// Node: Len
+		if !equal(a.Len, b.Len, opts) {
+			return false
+		}
+
+		// Decoration: Len
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Len, b.Decs.Len) {
+			return false
+		}
+
+		// Node: Elt
+		if !equal(a.Elt, b.Elt, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *AssignStmt:
+		b, ok := b.(*AssignStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// List: Lhs
+		if len(a.Lhs) != len(b.Lhs) {
+			return false
+		}
+		for i := range a.Lhs {
+			if !equal(a.Lhs[i], b.Lhs[i], opts) {
+				return false
+			}
+		}
+
+		// Token: Tok
+		if a.Tok != b.Tok {
+			return false
+		}
+
+		// Decoration: Tok
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Tok, b.Decs.Tok) {
+			return false
+		}
+
+		// List: Rhs
+		if len(a.Rhs) != len(b.Rhs) {
+			return false
+		}
+		for i := range a.Rhs {
+			if !equal(a.Rhs[i], b.Rhs[i], opts) {
+				return false
+			}
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *BadDecl:
+		b, ok := b.(*BadDecl)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Bad
+		if a.Length != b.Length {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *BadExpr:
+		b, ok := b.(*BadExpr)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Bad
+		if a.Length != b.Length {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *BadStmt:
+		b, ok := b.(*BadStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Bad
+		if a.Length != b.Length {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *BasicLit:
+		b, ok := b.(*BasicLit)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// String: Value
+		if a.Value != b.Value {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		// Value: Kind
+		if a.Kind != b.Kind {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *BinaryExpr:
+		b, ok := b.(*BinaryExpr)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: X
+		if !equal(a.X, b.X, opts) {
+			return false
+		}
+
+		// Decoration: X
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.X, b.Decs.X) {
+			return false
+		}
+
+		// Token: Op
+		if a.Op != b.Op {
+			return false
+		}
+
+		// Decoration: Op
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Op, b.Decs.Op) {
+			return false
+		}
+
+		// Node: Y
+		if !equal(a.Y, b.Y, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *BlockStmt:
+		b, ok := b.(*BlockStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Lbrace
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Lbrace, b.Decs.Lbrace) {
+			return false
+		}
+
+		// List: List
+		if len(a.List) != len(b.List) {
+			return false
+		}
+		for i := range a.List {
+			if !equal(a.List[i], b.List[i], opts) {
+				return false
+			}
+		}
+
+		// Token: Rbrace
+		if a.RbraceHasNoPos != b.RbraceHasNoPos {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *BranchStmt:
+		b, ok := b.(*BranchStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Token: Tok
+		if a.Tok != b.Tok {
+			return false
+		}
+
+		// Decoration: Tok
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Tok, b.Decs.Tok) {
+			return false
+		}
+
+		// Node: Label
+		if !equal(a.Label, b.Label, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *CallExpr:
+		b, ok := b.(*CallExpr)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: Fun
+		if !equal(a.Fun, b.Fun, opts) {
+			return false
+		}
+
+		// Decoration: Fun
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Fun, b.Decs.Fun) {
+			return false
+		}
+
+		// Decoration: Lparen
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Lparen, b.Decs.Lparen) {
+			return false
+		}
+
+		// List: Args
+		if len(a.Args) != len(b.Args) {
+			return false
+		}
+		for i := range a.Args {
+			if !equal(a.Args[i], b.Args[i], opts) {
+				return false
+			}
+		}
+
+		// Token: Ellipsis
+		if a.Ellipsis != b.Ellipsis {
+			return false
+		}
+
+		// Decoration: Ellipsis
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Ellipsis, b.Decs.Ellipsis) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *CaseClause:
+		b, ok := b.(*CaseClause)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Case
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Case, b.Decs.Case) {
+			return false
+		}
+
+		// List: List
+		if len(a.List) != len(b.List) {
+			return false
+		}
+		for i := range a.List {
+			if !equal(a.List[i], b.List[i], opts) {
+				return false
+			}
+		}
+
+		// Decoration: Colon
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Colon, b.Decs.Colon) {
+			return false
+		}
+
+		// List: Body
+		if len(a.Body) != len(b.Body) {
+			return false
+		}
+		for i := range a.Body {
+			if !equal(a.Body[i], b.Body[i], opts) {
+				return false
+			}
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *ChanType:
+		b, ok := b.(*ChanType)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Begin
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Begin, b.Decs.Begin) {
+			return false
+		}
+
+		// Decoration: Arrow
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Arrow, b.Decs.Arrow) {
+			return false
+		}
+
+		// Node: Value
+		if !equal(a.Value, b.Value, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		// Value: Dir
+		if a.Dir != b.Dir {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *CommClause:
+		b, ok := b.(*CommClause)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Case
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Case, b.Decs.Case) {
+			return false
+		}
+
+		// Node: Comm
+		if !equal(a.Comm, b.Comm, opts) {
+			return false
+		}
+
+		// Decoration: Comm
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Comm, b.Decs.Comm) {
+			return false
+		}
+
+		// Decoration: Colon
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Colon, b.Decs.Colon) {
+			return false
+		}
+
+		// List: Body
+		if len(a.Body) != len(b.Body) {
+			return false
+		}
+		for i := range a.Body {
+			if !equal(a.Body[i], b.Body[i], opts) {
+				return false
+			}
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *CompositeLit:
+		b, ok := b.(*CompositeLit)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: Type
+		if !equal(a.Type, b.Type, opts) {
+			return false
+		}
+
+		// Decoration: Type
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Type, b.Decs.Type) {
+			return false
+		}
+
+		// Decoration: Lbrace
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Lbrace, b.Decs.Lbrace) {
+			return false
+		}
+
+		// List: Elts
+		if len(a.Elts) != len(b.Elts) {
+			return false
+		}
+		for i := range a.Elts {
+			if !equal(a.Elts[i], b.Elts[i], opts) {
+				return false
+			}
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		// Value: Incomplete
+		if a.Incomplete != b.Incomplete {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *DeclStmt:
+		b, ok := b.(*DeclStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: Decl
+		if !equal(a.Decl, b.Decl, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *DeferStmt:
+		b, ok := b.(*DeferStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Defer
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Defer, b.Decs.Defer) {
+			return false
+		}
+
+		// Node: Call
+		if !equal(a.Call, b.Call, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *Ellipsis:
+		b, ok := b.(*Ellipsis)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Ellipsis
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Ellipsis, b.Decs.Ellipsis) {
+			return false
+		}
+
+		// Node: Elt
+		if !equal(a.Elt, b.Elt, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *EmptyStmt:
+		b, ok := b.(*EmptyStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		// Value: Implicit
+		if a.Implicit != b.Implicit {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *ExprStmt:
+		b, ok := b.(*ExprStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: X
+		if !equal(a.X, b.X, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *Field:
+		b, ok := b.(*Field)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// List: Names
+		if len(a.Names) != len(b.Names) {
+			return false
+		}
+		for i := range a.Names {
+			if !equal(a.Names[i], b.Names[i], opts) {
+				return false
+			}
+		}
+
+		// Node: Type
+		if !equal(a.Type, b.Type, opts) {
+			return false
+		}
+
+		// Decoration: Type
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Type, b.Decs.Type) {
+			return false
+		}
+
+		// Node: Tag
+		if !equal(a.Tag, b.Tag, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *FieldList:
+		b, ok := b.(*FieldList)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Token: Opening
+		if a.Opening != b.Opening {
+			return false
+		}
+
+		// Decoration: Opening
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Opening, b.Decs.Opening) {
+			return false
+		}
+
+		// List: List
+		if len(a.List) != len(b.List) {
+			return false
+		}
+		for i := range a.List {
+			if !equal(a.List[i], b.List[i], opts) {
+				return false
+			}
+		}
+
+		// Token: Closing
+		if a.Closing != b.Closing {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *File:
+		b, ok := b.(*File)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Package
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Package, b.Decs.Package) {
+			return false
+		}
+
+		// Node: Name
+		if !equal(a.Name, b.Name, opts) {
+			return false
+		}
+
+		// Decoration: Name
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Name, b.Decs.Name) {
+			return false
+		}
+
+		// List: Decls
+		if len(a.Decls) != len(b.Decls) {
+			return false
+		}
+		for i := range a.Decls {
+			if !equal(a.Decls[i], b.Decls[i], opts) {
+				return false
+			}
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		// Scope: Scope
+		if !opts.IgnoreObjects && !equalScope(a.Scope, b.Scope) {
+			return false
+		}
+
+		// List: Imports
+		if len(a.Imports) != len(b.Imports) {
+			return false
+		}
+		for i := range a.Imports {
+			if !equal(a.Imports[i], b.Imports[i], opts) {
+				return false
+			}
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *ForStmt:
+		b, ok := b.(*ForStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: For
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.For, b.Decs.For) {
+			return false
+		}
+
+		// Node: Init
+		if !equal(a.Init, b.Init, opts) {
+			return false
+		}
+
+		// Decoration: Init
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Init, b.Decs.Init) {
+			return false
+		}
+
+		// Node: Cond
+		if !equal(a.Cond, b.Cond, opts) {
+			return false
+		}
+
+		// Decoration: Cond
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Cond, b.Decs.Cond) {
+			return false
+		}
+
+		// Node: Post
+		if !equal(a.Post, b.Post, opts) {
+			return false
+		}
+
+		// Decoration: Post
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Post, b.Decs.Post) {
+			return false
+		}
+
+		// Node: Body
+		if !equal(a.Body, b.Body, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *FuncDecl:
+		b, ok := b.(*FuncDecl)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Special decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Type.Decs.Start, b.Type.Decs.Start) {
+			return false
+		}
+
+		// Token: Func
+		if a.Type.Func != b.Type.Func {
+			return false
+		}
+
+		// Decoration: Func
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Func, b.Decs.Func) {
+			return false
+		}
+
+		// Special decoration: Func
+		if !opts.IgnoreDecorations && !equalDecorations(a.Type.Decs.Func, b.Type.Decs.Func) {
+			return false
+		}
+
+		// Node: Recv
+		if !equal(a.Recv, b.Recv, opts) {
+			return false
+		}
+
+		// Decoration: Recv
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Recv, b.Decs.Recv) {
+			return false
+		}
+
+		// Node: Name
+		if !equal(a.Name, b.Name, opts) {
+			return false
+		}
+
+		// Decoration: Name
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Name, b.Decs.Name) {
+			return false
+		}
+
+		// Node: TypeParams
+		if !equal(a.Type.TypeParams, b.Type.TypeParams, opts) {
+			return false
+		}
+
+		// Decoration: TypeParams
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.TypeParams, b.Decs.TypeParams) {
+			return false
+		}
+
+		// Special decoration: TypeParams
+		if !opts.IgnoreDecorations && !equalDecorations(a.Type.Decs.TypeParams, b.Type.Decs.TypeParams) {
+			return false
+		}
+
+		// Node: Params
+		if !equal(a.Type.Params, b.Type.Params, opts) {
+			return false
+		}
+
+		// Decoration: Params
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Params, b.Decs.Params) {
+			return false
+		}
+
+		// Special decoration: Params
+		if !opts.IgnoreDecorations && !equalDecorations(a.Type.Decs.Params, b.Type.Decs.Params) {
+			return false
+		}
+
+		// Node: Results
+		if !equal(a.Type.Results, b.Type.Results, opts) {
+			return false
+		}
+
+		// Decoration: Results
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Results, b.Decs.Results) {
+			return false
+		}
+
+		// Special decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Type.Decs.End, b.Type.Decs.End) {
+			return false
+		}
+
+		// Node: Body
+		if !equal(a.Body, b.Body, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *FuncLit:
+		b, ok := b.(*FuncLit)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: Type
+		if !equal(a.Type, b.Type, opts) {
+			return false
+		}
+
+		// Decoration: Type
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Type, b.Decs.Type) {
+			return false
+		}
+
+		// Node: Body
+		if !equal(a.Body, b.Body, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *FuncType:
+		b, ok := b.(*FuncType)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Token: Func
+		if a.Func != b.Func {
+			return false
+		}
+
+		// Decoration: Func
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Func, b.Decs.Func) {
+			return false
+		}
+
+		// Node: TypeParams
+		if !equal(a.TypeParams, b.TypeParams, opts) {
+			return false
+		}
+
+		// Decoration: TypeParams
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.TypeParams, b.Decs.TypeParams) {
+			return false
+		}
+
+		// Node: Params
+		if !equal(a.Params, b.Params, opts) {
+			return false
+		}
+
+		// Decoration: Params
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Params, b.Decs.Params) {
+			return false
+		}
+
+		// Node: Results
+		if !equal(a.Results, b.Results, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *GenDecl:
+		b, ok := b.(*GenDecl)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Token: Tok
+		if a.Tok != b.Tok {
+			return false
+		}
+
+		// Decoration: Tok
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Tok, b.Decs.Tok) {
+			return false
+		}
+
+		// Token: Lparen
+		if a.Lparen != b.Lparen {
+			return false
+		}
+
+		// Decoration: Lparen
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Lparen, b.Decs.Lparen) {
+			return false
+		}
+
+		// List: Specs
+		if len(a.Specs) != len(b.Specs) {
+			return false
+		}
+		for i := range a.Specs {
+			if !equal(a.Specs[i], b.Specs[i], opts) {
+				return false
+			}
+		}
+
+		// Token: Rparen
+		if a.Rparen != b.Rparen {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *GoStmt:
+		b, ok := b.(*GoStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Go
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Go, b.Decs.Go) {
+			return false
+		}
+
+		// Node: Call
+		if !equal(a.Call, b.Call, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *Ident:
+		b, ok := b.(*Ident)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: X
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.X, b.Decs.X) {
+			return false
+		}
+
+		// String: Name
+		if a.Name != b.Name {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		// Object: Obj
+		if !opts.IgnoreObjects && !equalObject(a.Obj, b.Obj) {
+			return false
+		}
+
+		// Path: Path
+		if opts.path(a.Path) != opts.path(b.Path) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *IfStmt:
+		b, ok := b.(*IfStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: If
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.If, b.Decs.If) {
+			return false
+		}
+
+		// Node: Init
+		if !equal(a.Init, b.Init, opts) {
+			return false
+		}
+
+		// Decoration: Init
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Init, b.Decs.Init) {
+			return false
+		}
+
+		// Node: Cond
+		if !equal(a.Cond, b.Cond, opts) {
+			return false
+		}
+
+		// Decoration: Cond
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Cond, b.Decs.Cond) {
+			return false
+		}
+
+		// Node: Body
+		if !equal(a.Body, b.Body, opts) {
+			return false
+		}
+
+		// Decoration: Else
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Else, b.Decs.Else) {
+			return false
+		}
+
+		// Node: Else
+		if !equal(a.Else, b.Else, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *ImportSpec:
+		b, ok := b.(*ImportSpec)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: Name
+		if !equal(a.Name, b.Name, opts) {
+			return false
+		}
+
+		// Decoration: Name
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Name, b.Decs.Name) {
+			return false
+		}
+
+		// Node: Path
+		if !equal(a.Path, b.Path, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *IncDecStmt:
+		b, ok := b.(*IncDecStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: X
+		if !equal(a.X, b.X, opts) {
+			return false
+		}
+
+		// Decoration: X
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.X, b.Decs.X) {
+			return false
+		}
+
+		// Token: Tok
+		if a.Tok != b.Tok {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *IndexExpr:
+		b, ok := b.(*IndexExpr)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: X
+		if !equal(a.X, b.X, opts) {
+			return false
+		}
+
+		// Decoration: X
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.X, b.Decs.X) {
+			return false
+		}
+
+		// Decoration: Lbrack
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Lbrack, b.Decs.Lbrack) {
+			return false
+		}
+
+		// Node: Index
+		if !equal(a.Index, b.Index, opts) {
+			return false
+		}
+
+		// Decoration: Index
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Index, b.Decs.Index) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *IndexListExpr:
+		b, ok := b.(*IndexListExpr)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: X
+		if !equal(a.X, b.X, opts) {
+			return false
+		}
+
+		// Decoration: X
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.X, b.Decs.X) {
+			return false
+		}
+
+		// Decoration: Lbrack
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Lbrack, b.Decs.Lbrack) {
+			return false
+		}
+
+		// List: Indices
+		if len(a.Indices) != len(b.Indices) {
+			return false
+		}
+		for i := range a.Indices {
+			if !equal(a.Indices[i], b.Indices[i], opts) {
+				return false
+			}
+		}
+
+		// Decoration: Indices
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Indices, b.Decs.Indices) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *InterfaceType:
+		b, ok := b.(*InterfaceType)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Interface
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Interface, b.Decs.Interface) {
+			return false
+		}
+
+		// Node: Methods
+		if !equal(a.Methods, b.Methods, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		// Value: Incomplete
+		if a.Incomplete != b.Incomplete {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *KeyValueExpr:
+		b, ok := b.(*KeyValueExpr)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: Key
+		if !equal(a.Key, b.Key, opts) {
+			return false
+		}
+
+		// Decoration: Key
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Key, b.Decs.Key) {
+			return false
+		}
+
+		// Decoration: Colon
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Colon, b.Decs.Colon) {
+			return false
+		}
+
+		// Node: Value
+		if !equal(a.Value, b.Value, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *LabeledStmt:
+		b, ok := b.(*LabeledStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: Label
+		if !equal(a.Label, b.Label, opts) {
+			return false
+		}
+
+		// Decoration: Label
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Label, b.Decs.Label) {
+			return false
+		}
+
+		// Decoration: Colon
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Colon, b.Decs.Colon) {
+			return false
+		}
+
+		// Node: Stmt
+		if !equal(a.Stmt, b.Stmt, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *MapType:
+		b, ok := b.(*MapType)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Map
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Map, b.Decs.Map) {
+			return false
+		}
+
+		// Node: Key
+		if !equal(a.Key, b.Key, opts) {
+			return false
+		}
+
+		// Decoration: Key
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Key, b.Decs.Key) {
+			return false
+		}
+
+		// Node: Value
+		if !equal(a.Value, b.Value, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *Package:
+		b, ok := b.(*Package)
+		if !ok {
+			return false
+		}
+
+		// Value: Name
+		if a.Name != b.Name {
+			return false
+		}
+
+		// Scope: Scope
+		if !opts.IgnoreObjects && !equalScope(a.Scope, b.Scope) {
+			return false
+		}
+
+		// Map: Imports
+		if len(a.Imports) != len(b.Imports) {
+			return false
+		}
+		for k, v := range a.Imports {
+			bv, ok := b.Imports[k]
+			if !ok || !opts.IgnoreObjects && !equalObject(v, bv) {
+				return false
+			}
+		}
+
+		// Map: Files
+		if len(a.Files) != len(b.Files) {
+			return false
+		}
+		for k, v := range a.Files {
+			bv, ok := b.Files[k]
+			if !ok || !equal(v, bv, opts) {
+				return false
+			}
+		}
+
+		return true
+	case *ParenExpr:
+		b, ok := b.(*ParenExpr)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Lparen
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Lparen, b.Decs.Lparen) {
+			return false
+		}
+
+		// Node: X
+		if !equal(a.X, b.X, opts) {
+			return false
+		}
+
+		// Decoration: X
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.X, b.Decs.X) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *RangeStmt:
+		b, ok := b.(*RangeStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: For
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.For, b.Decs.For) {
+			return false
+		}
+
+		// Node: Key
+		if !equal(a.Key, b.Key, opts) {
+			return false
+		}
+
+		// Decoration: Key
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Key, b.Decs.Key) {
+			return false
+		}
+
+		// Node: Value
+		if !equal(a.Value, b.Value, opts) {
+			return false
+		}
+
+		// Decoration: Value
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Value, b.Decs.Value) {
+			return false
+		}
+
+		// Token: Tok
+		if a.Tok != b.Tok {
+			return false
+		}
+
+		// Decoration: Range
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Range, b.Decs.Range) {
+			return false
+		}
+
+		// Node: X
+		if !equal(a.X, b.X, opts) {
+			return false
+		}
+
+		// Decoration: X
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.X, b.Decs.X) {
+			return false
+		}
+
+		// Node: Body
+		if !equal(a.Body, b.Body, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *ReturnStmt:
+		b, ok := b.(*ReturnStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Return
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Return, b.Decs.Return) {
+			return false
+		}
+
+		// List: Results
+		if len(a.Results) != len(b.Results) {
+			return false
+		}
+		for i := range a.Results {
+			if !equal(a.Results[i], b.Results[i], opts) {
+				return false
+			}
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *SelectStmt:
+		b, ok := b.(*SelectStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Select
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Select, b.Decs.Select) {
+			return false
+		}
+
+		// Node: Body
+		if !equal(a.Body, b.Body, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *SelectorExpr:
+		b, ok := b.(*SelectorExpr)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: X
+		if !equal(a.X, b.X, opts) {
+			return false
+		}
+
+		// Decoration: X
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.X, b.Decs.X) {
+			return false
+		}
+
+		// Node: Sel
+		if !equal(a.Sel, b.Sel, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *SendStmt:
+		b, ok := b.(*SendStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: Chan
+		if !equal(a.Chan, b.Chan, opts) {
+			return false
+		}
+
+		// Decoration: Chan
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Chan, b.Decs.Chan) {
+			return false
+		}
+
+		// Decoration: Arrow
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Arrow, b.Decs.Arrow) {
+			return false
+		}
+
+		// Node: Value
+		if !equal(a.Value, b.Value, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *SliceExpr:
+		b, ok := b.(*SliceExpr)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: X
+		if !equal(a.X, b.X, opts) {
+			return false
+		}
+
+		// Decoration: X
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.X, b.Decs.X) {
+			return false
+		}
+
+		// Decoration: Lbrack
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Lbrack, b.Decs.Lbrack) {
+			return false
+		}
+
+		// Node: Low
+		if !equal(a.Low, b.Low, opts) {
+			return false
+		}
+
+		// Decoration: Low
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Low, b.Decs.Low) {
+			return false
+		}
+
+		// Node: High
+		if !equal(a.High, b.High, opts) {
+			return false
+		}
+
+		// Decoration: High
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.High, b.Decs.High) {
+			return false
+		}
+
+		// Node: Max
+		if !equal(a.Max, b.Max, opts) {
+			return false
+		}
+
+		// Decoration: Max
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Max, b.Decs.Max) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		// Value: Slice3
+		if a.Slice3 != b.Slice3 {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *StarExpr:
+		b, ok := b.(*StarExpr)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Star
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Star, b.Decs.Star) {
+			return false
+		}
+
+		// Node: X
+		if !equal(a.X, b.X, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *StructType:
+		b, ok := b.(*StructType)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Struct
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Struct, b.Decs.Struct) {
+			return false
+		}
+
+		// Node: Fields
+		if !equal(a.Fields, b.Fields, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		// Value: Incomplete
+		if a.Incomplete != b.Incomplete {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *SwitchStmt:
+		b, ok := b.(*SwitchStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Switch
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Switch, b.Decs.Switch) {
+			return false
+		}
+
+		// Node: Init
+		if !equal(a.Init, b.Init, opts) {
+			return false
+		}
+
+		// Decoration: Init
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Init, b.Decs.Init) {
+			return false
+		}
+
+		// Node: Tag
+		if !equal(a.Tag, b.Tag, opts) {
+			return false
+		}
+
+		// Decoration: Tag
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Tag, b.Decs.Tag) {
+			return false
+		}
+
+		// Node: Body
+		if !equal(a.Body, b.Body, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *TypeAssertExpr:
+		b, ok := b.(*TypeAssertExpr)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: X
+		if !equal(a.X, b.X, opts) {
+			return false
+		}
+
+		// Decoration: X
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.X, b.Decs.X) {
+			return false
+		}
+
+		// Decoration: Lparen
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Lparen, b.Decs.Lparen) {
+			return false
+		}
+
+		// Node: Type
+		if !equal(a.Type, b.Type, opts) {
+			return false
+		}
+
+		// Decoration: Type
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Type, b.Decs.Type) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *TypeSpec:
+		b, ok := b.(*TypeSpec)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Node: Name
+		if !equal(a.Name, b.Name, opts) {
+			return false
+		}
+
+		// Node: TypeParams
+		if !equal(a.TypeParams, b.TypeParams, opts) {
+			return false
+		}
+
+		// Token: Assign
+		if a.Assign != b.Assign {
+			return false
+		}
+
+		// Decoration: TypeParams
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.TypeParams, b.Decs.TypeParams) {
+			return false
+		}
+
+		// Decoration: Name
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Name, b.Decs.Name) {
+			return false
+		}
+
+		// Node: Type
+		if !equal(a.Type, b.Type, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *TypeSwitchStmt:
+		b, ok := b.(*TypeSwitchStmt)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Decoration: Switch
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Switch, b.Decs.Switch) {
+			return false
+		}
+
+		// Node: Init
+		if !equal(a.Init, b.Init, opts) {
+			return false
+		}
+
+		// Decoration: Init
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Init, b.Decs.Init) {
+			return false
+		}
+
+		// Node: Assign
+		if !equal(a.Assign, b.Assign, opts) {
+			return false
+		}
+
+		// Decoration: Assign
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Assign, b.Decs.Assign) {
+			return false
+		}
+
+		// Node: Body
+		if !equal(a.Body, b.Body, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *UnaryExpr:
+		b, ok := b.(*UnaryExpr)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// Token: Op
+		if a.Op != b.Op {
+			return false
+		}
+
+		// Decoration: Op
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Op, b.Decs.Op) {
+			return false
+		}
+
+		// Node: X
+		if !equal(a.X, b.X, opts) {
+			return false
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	case *ValueSpec:
+		b, ok := b.(*ValueSpec)
+		if !ok {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.Before != b.Decs.Before {
+			return false
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Start, b.Decs.Start) {
+			return false
+		}
+
+		// List: Names
+		if len(a.Names) != len(b.Names) {
+			return false
+		}
+		for i := range a.Names {
+			if !equal(a.Names[i], b.Names[i], opts) {
+				return false
+			}
+		}
+
+		// Node: Type
+		if !equal(a.Type, b.Type, opts) {
+			return false
+		}
+
+		// Decoration: Assign
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.Assign, b.Decs.Assign) {
+			return false
+		}
+
+		// List: Values
+		if len(a.Values) != len(b.Values) {
+			return false
+		}
+		for i := range a.Values {
+			if !equal(a.Values[i], b.Values[i], opts) {
+				return false
+			}
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations && !equalDecorations(a.Decs.End, b.Decs.End) {
+			return false
+		}
+
+		if !opts.IgnoreSpacing && a.Decs.After != b.Decs.After {
+			return false
+		}
+
+		return true
+	default:
+		panic(fmt.Sprintf("%T", a))
+	}
+}
+
+// hashNode writes the structure of n to h. See Hash.
+func hashNode(h *hasher, n Node, opts *EqualOptions) {
+	n = nilNode(n)
+	if n == nil {
+		h.string("nil")
+		return
+	}
+	switch n := n.(type) {
+	case *ArrayType:
+		h.string("ArrayType")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Lbrack
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Lbrack)
+		}
+
+		// Node: Len
+		hashNode(h, n.Len, opts)
+
+		// Decoration: Len
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Len)
+		}
+
+		// Node: Elt
+		hashNode(h, n.Elt, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *AssignStmt:
+		h.string("AssignStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// List: Lhs
+		h.int(len(n.Lhs))
+		for _, v := range n.Lhs {
+			hashNode(h, v, opts)
+		}
+
+		// Token: Tok
+		h.int(int(n.Tok))
+
+		// Decoration: Tok
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Tok)
+		}
+
+		// List: Rhs
+		h.int(len(n.Rhs))
+		for _, v := range n.Rhs {
+			hashNode(h, v, opts)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *BadDecl:
+		h.string("BadDecl")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Bad
+		h.int(n.Length)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *BadExpr:
+		h.string("BadExpr")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Bad
+		h.int(n.Length)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *BadStmt:
+		h.string("BadStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Bad
+		h.int(n.Length)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *BasicLit:
+		h.string("BasicLit")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// String: Value
+		h.string(n.Value)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		// Value: Kind
+		h.value(n.Kind)
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *BinaryExpr:
+		h.string("BinaryExpr")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: X
+		hashNode(h, n.X, opts)
+
+		// Decoration: X
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.X)
+		}
+
+		// Token: Op
+		h.int(int(n.Op))
+
+		// Decoration: Op
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Op)
+		}
+
+		// Node: Y
+		hashNode(h, n.Y, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *BlockStmt:
+		h.string("BlockStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Lbrace
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Lbrace)
+		}
+
+		// List: List
+		h.int(len(n.List))
+		for _, v := range n.List {
+			hashNode(h, v, opts)
+		}
+
+		// Token: Rbrace
+		h.bool(n.RbraceHasNoPos)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *BranchStmt:
+		h.string("BranchStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Token: Tok
+		h.int(int(n.Tok))
+
+		// Decoration: Tok
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Tok)
+		}
+
+		// Node: Label
+		hashNode(h, n.Label, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *CallExpr:
+		h.string("CallExpr")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: Fun
+		hashNode(h, n.Fun, opts)
+
+		// Decoration: Fun
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Fun)
+		}
+
+		// Decoration: Lparen
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Lparen)
+		}
+
+		// List: Args
+		h.int(len(n.Args))
+		for _, v := range n.Args {
+			hashNode(h, v, opts)
+		}
+
+		// Token: Ellipsis
+		h.bool(n.Ellipsis)
+
+		// Decoration: Ellipsis
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Ellipsis)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *CaseClause:
+		h.string("CaseClause")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Case
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Case)
+		}
+
+		// List: List
+		h.int(len(n.List))
+		for _, v := range n.List {
+			hashNode(h, v, opts)
+		}
+
+		// Decoration: Colon
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Colon)
+		}
+
+		// List: Body
+		h.int(len(n.Body))
+		for _, v := range n.Body {
+			hashNode(h, v, opts)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *ChanType:
+		h.string("ChanType")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Begin
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Begin)
+		}
+
+		// Decoration: Arrow
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Arrow)
+		}
+
+		// Node: Value
+		hashNode(h, n.Value, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		// Value: Dir
+		h.value(n.Dir)
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *CommClause:
+		h.string("CommClause")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Case
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Case)
+		}
+
+		// Node: Comm
+		hashNode(h, n.Comm, opts)
+
+		// Decoration: Comm
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Comm)
+		}
+
+		// Decoration: Colon
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Colon)
+		}
+
+		// List: Body
+		h.int(len(n.Body))
+		for _, v := range n.Body {
+			hashNode(h, v, opts)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *CompositeLit:
+		h.string("CompositeLit")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: Type
+		hashNode(h, n.Type, opts)
+
+		// Decoration: Type
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Type)
+		}
+
+		// Decoration: Lbrace
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Lbrace)
+		}
+
+		// List: Elts
+		h.int(len(n.Elts))
+		for _, v := range n.Elts {
+			hashNode(h, v, opts)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		// Value: Incomplete
+		h.value(n.Incomplete)
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *DeclStmt:
+		h.string("DeclStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: Decl
+		hashNode(h, n.Decl, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *DeferStmt:
+		h.string("DeferStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Defer
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Defer)
+		}
+
+		// Node: Call
+		hashNode(h, n.Call, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *Ellipsis:
+		h.string("Ellipsis")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Ellipsis
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Ellipsis)
+		}
+
+		// Node: Elt
+		hashNode(h, n.Elt, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *EmptyStmt:
+		h.string("EmptyStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		// Value: Implicit
+		h.value(n.Implicit)
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *ExprStmt:
+		h.string("ExprStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: X
+		hashNode(h, n.X, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *Field:
+		h.string("Field")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// List: Names
+		h.int(len(n.Names))
+		for _, v := range n.Names {
+			hashNode(h, v, opts)
+		}
+
+		// Node: Type
+		hashNode(h, n.Type, opts)
+
+		// Decoration: Type
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Type)
+		}
+
+		// Node: Tag
+		hashNode(h, n.Tag, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *FieldList:
+		h.string("FieldList")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Token: Opening
+		h.bool(n.Opening)
+
+		// Decoration: Opening
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Opening)
+		}
+
+		// List: List
+		h.int(len(n.List))
+		for _, v := range n.List {
+			hashNode(h, v, opts)
+		}
+
+		// Token: Closing
+		h.bool(n.Closing)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *File:
+		h.string("File")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Package
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Package)
+		}
+
+		// Node: Name
+		hashNode(h, n.Name, opts)
+
+		// Decoration: Name
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Name)
+		}
+
+		// List: Decls
+		h.int(len(n.Decls))
+		for _, v := range n.Decls {
+			hashNode(h, v, opts)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		// Scope: Scope
+		if !opts.IgnoreObjects {
+			h.scope(n.Scope)
+		}
+
+		// List: Imports
+		h.int(len(n.Imports))
+		for _, v := range n.Imports {
+			hashNode(h, v, opts)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *ForStmt:
+		h.string("ForStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: For
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.For)
+		}
+
+		// Node: Init
+		hashNode(h, n.Init, opts)
+
+		// Decoration: Init
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Init)
+		}
+
+		// Node: Cond
+		hashNode(h, n.Cond, opts)
+
+		// Decoration: Cond
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Cond)
+		}
+
+		// Node: Post
+		hashNode(h, n.Post, opts)
+
+		// Decoration: Post
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Post)
+		}
+
+		// Node: Body
+		hashNode(h, n.Body, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *FuncDecl:
+		h.string("FuncDecl")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Special decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Type.Decs.Start)
+		}
+
+		// Token: Func
+		h.bool(n.Type.Func)
+
+		// Decoration: Func
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Func)
+		}
+
+		// Special decoration: Func
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Type.Decs.Func)
+		}
+
+		// Node: Recv
+		hashNode(h, n.Recv, opts)
+
+		// Decoration: Recv
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Recv)
+		}
+
+		// Node: Name
+		hashNode(h, n.Name, opts)
+
+		// Decoration: Name
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Name)
+		}
+
+		// Node: TypeParams
+		hashNode(h, n.Type.TypeParams, opts)
+
+		// Decoration: TypeParams
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.TypeParams)
+		}
+
+		// Special decoration: TypeParams
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Type.Decs.TypeParams)
+		}
+
+		// Node: Params
+		hashNode(h, n.Type.Params, opts)
+
+		// Decoration: Params
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Params)
+		}
+
+		// Special decoration: Params
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Type.Decs.Params)
+		}
+
+		// Node: Results
+		hashNode(h, n.Type.Results, opts)
+
+		// Decoration: Results
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Results)
+		}
+
+		// Special decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Type.Decs.End)
+		}
+
+		// Node: Body
+		hashNode(h, n.Body, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *FuncLit:
+		h.string("FuncLit")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: Type
+		hashNode(h, n.Type, opts)
+
+		// Decoration: Type
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Type)
+		}
+
+		// Node: Body
+		hashNode(h, n.Body, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *FuncType:
+		h.string("FuncType")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Token: Func
+		h.bool(n.Func)
+
+		// Decoration: Func
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Func)
+		}
+
+		// Node: TypeParams
+		hashNode(h, n.TypeParams, opts)
+
+		// Decoration: TypeParams
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.TypeParams)
+		}
+
+		// Node: Params
+		hashNode(h, n.Params, opts)
+
+		// Decoration: Params
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Params)
+		}
+
+		// Node: Results
+		hashNode(h, n.Results, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *GenDecl:
+		h.string("GenDecl")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Token: Tok
+		h.int(int(n.Tok))
+
+		// Decoration: Tok
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Tok)
+		}
+
+		// Token: Lparen
+		h.bool(n.Lparen)
+
+		// Decoration: Lparen
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Lparen)
+		}
+
+		// List: Specs
+		h.int(len(n.Specs))
+		for _, v := range n.Specs {
+			hashNode(h, v, opts)
+		}
+
+		// Token: Rparen
+		h.bool(n.Rparen)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *GoStmt:
+		h.string("GoStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Go
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Go)
+		}
+
+		// Node: Call
+		hashNode(h, n.Call, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *Ident:
+		h.string("Ident")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: X
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.X)
+		}
+
+		// String: Name
+		h.string(n.Name)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		// Object: Obj
+		if !opts.IgnoreObjects {
+			h.object(n.Obj)
+		}
+
+		// Path: Path
+		h.string(opts.path(n.Path))
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *IfStmt:
+		h.string("IfStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: If
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.If)
+		}
+
+		// Node: Init
+		hashNode(h, n.Init, opts)
+
+		// Decoration: Init
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Init)
+		}
+
+		// Node: Cond
+		hashNode(h, n.Cond, opts)
+
+		// Decoration: Cond
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Cond)
+		}
+
+		// Node: Body
+		hashNode(h, n.Body, opts)
+
+		// Decoration: Else
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Else)
+		}
+
+		// Node: Else
+		hashNode(h, n.Else, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *ImportSpec:
+		h.string("ImportSpec")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: Name
+		hashNode(h, n.Name, opts)
+
+		// Decoration: Name
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Name)
+		}
+
+		// Node: Path
+		hashNode(h, n.Path, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *IncDecStmt:
+		h.string("IncDecStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: X
+		hashNode(h, n.X, opts)
+
+		// Decoration: X
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.X)
+		}
+
+		// Token: Tok
+		h.int(int(n.Tok))
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *IndexExpr:
+		h.string("IndexExpr")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: X
+		hashNode(h, n.X, opts)
+
+		// Decoration: X
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.X)
+		}
+
+		// Decoration: Lbrack
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Lbrack)
+		}
+
+		// Node: Index
+		hashNode(h, n.Index, opts)
+
+		// Decoration: Index
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Index)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *IndexListExpr:
+		h.string("IndexListExpr")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: X
+		hashNode(h, n.X, opts)
+
+		// Decoration: X
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.X)
+		}
+
+		// Decoration: Lbrack
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Lbrack)
+		}
+
+		// List: Indices
+		h.int(len(n.Indices))
+		for _, v := range n.Indices {
+			hashNode(h, v, opts)
+		}
+
+		// Decoration: Indices
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Indices)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *InterfaceType:
+		h.string("InterfaceType")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Interface
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Interface)
+		}
+
+		// Node: Methods
+		hashNode(h, n.Methods, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		// Value: Incomplete
+		h.value(n.Incomplete)
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *KeyValueExpr:
+		h.string("KeyValueExpr")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: Key
+		hashNode(h, n.Key, opts)
+
+		// Decoration: Key
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Key)
+		}
+
+		// Decoration: Colon
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Colon)
+		}
+
+		// Node: Value
+		hashNode(h, n.Value, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *LabeledStmt:
+		h.string("LabeledStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: Label
+		hashNode(h, n.Label, opts)
+
+		// Decoration: Label
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Label)
+		}
+
+		// Decoration: Colon
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Colon)
+		}
+
+		// Node: Stmt
+		hashNode(h, n.Stmt, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *MapType:
+		h.string("MapType")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Map
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Map)
+		}
+
+		// Node: Key
+		hashNode(h, n.Key, opts)
+
+		// Decoration: Key
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Key)
+		}
+
+		// Node: Value
+		hashNode(h, n.Value, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *Package:
+		h.string("Package")
+
+		// Value: Name
+		h.value(n.Name)
+
+		// Scope: Scope
+		if !opts.IgnoreObjects {
+			h.scope(n.Scope)
+		}
+
+		// Map: Imports
+		h.int(len(n.Imports))
+		{
+			var keys []string
+			for k := range n.Imports {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				h.string(k)
+				if !opts.IgnoreObjects {
+					h.object(n.Imports[k])
+				}
+			}
+		}
+
+		// Map: Files
+		h.int(len(n.Files))
+		{
+			var keys []string
+			for k := range n.Files {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				h.string(k)
+				hashNode(h, n.Files[k], opts)
+			}
+		}
+	case *ParenExpr:
+		h.string("ParenExpr")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Lparen
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Lparen)
+		}
+
+		// Node: X
+		hashNode(h, n.X, opts)
+
+		// Decoration: X
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.X)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *RangeStmt:
+		h.string("RangeStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: For
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.For)
+		}
+
+		// Node: Key
+		hashNode(h, n.Key, opts)
+
+		// Decoration: Key
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Key)
+		}
+
+		// Node: Value
+		hashNode(h, n.Value, opts)
+
+		// Decoration: Value
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Value)
+		}
+
+		// Token: Tok
+		h.int(int(n.Tok))
+
+		// Decoration: Range
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Range)
+		}
+
+		// Node: X
+		hashNode(h, n.X, opts)
+
+		// Decoration: X
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.X)
+		}
+
+		// Node: Body
+		hashNode(h, n.Body, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *ReturnStmt:
+		h.string("ReturnStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Return
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Return)
+		}
+
+		// List: Results
+		h.int(len(n.Results))
+		for _, v := range n.Results {
+			hashNode(h, v, opts)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *SelectStmt:
+		h.string("SelectStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Select
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Select)
+		}
+
+		// Node: Body
+		hashNode(h, n.Body, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *SelectorExpr:
+		h.string("SelectorExpr")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: X
+		hashNode(h, n.X, opts)
+
+		// Decoration: X
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.X)
+		}
+
+		// Node: Sel
+		hashNode(h, n.Sel, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *SendStmt:
+		h.string("SendStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: Chan
+		hashNode(h, n.Chan, opts)
+
+		// Decoration: Chan
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Chan)
+		}
+
+		// Decoration: Arrow
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Arrow)
+		}
+
+		// Node: Value
+		hashNode(h, n.Value, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *SliceExpr:
+		h.string("SliceExpr")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: X
+		hashNode(h, n.X, opts)
+
+		// Decoration: X
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.X)
+		}
+
+		// Decoration: Lbrack
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Lbrack)
+		}
+
+		// Node: Low
+		hashNode(h, n.Low, opts)
+
+		// Decoration: Low
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Low)
+		}
+
+		// Node: High
+		hashNode(h, n.High, opts)
+
+		// Decoration: High
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.High)
+		}
+
+		// Node: Max
+		hashNode(h, n.Max, opts)
+
+		// Decoration: Max
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Max)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		// Value: Slice3
+		h.value(n.Slice3)
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *StarExpr:
+		h.string("StarExpr")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Star
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Star)
+		}
+
+		// Node: X
+		hashNode(h, n.X, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *StructType:
+		h.string("StructType")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Struct
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Struct)
+		}
+
+		// Node: Fields
+		hashNode(h, n.Fields, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		// Value: Incomplete
+		h.value(n.Incomplete)
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *SwitchStmt:
+		h.string("SwitchStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Switch
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Switch)
+		}
+
+		// Node: Init
+		hashNode(h, n.Init, opts)
+
+		// Decoration: Init
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Init)
+		}
+
+		// Node: Tag
+		hashNode(h, n.Tag, opts)
+
+		// Decoration: Tag
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Tag)
+		}
+
+		// Node: Body
+		hashNode(h, n.Body, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *TypeAssertExpr:
+		h.string("TypeAssertExpr")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: X
+		hashNode(h, n.X, opts)
+
+		// Decoration: X
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.X)
+		}
+
+		// Decoration: Lparen
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Lparen)
+		}
+
+		// Node: Type
+		hashNode(h, n.Type, opts)
+
+		// Decoration: Type
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Type)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *TypeSpec:
+		h.string("TypeSpec")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Node: Name
+		hashNode(h, n.Name, opts)
+
+		// Node: TypeParams
+		hashNode(h, n.TypeParams, opts)
+
+		// Token: Assign
+		h.bool(n.Assign)
+
+		// Decoration: TypeParams
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.TypeParams)
+		}
+
+		// Decoration: Name
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Name)
+		}
+
+		// Node: Type
+		hashNode(h, n.Type, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *TypeSwitchStmt:
+		h.string("TypeSwitchStmt")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Decoration: Switch
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Switch)
+		}
+
+		// Node: Init
+		hashNode(h, n.Init, opts)
+
+		// Decoration: Init
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Init)
+		}
+
+		// Node: Assign
+		hashNode(h, n.Assign, opts)
+
+		// Decoration: Assign
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Assign)
+		}
+
+		// Node: Body
+		hashNode(h, n.Body, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *UnaryExpr:
+		h.string("UnaryExpr")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// Token: Op
+		h.int(int(n.Op))
+
+		// Decoration: Op
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Op)
+		}
+
+		// Node: X
+		hashNode(h, n.X, opts)
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	case *ValueSpec:
+		h.string("ValueSpec")
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.Before))
+		}
+
+		// Decoration: Start
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Start)
+		}
+
+		// List: Names
+		h.int(len(n.Names))
+		for _, v := range n.Names {
+			hashNode(h, v, opts)
+		}
+
+		// Node: Type
+		hashNode(h, n.Type, opts)
+
+		// Decoration: Assign
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.Assign)
+		}
+
+		// List: Values
+		h.int(len(n.Values))
+		for _, v := range n.Values {
+			hashNode(h, v, opts)
+		}
+
+		// Decoration: End
+		if !opts.IgnoreDecorations {
+			h.decorations(n.Decs.End)
+		}
+
+		if !opts.IgnoreSpacing {
+			h.int(int(n.Decs.After))
+		}
+	default:
+		panic(fmt.Sprintf("%T", n))
+	}
+}
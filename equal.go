@@ -0,0 +1,152 @@
+package dst
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"reflect"
+	"sort"
+)
+
+// EqualOptions configures Equal and Hash. The zero value compares every part of the nodes.
+type EqualOptions struct {
+	// IgnoreDecorations ignores comments and other decorations (e.g. Decs.Start and Decs.End).
+	IgnoreDecorations bool
+
+	// IgnoreSpacing ignores the Before and After SpaceType of each node.
+	IgnoreSpacing bool
+
+	// IgnoreObjects ignores the Obj and Scope links. If not set, Objects are equal if they have the
+	// same Kind and Name, and Scopes are equal if they contain Objects with the same names and
+	// kinds.
+	IgnoreObjects bool
+
+	// NormalizePath, if not nil, is applied to the Path of each Ident before it is compared (e.g.
+	// to strip a vendor directory prefix). To ignore Path, use a func that returns "".
+	NormalizePath func(path string) string
+}
+
+// Equal returns true if a and b are structurally equal. Both trees are compared node by node,
+// including decorations, SpaceType and the Path of identifiers, unless the options specify
+// otherwise.
+func Equal(a, b Node, opts EqualOptions) bool {
+	return equal(a, b, &opts)
+}
+
+// Hash returns a structural hash of n. The hash is stable across runs, and nodes that are equal
+// according to Equal with the same options have the same hash.
+func Hash(n Node, opts EqualOptions) uint64 {
+	h := &hasher{h: fnv.New64a()}
+	hashNode(h, n, &opts)
+	return h.h.Sum64()
+}
+
+func (o *EqualOptions) path(path string) string {
+	if o.NormalizePath == nil {
+		return path
+	}
+	return o.NormalizePath(path)
+}
+
+// nilNode converts a typed nil node into an untyped nil.
+func nilNode(n Node) Node {
+	if v := reflect.ValueOf(n); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	return n
+}
+
+func equalDecorations(a, b Decorations) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalObject(a, b *Object) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Kind == b.Kind && a.Name == b.Name
+}
+
+func equalScope(a, b *Scope) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if len(a.Objects) != len(b.Objects) {
+		return false
+	}
+	for name, o := range a.Objects {
+		if !equalObject(o, b.Objects[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+type hasher struct {
+	h   hash.Hash64
+	buf [binary.MaxVarintLen64]byte
+}
+
+func (h *hasher) int(i int) {
+	n := binary.PutVarint(h.buf[:], int64(i))
+	h.h.Write(h.buf[:n])
+}
+
+func (h *hasher) bool(b bool) {
+	if b {
+		h.int(1)
+	} else {
+		h.int(0)
+	}
+}
+
+func (h *hasher) string(s string) {
+	// the length prefix ensures the boundaries between strings are part of the hash
+	h.int(len(s))
+	h.h.Write([]byte(s))
+}
+
+func (h *hasher) value(v interface{}) {
+	h.string(fmt.Sprint(v))
+}
+
+func (h *hasher) decorations(d Decorations) {
+	h.int(len(d))
+	for _, s := range d {
+		h.string(s)
+	}
+}
+
+func (h *hasher) object(o *Object) {
+	if o == nil {
+		h.string("nil")
+		return
+	}
+	h.int(int(o.Kind))
+	h.string(o.Name)
+}
+
+func (h *hasher) scope(s *Scope) {
+	if s == nil {
+		h.string("nil")
+		return
+	}
+	var names []string
+	for name := range s.Objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		h.string(name)
+		h.object(s.Objects[name])
+	}
+}
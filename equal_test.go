@@ -0,0 +1,186 @@
+package dst_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		skip, solo bool
+		name       string
+		a, b       string
+		opts       dst.EqualOptions
+		expect     bool
+		modify     func(f *dst.File)
+	}{
+		{
+			name:   "same",
+			a:      "package a\n\nfunc f() { g(1) }",
+			b:      "package a\n\nfunc f() { g(1) }",
+			expect: true,
+		},
+		{
+			name: "different",
+			a:    "package a\n\nfunc f() { g(1) }",
+			b:    "package a\n\nfunc f() { g(2) }",
+		},
+		{
+			name: "different type",
+			a:    "package a\n\nvar a = b",
+			b:    "package a\n\nvar a = *b",
+		},
+		{
+			name: "different token",
+			a:    "package a\n\nvar a = b + c",
+			b:    "package a\n\nvar a = b - c",
+		},
+		{
+			name: "comment",
+			a:    "package a\n\n// f\nfunc f() {}",
+			b:    "package a\n\nfunc f() {}",
+		},
+		{
+			name:   "comment ignored",
+			a:      "package a\n\n// f\nfunc f() {}",
+			b:      "package a\n\nfunc f() {}",
+			opts:   dst.EqualOptions{IgnoreDecorations: true},
+			expect: true,
+		},
+		{
+			name: "spacing",
+			a:    "package a\n\nfunc f() {\n\ta()\n\n\tb()\n}",
+			b:    "package a\n\nfunc f() {\n\ta()\n\tb()\n}",
+		},
+		{
+			name:   "spacing ignored",
+			a:      "package a\n\nfunc f() {\n\ta()\n\n\tb()\n}",
+			b:      "package a\n\nfunc f() {\n\ta()\n\tb()\n}",
+			opts:   dst.EqualOptions{IgnoreSpacing: true},
+			expect: true,
+		},
+		{
+			name: "object",
+			a:    "package a\n\nfunc f(a int) { a++ }",
+			b:    "package a\n\nfunc f(b int) { a++ }",
+			modify: func(f *dst.File) {
+				// rename the parameter so only the resolved objects differ
+				f.Decls[0].(*dst.FuncDecl).Type.Params.List[0].Names[0].Name = "a"
+			},
+		},
+		{
+			name: "object ignored",
+			a:    "package a\n\nfunc f(a int) { a++ }",
+			b:    "package a\n\nfunc f(b int) { a++ }",
+			modify: func(f *dst.File) {
+				f.Decls[0].(*dst.FuncDecl).Type.Params.List[0].Names[0].Name = "a"
+			},
+			opts:   dst.EqualOptions{IgnoreObjects: true},
+			expect: true,
+		},
+		{
+			name: "func type decoration",
+			a:    "package a\n\nfunc f() {}",
+			b:    "package a\n\nfunc f() {}",
+			modify: func(f *dst.File) {
+				f.Decls[0].(*dst.FuncDecl).Type.Decs.Params.Append("/* a */")
+			},
+		},
+		{
+			name: "func type decoration ignored",
+			a:    "package a\n\nfunc f() {}",
+			b:    "package a\n\nfunc f() {}",
+			modify: func(f *dst.File) {
+				f.Decls[0].(*dst.FuncDecl).Type.Decs.Params.Append("/* a */")
+			},
+			opts:   dst.EqualOptions{IgnoreDecorations: true},
+			expect: true,
+		},
+		{
+			name: "path",
+			a:    "package a\n\nvar a = b",
+			b:    "package a\n\nvar a = b",
+			modify: func(f *dst.File) {
+				f.Decls[0].(*dst.GenDecl).Specs[0].(*dst.ValueSpec).Values[0].(*dst.Ident).Path = "x/vendor/b"
+			},
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		if test.skip {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			a, err := decorator.Parse(test.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := decorator.Parse(test.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.modify != nil {
+				test.modify(b)
+			}
+			if found := dst.Equal(a, b, test.opts); found != test.expect {
+				t.Fatalf("expected Equal %v, found %v", test.expect, found)
+			}
+			if found := dst.Hash(a, test.opts) == dst.Hash(b, test.opts); found != test.expect {
+				t.Fatalf("expected equal hashes %v, found %v", test.expect, found)
+			}
+			// Clone doesn't copy objects or scopes
+			opts := test.opts
+			opts.IgnoreObjects = true
+			for _, n := range []dst.Node{a, b} {
+				if c := dst.Clone(n); !dst.Equal(n, c, opts) || dst.Hash(n, opts) != dst.Hash(c, opts) {
+					t.Fatal("expected clone to be equal")
+				}
+			}
+		})
+	}
+}
+
+func TestEqualNormalizePath(t *testing.T) {
+	a := &dst.Ident{Name: "B", Path: "a/vendor/b"}
+	b := &dst.Ident{Name: "B", Path: "b"}
+	if dst.Equal(a, b, dst.EqualOptions{}) {
+		t.Fatal("expected different paths to be unequal")
+	}
+	opts := dst.EqualOptions{NormalizePath: func(path string) string {
+		if i := strings.LastIndex(path, "/vendor/"); i > -1 {
+			return path[i+len("/vendor/"):]
+		}
+		return path
+	}}
+	if !dst.Equal(a, b, opts) {
+		t.Fatal("expected normalized paths to be equal")
+	}
+	if dst.Hash(a, opts) != dst.Hash(b, opts) {
+		t.Fatal("expected normalized paths to have equal hashes")
+	}
+}
+
+func TestEqualNil(t *testing.T) {
+	var e *dst.Ident
+	if !dst.Equal(nil, e, dst.EqualOptions{}) {
+		t.Fatal("expected nil and typed nil to be equal")
+	}
+	if dst.Equal(nil, dst.NewIdent("a"), dst.EqualOptions{}) {
+		t.Fatal("expected nil and non-nil to be unequal")
+	}
+	if dst.Hash(nil, dst.EqualOptions{}) != dst.Hash(e, dst.EqualOptions{}) {
+		t.Fatal("expected nil and typed nil to have equal hashes")
+	}
+}
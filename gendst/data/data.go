@@ -416,6 +416,54 @@ var Info = map[string][]Part{
 			Name: "End",
 		},
 	},
+	/*
+		// An IndexListExpr node represents an expression followed by multiple
+		// indices.
+		IndexListExpr struct {
+			X       Expr      // expression
+			Lbrack  token.Pos // position of "["
+			Indices []Expr    // index expressions
+			Rbrack  token.Pos // position of "]"
+		}
+	*/
+	"IndexListExpr": {
+		Decoration{
+			Name: "Start",
+		},
+		Node{
+			Name:  "X",
+			Field: Field{"X"},
+			Type:  Iface{"Expr"},
+		},
+		Decoration{
+			Name: "X",
+		},
+		Token{
+			Name:          "Lbrack",
+			Token:         Basic{jen.Qual("go/token", "LBRACK")},
+			PositionField: Field{"Lbrack"},
+		},
+		Decoration{
+			Name: "Lbrack",
+		},
+		List{
+			Name:      "Indices",
+			Field:     Field{"Indices"},
+			Elem:      Iface{"Expr"},
+			Separator: token.COMMA,
+		},
+		Decoration{
+			Name: "Indices",
+		},
+		Token{
+			Name:          "Rbrack",
+			Token:         Basic{jen.Qual("go/token", "RBRACK")},
+			PositionField: Field{"Rbrack"},
+		},
+		Decoration{
+			Name: "End",
+		},
+	},
 	/*
 		// An SliceExpr node represents an expression followed by slice indices.
 		SliceExpr struct {
@@ -835,9 +883,10 @@ var Info = map[string][]Part{
 	/*
 		// A FuncType node represents a function type.
 		FuncType struct {
-			Func    token.Pos  // position of "func" keyword (token.NoPos if there is no "func")
-			Params  *FieldList // (incoming) parameters; non-nil
-			Results *FieldList // (outgoing) results; or nil
+			Func       token.Pos  // position of "func" keyword (token.NoPos if there is no "func")
+			TypeParams *FieldList // type parameters; or nil
+			Params     *FieldList // (incoming) parameters; non-nil
+			Results    *FieldList // (outgoing) results; or nil
 		}
 	*/
 	"FuncType": {
@@ -861,6 +910,15 @@ var Info = map[string][]Part{
 				Dst: Expr(func(n *jen.Statement) *jen.Statement { return n.Dot("Func") }),
 			},
 		},
+		Node{
+			Name:  "TypeParams",
+			Field: Field{"TypeParams"},
+			Type:  Struct{"FieldList"},
+		},
+		Decoration{
+			Name: "TypeParams",
+			Use:  Expr(func(n *jen.Statement) *jen.Statement { return n.Dot("TypeParams").Op("!=").Nil() }),
+		},
 		Node{
 			Name:  "Params",
 			Field: Field{"Params"},
@@ -1934,11 +1992,12 @@ var Info = map[string][]Part{
 	/*
 		// A TypeSpec node represents a type declaration (TypeSpec production).
 		TypeSpec struct {
-			Doc     *CommentGroup // associated documentation; or nil
-			Name    *Ident        // type name
-			Assign  token.Pos     // position of '=', if any
-			Type    Expr          // *Ident, *ParenExpr, *SelectorExpr, *StarExpr, or any of the *XxxTypes
-			Comment *CommentGroup // line comments; or nil
+			Doc        *CommentGroup // associated documentation; or nil
+			Name       *Ident        // type name
+			TypeParams *FieldList    // type parameters; or nil
+			Assign     token.Pos     // position of '=', if any
+			Type       Expr          // *Ident, *ParenExpr, *SelectorExpr, *StarExpr, or any of the *XxxTypes
+			Comment    *CommentGroup // line comments; or nil
 		}
 	*/
 	"TypeSpec": {
@@ -1950,6 +2009,11 @@ var Info = map[string][]Part{
 			Field: Field{"Name"},
 			Type:  Struct{"Ident"},
 		},
+		Node{
+			Name:  "TypeParams",
+			Field: Field{"TypeParams"},
+			Type:  Struct{"FieldList"},
+		},
 		Token{
 			Name:  "Assign",
 			Token: Basic{jen.Qual("go/token", "ASSIGN")},
@@ -1961,7 +2025,14 @@ var Info = map[string][]Part{
 			PositionField: Field{"Assign"},
 		},
 		Decoration{
+			Name: "TypeParams",
+			Use:  Expr(func(n *jen.Statement) *jen.Statement { return n.Dot("TypeParams").Op("!=").Nil() }),
+		},
+		Decoration{
+			// The Name attachment point is after the "=" token (if any), so when there are type
+			// parameters it is in the same position as TypeParams, and is not used.
 			Name: "Name",
+			Use:  Expr(func(n *jen.Statement) *jen.Statement { return n.Dot("TypeParams").Op("==").Nil() }),
 		},
 		Node{
 			Name:  "Type",
@@ -2083,9 +2154,10 @@ var Info = map[string][]Part{
 
 		// A FuncType node represents a function type.
 		FuncType struct {
-			Func    token.Pos  // position of "func" keyword (token.NoPos if there is no "func")
-			Params  *FieldList // (incoming) parameters; non-nil
-			Results *FieldList // (outgoing) results; or nil
+			Func       token.Pos  // position of "func" keyword (token.NoPos if there is no "func")
+			TypeParams *FieldList // type parameters; or nil
+			Params     *FieldList // (incoming) parameters; non-nil
+			Results    *FieldList // (outgoing) results; or nil
 		}
 	*/
 	"FuncDecl": {
@@ -2135,6 +2207,20 @@ var Info = map[string][]Part{
 		Decoration{
 			Name: "Name",
 		},
+		Node{
+			Name:  "TypeParams",
+			Field: InnerField{"Type", "TypeParams"},
+			Type:  Struct{"FieldList"},
+		},
+		Decoration{
+			Name: "TypeParams",
+			Use:  Expr(func(n *jen.Statement) *jen.Statement { return n.Dot("Type").Dot("TypeParams").Op("!=").Nil() }),
+		},
+		SpecialDecoration{
+			// This renders any decorations from n.Type.TypeParams (but never saves them there)
+			Name: "TypeParams",
+			Decs: InnerField{"Type", "Decs"},
+		},
 		Node{
 			Name:  "Params",
 			Field: InnerField{"Type", "Params"},
@@ -2288,6 +2374,7 @@ var Exprs = map[string]bool{
 	"ParenExpr":      true,
 	"SelectorExpr":   true,
 	"IndexExpr":      true,
+	"IndexListExpr":  true,
 	"SliceExpr":      true,
 	"TypeAssertExpr": true,
 	"CallExpr":       true,
@@ -46,6 +46,9 @@ var F = /*Start*/ tt. /*X*/ F /*End*/ ()
 // IndexExpr
 var G = /*Start*/ []int{0} /*X*/ [ /*Lbrack*/ 0 /*Index*/] /*End*/
 
+// IndexListExpr
+var G1 = /*Start*/ GF /*X*/ [ /*Lbrack*/ int, string /*Indices*/] /*End*/
+
 // SliceExpr(0)
 var H = /*Start*/ []int{0, 1, 2} /*X*/ [ /*Lbrack*/ 1: /*Low*/ 2: /*High*/ 3 /*Max*/] /*End*/
 
@@ -73,12 +76,22 @@ var L = /*Start*/ C /*Fun*/ ( /*Lparen*/ 0, []int{}... /*Ellipsis*/) /*End*/
 // StarExpr
 var N = /*Start*/ * /*Star*/ p /*End*/
 
-// UnaryExpr
+// UnaryExpr(0)
 var O = /*Start*/ ^ /*Op*/ 1 /*End*/
 
-// BinaryExpr
+// UnaryExpr(1)
+type O1 interface {
+	/*Start*/ ~ /*Op*/ int /*End*/
+}
+
+// BinaryExpr(0)
 var P = /*Start*/ 1 /*X*/ & /*Op*/ 2 /*End*/
 
+// BinaryExpr(1)
+type P1 interface {
+	/*Start*/ ~int /*X*/ | /*Op*/ ~string /*End*/
+}
+
 // KeyValueExpr
 var Q = map[string]string{
 	/*Start*/ "a" /*Key*/ : /*Colon*/ "a", /*End*/
@@ -316,6 +329,22 @@ func /*Func*/ (a *A) /*Recv*/ f /*Name*/ (d, e int) /*Params*/ (f, g int) /*Resu
 	return
 } /*End*/
 
+// FuncDecl(3)
+/*Start*/
+func /*Func*/ h /*Name*/ [P any] /*TypeParams*/ (d P) /*Params*/ {
+	return
+} /*End*/
+
+// TypeSpec(2)
+type (
+	/*Start*/ T3[P any] /*TypeParams*/ []P /*End*/
+)
+
+// TypeSpec(3)
+type (
+	/*Start*/ T4[P any] = /*TypeParams*/ T3[P] /*End*/
+)
+
 // --
 
 type TT int
@@ -323,3 +352,5 @@ type TT int
 func (TT) F() int { return 0 }
 
 var tt TT
+
+func GF[P, Q any]() {}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/dave/dst/gendst/data"
+	. "github.com/dave/jennifer/jen"
+)
+
+// notest
+
+func generateEqual(names []string) error {
+
+	f := NewFilePathName(DSTPATH, "dst")
+
+	// returnFalse returns a statement that returns false if the condition is true
+	returnFalse := func(cond *Statement) *Statement {
+		return If(cond).Block(Return(False()))
+	}
+
+	f.Comment("equal returns true if a and b are structurally equal. See Equal.")
+	f.Func().Id("equal").Params(List(Id("a"), Id("b")).Id("Node"), Id("opts").Op("*").Id("EqualOptions")).Bool().BlockFunc(func(g *Group) {
+		g.Id("a").Op("=").Id("nilNode").Call(Id("a"))
+		g.Id("b").Op("=").Id("nilNode").Call(Id("b"))
+		g.If(Id("a").Op("==").Nil().Op("||").Id("b").Op("==").Nil()).Block(
+			Return(Id("a").Op("==").Nil().Op("&&").Id("b").Op("==").Nil()),
+		)
+		g.Switch(Id("a").Op(":=").Id("a").Assert(Id("type"))).BlockFunc(func(g *Group) {
+			for _, nodeName := range names {
+				g.Case(Op("*").Qual(DSTPATH, nodeName)).BlockFunc(func(g *Group) {
+					g.List(Id("b"), Id("ok")).Op(":=").Id("b").Assert(Op("*").Id(nodeName))
+					g.Add(returnFalse(Op("!").Id("ok")))
+
+					if nodeName != "Package" {
+						g.Line()
+						g.Add(returnFalse(Op("!").Id("opts").Dot("IgnoreSpacing").Op("&&").Id("a").Dot("Decs").Dot("Before").Op("!=").Id("b").Dot("Decs").Dot("Before")))
+					}
+
+					for _, frag := range data.Info[nodeName] {
+						switch frag := frag.(type) {
+						case data.Init:
+							// ignore
+						case data.Decoration:
+							g.Line().Commentf("Decoration: %s", frag.Name)
+							g.Add(returnFalse(Op("!").Id("opts").Dot("IgnoreDecorations").Op("&&").Op("!").Id("equalDecorations").Call(Id("a").Dot("Decs").Dot(frag.Name), Id("b").Dot("Decs").Dot(frag.Name))))
+						case data.Token:
+							if frag.NoPosField != nil {
+								g.Line().Commentf("Token: %s", frag.Name)
+								g.Add(returnFalse(frag.NoPosField.Get("a").Op("!=").Add(frag.NoPosField.Get("b"))))
+							}
+							if frag.TokenField != nil {
+								g.Line().Commentf("Token: %s", frag.Name)
+								g.Add(returnFalse(frag.TokenField.Get("a").Op("!=").Add(frag.TokenField.Get("b"))))
+							}
+							if frag.ExistsField != nil {
+								g.Line().Commentf("Token: %s", frag.Name)
+								g.Add(returnFalse(frag.ExistsField.Get("a").Op("!=").Add(frag.ExistsField.Get("b"))))
+							}
+						case data.String:
+							g.Line().Commentf("String: %s", frag.Name)
+							g.Add(returnFalse(frag.ValueField.Get("a").Op("!=").Add(frag.ValueField.Get("b"))))
+						case data.Node:
+							g.Line().Commentf("Node: %s", frag.Name)
+							g.Add(returnFalse(Op("!").Id("equal").Call(frag.Field.Get("a"), frag.Field.Get("b"), Id("opts"))))
+						case data.List:
+							g.Line().Commentf("List: %s", frag.Name)
+							g.Add(returnFalse(Len(frag.Field.Get("a")).Op("!=").Len(frag.Field.Get("b"))))
+							g.For(Id("i").Op(":=").Range().Add(frag.Field.Get("a"))).Block(
+								returnFalse(Op("!").Id("equal").Call(frag.Field.Get("a").Index(Id("i")), frag.Field.Get("b").Index(Id("i")), Id("opts"))),
+							)
+						case data.Map:
+							g.Line().Commentf("Map: %s", frag.Name)
+							g.Add(returnFalse(Len(frag.Field.Get("a")).Op("!=").Len(frag.Field.Get("b"))))
+							g.For(List(Id("k"), Id("v")).Op(":=").Range().Add(frag.Field.Get("a"))).BlockFunc(func(g *Group) {
+								g.List(Id("bv"), Id("ok")).Op(":=").Add(frag.Field.Get("b")).Index(Id("k"))
+								if frag.Elem.TypeName() == "Object" {
+									g.Add(returnFalse(Op("!").Id("ok").Op("||").Op("!").Id("opts").Dot("IgnoreObjects").Op("&&").Op("!").Id("equalObject").Call(Id("v"), Id("bv"))))
+								} else {
+									g.Add(returnFalse(Op("!").Id("ok").Op("||").Op("!").Id("equal").Call(Id("v"), Id("bv"), Id("opts"))))
+								}
+							})
+						case data.Value:
+							g.Line().Commentf("Value: %s", frag.Name)
+							g.Add(returnFalse(frag.Field.Get("a").Op("!=").Add(frag.Field.Get("b"))))
+						case data.Scope:
+							g.Line().Commentf("Scope: %s", frag.Name)
+							g.Add(returnFalse(Op("!").Id("opts").Dot("IgnoreObjects").Op("&&").Op("!").Id("equalScope").Call(frag.Field.Get("a"), frag.Field.Get("b"))))
+						case data.Object:
+							g.Line().Commentf("Object: %s", frag.Name)
+							g.Add(returnFalse(Op("!").Id("opts").Dot("IgnoreObjects").Op("&&").Op("!").Id("equalObject").Call(frag.Field.Get("a"), frag.Field.Get("b"))))
+						case data.Bad:
+							g.Line().Comment("Bad")
+							g.Add(returnFalse(frag.LengthField.Get("a").Op("!=").Add(frag.LengthField.Get("b"))))
+						case data.PathDecoration:
+							g.Line().Commentf("Path: %s", frag.Name)
+							g.Add(returnFalse(Id("opts").Dot("path").Call(frag.Field.Get("a")).Op("!=").Id("opts").Dot("path").Call(frag.Field.Get("b"))))
+						case data.SpecialDecoration:
+							g.Line().Commentf("Special decoration: %s", frag.Name)
+							g.Add(returnFalse(Op("!").Id("opts").Dot("IgnoreDecorations").Op("&&").Op("!").Id("equalDecorations").Call(frag.Decs.Get("a").Dot(frag.Name), frag.Decs.Get("b").Dot(frag.Name))))
+						default:
+							panic(fmt.Sprintf("unknown fragment type %T", frag))
+						}
+					}
+
+					if nodeName != "Package" {
+						g.Line()
+						g.Add(returnFalse(Op("!").Id("opts").Dot("IgnoreSpacing").Op("&&").Id("a").Dot("Decs").Dot("After").Op("!=").Id("b").Dot("Decs").Dot("After")))
+					}
+
+					g.Line()
+					g.Return(True())
+				})
+			}
+			g.Default().Block(
+				Panic(Qual("fmt", "Sprintf").Call(Lit("%T"), Id("a"))),
+			)
+		})
+	})
+
+	f.Comment("hashNode writes the structure of n to h. See Hash.")
+	f.Func().Id("hashNode").Params(Id("h").Op("*").Id("hasher"), Id("n").Id("Node"), Id("opts").Op("*").Id("EqualOptions")).BlockFunc(func(g *Group) {
+		g.Id("n").Op("=").Id("nilNode").Call(Id("n"))
+		g.If(Id("n").Op("==").Nil()).Block(
+			Id("h").Dot("string").Call(Lit("nil")),
+			Return(),
+		)
+		g.Switch(Id("n").Op(":=").Id("n").Assert(Id("type"))).BlockFunc(func(g *Group) {
+			for _, nodeName := range names {
+				g.Case(Op("*").Qual(DSTPATH, nodeName)).BlockFunc(func(g *Group) {
+					g.Id("h").Dot("string").Call(Lit(nodeName))
+
+					if nodeName != "Package" {
+						g.Line()
+						g.If(Op("!").Id("opts").Dot("IgnoreSpacing")).Block(
+							Id("h").Dot("int").Call(Int().Call(Id("n").Dot("Decs").Dot("Before"))),
+						)
+					}
+
+					for _, frag := range data.Info[nodeName] {
+						switch frag := frag.(type) {
+						case data.Init:
+							// ignore
+						case data.Decoration:
+							g.Line().Commentf("Decoration: %s", frag.Name)
+							g.If(Op("!").Id("opts").Dot("IgnoreDecorations")).Block(
+								Id("h").Dot("decorations").Call(Id("n").Dot("Decs").Dot(frag.Name)),
+							)
+						case data.Token:
+							if frag.NoPosField != nil {
+								g.Line().Commentf("Token: %s", frag.Name)
+								g.Id("h").Dot("bool").Call(frag.NoPosField.Get("n"))
+							}
+							if frag.TokenField != nil {
+								g.Line().Commentf("Token: %s", frag.Name)
+								g.Id("h").Dot("int").Call(Int().Call(frag.TokenField.Get("n")))
+							}
+							if frag.ExistsField != nil {
+								g.Line().Commentf("Token: %s", frag.Name)
+								g.Id("h").Dot("bool").Call(frag.ExistsField.Get("n"))
+							}
+						case data.String:
+							g.Line().Commentf("String: %s", frag.Name)
+							g.Id("h").Dot("string").Call(frag.ValueField.Get("n"))
+						case data.Node:
+							g.Line().Commentf("Node: %s", frag.Name)
+							g.Id("hashNode").Call(Id("h"), frag.Field.Get("n"), Id("opts"))
+						case data.List:
+							g.Line().Commentf("List: %s", frag.Name)
+							g.Id("h").Dot("int").Call(Len(frag.Field.Get("n")))
+							g.For(List(Id("_"), Id("v")).Op(":=").Range().Add(frag.Field.Get("n"))).Block(
+								Id("hashNode").Call(Id("h"), Id("v"), Id("opts")),
+							)
+						case data.Map:
+							g.Line().Commentf("Map: %s", frag.Name)
+							g.Id("h").Dot("int").Call(Len(frag.Field.Get("n")))
+							g.BlockFunc(func(g *Group) {
+								g.Var().Id("keys").Index().String()
+								g.For(Id("k").Op(":=").Range().Add(frag.Field.Get("n"))).Block(
+									Id("keys").Op("=").Append(Id("keys"), Id("k")),
+								)
+								g.Qual("sort", "Strings").Call(Id("keys"))
+								g.For(List(Id("_"), Id("k")).Op(":=").Range().Id("keys")).BlockFunc(func(g *Group) {
+									g.Id("h").Dot("string").Call(Id("k"))
+									if frag.Elem.TypeName() == "Object" {
+										g.If(Op("!").Id("opts").Dot("IgnoreObjects")).Block(
+											Id("h").Dot("object").Call(frag.Field.Get("n").Index(Id("k"))),
+										)
+									} else {
+										g.Id("hashNode").Call(Id("h"), frag.Field.Get("n").Index(Id("k")), Id("opts"))
+									}
+								})
+							})
+						case data.Value:
+							g.Line().Commentf("Value: %s", frag.Name)
+							g.Id("h").Dot("value").Call(frag.Field.Get("n"))
+						case data.Scope:
+							g.Line().Commentf("Scope: %s", frag.Name)
+							g.If(Op("!").Id("opts").Dot("IgnoreObjects")).Block(
+								Id("h").Dot("scope").Call(frag.Field.Get("n")),
+							)
+						case data.Object:
+							g.Line().Commentf("Object: %s", frag.Name)
+							g.If(Op("!").Id("opts").Dot("IgnoreObjects")).Block(
+								Id("h").Dot("object").Call(frag.Field.Get("n")),
+							)
+						case data.Bad:
+							g.Line().Comment("Bad")
+							g.Id("h").Dot("int").Call(frag.LengthField.Get("n"))
+						case data.PathDecoration:
+							g.Line().Commentf("Path: %s", frag.Name)
+							g.Id("h").Dot("string").Call(Id("opts").Dot("path").Call(frag.Field.Get("n")))
+						case data.SpecialDecoration:
+							g.Line().Commentf("Special decoration: %s", frag.Name)
+							g.If(Op("!").Id("opts").Dot("IgnoreDecorations")).Block(
+								Id("h").Dot("decorations").Call(frag.Decs.Get("n").Dot(frag.Name)),
+							)
+						default:
+							panic(fmt.Sprintf("unknown fragment type %T", frag))
+						}
+					}
+
+					if nodeName != "Package" {
+						g.Line()
+						g.If(Op("!").Id("opts").Dot("IgnoreSpacing")).Block(
+							Id("h").Dot("int").Call(Int().Call(Id("n").Dot("Decs").Dot("After"))),
+						)
+					}
+				})
+			}
+			g.Default().Block(
+				Panic(Qual("fmt", "Sprintf").Call(Lit("%T"), Id("n"))),
+			)
+		})
+	})
+
+	return f.Save("./equal-generated.go")
+}
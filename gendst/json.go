@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/dave/dst/gendst/data"
+	. "github.com/dave/jennifer/jen"
+)
+
+// notest
+
+func generateJson(names []string) error {
+
+	f := NewFilePathName(DSTPATH, "dst")
+
+	f.Comment("encodeNode encodes the fields of n. See Marshal.")
+	f.Func().Params(Id("e").Op("*").Id("jsonEncoder")).Id("encodeNode").Params(Id("n").Id("Node")).Op("*").Id("jsonObject").BlockFunc(func(g *Group) {
+		g.Switch(Id("n").Op(":=").Id("n").Assert(Id("type"))).BlockFunc(func(g *Group) {
+			for _, nodeName := range names {
+				g.Case(Op("*").Qual(DSTPATH, nodeName)).BlockFunc(func(g *Group) {
+					g.Id("out").Op(":=").Op("&").Id("jsonObject").Values()
+					g.Id("out").Dot("add").Call(Lit("Node"), Lit(nodeName))
+					if nodeName != "Package" {
+						g.Id("decs").Op(":=").Op("&").Id("jsonObject").Values()
+						g.Line()
+						g.Id("decs").Dot("add").Call(Lit("Before"), Id("n").Dot("Decs").Dot("Before"))
+					}
+
+					for _, frag := range data.Info[nodeName] {
+						switch frag := frag.(type) {
+						case data.Init:
+							// ignore
+						case data.Decoration:
+							g.Line().Commentf("Decoration: %s", frag.Name)
+							g.Id("decs").Dot("add").Call(Lit(frag.Name), Id("n").Dot("Decs").Dot(frag.Name))
+						case data.Token:
+							for _, field := range []data.FieldSpec{frag.NoPosField, frag.TokenField, frag.ExistsField} {
+								if field != nil {
+									g.Line().Commentf("Token: %s", frag.Name)
+									g.Id("out").Dot("add").Call(Lit(field.FieldName()), field.Get("n"))
+								}
+							}
+						case data.String:
+							g.Line().Commentf("String: %s", frag.Name)
+							g.Id("out").Dot("add").Call(Lit(frag.ValueField.FieldName()), frag.ValueField.Get("n"))
+						case data.Node:
+							g.Line().Commentf("Node: %s", frag.Name)
+							g.Id("out").Dot("add").Call(Lit(frag.Field.FieldName()), Id("e").Dot("node").Call(frag.Field.Get("n")))
+						case data.List:
+							g.Line().Commentf("List: %s", frag.Name)
+							g.Id("out").Dot("add").Call(
+								Lit(frag.Field.FieldName()),
+								Id("e").Dot("list").Call(
+									Len(frag.Field.Get("n")),
+									Func().Params(Id("i").Int()).Id("Node").Block(Return(frag.Field.Get("n").Index(Id("i")))),
+								),
+							)
+						case data.Map:
+							g.Line().Commentf("Map: %s", frag.Name)
+							if frag.Elem.TypeName() == "Object" {
+								g.Id("out").Dot("add").Call(Lit(frag.Field.FieldName()), Id("e").Dot("objectMap").Call(frag.Field.Get("n")))
+							} else {
+								g.Id("out").Dot("add").Call(Lit(frag.Field.FieldName()), Id("e").Dot("fileMap").Call(frag.Field.Get("n")))
+							}
+						case data.Value:
+							g.Line().Commentf("Value: %s", frag.Name)
+							g.Id("out").Dot("add").Call(Lit(frag.Field.FieldName()), frag.Field.Get("n"))
+						case data.Scope:
+							g.Line().Commentf("Scope: %s", frag.Name)
+							g.Id("out").Dot("add").Call(Lit(frag.Field.FieldName()), Id("e").Dot("scope").Call(frag.Field.Get("n")))
+						case data.Object:
+							g.Line().Commentf("Object: %s", frag.Name)
+							g.Id("out").Dot("add").Call(Lit(frag.Field.FieldName()), Id("e").Dot("object").Call(frag.Field.Get("n")))
+						case data.Bad:
+							g.Line().Comment("Bad")
+							g.Id("out").Dot("add").Call(Lit(frag.LengthField.FieldName()), frag.LengthField.Get("n"))
+						case data.PathDecoration:
+							g.Line().Commentf("Path: %s", frag.Name)
+							g.Id("out").Dot("add").Call(Lit(frag.Field.FieldName()), frag.Field.Get("n"))
+						case data.SpecialDecoration:
+							// ignore
+						default:
+							panic(fmt.Sprintf("unknown fragment type %T", frag))
+						}
+					}
+
+					if nodeName != "Package" {
+						g.Line()
+						g.Id("decs").Dot("add").Call(Lit("After"), Id("n").Dot("Decs").Dot("After"))
+						g.Id("out").Dot("add").Call(Lit("Decs"), Id("decs"))
+					}
+
+					g.Line()
+					g.Return(Id("out"))
+				})
+			}
+			g.Default().Block(
+				Panic(Qual("fmt", "Sprintf").Call(Lit("%T"), Id("n"))),
+			)
+		})
+	})
+
+	f.Comment("decodeNode decodes the fields of a node of type typ. See Unmarshal.")
+	f.Func().Params(Id("d").Op("*").Id("jsonDecoder")).Id("decodeNode").Params(Id("typ").String(), Id("in").Map(String()).Qual("encoding/json", "RawMessage")).Id("Node").BlockFunc(func(g *Group) {
+		g.Switch(Id("typ")).BlockFunc(func(g *Group) {
+			for _, nodeName := range names {
+				g.Case(Lit(nodeName)).BlockFunc(func(g *Group) {
+					g.Id("out").Op(":=").Op("&").Id(nodeName).Values()
+					g.Id("d").Dot("register").Call(Id("in"), Id("out"))
+					if nodeName != "Package" {
+						g.Id("decs").Op(":=").Id("d").Dot("fields").Call(Id("in").Index(Lit("Decs")))
+						g.Line()
+						g.Id("d").Dot("value").Call(Id("decs").Index(Lit("Before")), Op("&").Id("out").Dot("Decs").Dot("Before"))
+					}
+
+					for _, frag := range data.Info[nodeName] {
+						switch frag := frag.(type) {
+						case data.Init:
+							g.Line().Commentf("Init: %s", frag.Name)
+							g.Add(frag.Field.Get("out")).Op("=").Op("&").Id(frag.Type.TypeName()).Values()
+						case data.Decoration:
+							g.Line().Commentf("Decoration: %s", frag.Name)
+							g.Id("d").Dot("value").Call(Id("decs").Index(Lit(frag.Name)), Op("&").Id("out").Dot("Decs").Dot(frag.Name))
+						case data.Token:
+							for _, field := range []data.FieldSpec{frag.NoPosField, frag.TokenField, frag.ExistsField} {
+								if field != nil {
+									g.Line().Commentf("Token: %s", frag.Name)
+									g.Id("d").Dot("value").Call(Id("in").Index(Lit(field.FieldName())), Op("&").Add(field.Get("out")))
+								}
+							}
+						case data.String:
+							g.Line().Commentf("String: %s", frag.Name)
+							g.Id("d").Dot("value").Call(Id("in").Index(Lit(frag.ValueField.FieldName())), Op("&").Add(frag.ValueField.Get("out")))
+						case data.Node:
+							g.Line().Commentf("Node: %s", frag.Name)
+							g.Id("d").Dot("node").Call(Id("in").Index(Lit(frag.Field.FieldName())), Op("&").Add(frag.Field.Get("out")))
+						case data.List:
+							g.Line().Commentf("List: %s", frag.Name)
+							g.Id("d").Dot("list").Call(Id("in").Index(Lit(frag.Field.FieldName())), Op("&").Add(frag.Field.Get("out")))
+						case data.Map:
+							g.Line().Commentf("Map: %s", frag.Name)
+							if frag.Elem.TypeName() == "Object" {
+								g.Id("d").Dot("objectMap").Call(Id("in").Index(Lit(frag.Field.FieldName())), Op("&").Add(frag.Field.Get("out")))
+							} else {
+								g.Id("d").Dot("fileMap").Call(Id("in").Index(Lit(frag.Field.FieldName())), Op("&").Add(frag.Field.Get("out")))
+							}
+						case data.Value:
+							g.Line().Commentf("Value: %s", frag.Name)
+							g.Id("d").Dot("value").Call(Id("in").Index(Lit(frag.Field.FieldName())), Op("&").Add(frag.Field.Get("out")))
+						case data.Scope:
+							g.Line().Commentf("Scope: %s", frag.Name)
+							g.Id("d").Dot("scope").Call(Id("in").Index(Lit(frag.Field.FieldName())), Op("&").Add(frag.Field.Get("out")))
+						case data.Object:
+							g.Line().Commentf("Object: %s", frag.Name)
+							g.Id("d").Dot("object").Call(Id("in").Index(Lit(frag.Field.FieldName())), Op("&").Add(frag.Field.Get("out")))
+						case data.Bad:
+							g.Line().Comment("Bad")
+							g.Id("d").Dot("value").Call(Id("in").Index(Lit(frag.LengthField.FieldName())), Op("&").Add(frag.LengthField.Get("out")))
+						case data.PathDecoration:
+							g.Line().Commentf("Path: %s", frag.Name)
+							g.Id("d").Dot("value").Call(Id("in").Index(Lit(frag.Field.FieldName())), Op("&").Add(frag.Field.Get("out")))
+						case data.SpecialDecoration:
+							// ignore
+						default:
+							panic(fmt.Sprintf("unknown fragment type %T", frag))
+						}
+					}
+
+					if nodeName != "Package" {
+						g.Line()
+						g.Id("d").Dot("value").Call(Id("decs").Index(Lit("After")), Op("&").Id("out").Dot("Decs").Dot("After"))
+					}
+
+					g.Line()
+					g.Return(Id("out"))
+				})
+			}
+			g.Default().Block(
+				Id("d").Dot("errorf").Call(Lit("unknown node type %q"), Id("typ")),
+				Return(Nil()),
+			)
+		})
+	})
+
+	return f.Save("./json-generated.go")
+}
@@ -42,5 +42,11 @@ func run() error {
 	if err := generateClone(names); err != nil {
 		return err
 	}
+	if err := generateEqual(names); err != nil {
+		return err
+	}
+	if err := generateJson(names); err != nil {
+		return err
+	}
 	return nil
 }
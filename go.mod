@@ -1,16 +1,34 @@
 module github.com/dave/dst
 
 require (
-	github.com/dave/gopackages v0.0.0-20170318123100-46e7023ec56e // indirect
 	github.com/dave/jennifer v1.2.0
+	github.com/sergi/go-diff v1.0.0
+	golang.org/x/mod v0.35.0
+	golang.org/x/tools v0.44.0
+	gopkg.in/src-d/go-billy.v4 v4.3.0
+)
+
+require (
+	github.com/dave/gopackages v0.0.0-20170318123100-46e7023ec56e // indirect
 	github.com/dave/kerr v0.0.0-20170318121727-bc25dd6abe8e // indirect
 	github.com/dave/rebecca v0.9.1 // indirect
+	github.com/google/go-cmp v0.6.0 // indirect
 	github.com/google/pprof v0.0.0-20181127221834-b4f47329b966 // indirect
 	github.com/ianlancetaylor/demangle v0.0.0-20181102032728-5e5cf60278f6 // indirect
-	github.com/sergi/go-diff v1.0.0
+	github.com/kr/pretty v0.1.0 // indirect
+	github.com/kr/pty v1.1.1 // indirect
+	github.com/kr/text v0.1.0 // indirect
+	github.com/yuin/goldmark v1.4.13 // indirect
 	golang.org/x/arch v0.0.0-20180920145803-b19384d3c130 // indirect
-	golang.org/x/tools v0.0.0-20200509030707-2212a7e161a5
-	gopkg.in/src-d/go-billy.v4 v4.3.0
+	golang.org/x/crypto v0.50.0 // indirect
+	golang.org/x/net v0.53.0 // indirect
+	golang.org/x/sync v0.20.0 // indirect
+	golang.org/x/sys v0.43.0 // indirect
+	golang.org/x/telemetry v0.0.0-20260409153401-be6f6cb8b1fa // indirect
+	golang.org/x/term v0.42.0 // indirect
+	golang.org/x/text v0.36.0 // indirect
+	golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543 // indirect
+	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
 )
 
-go 1.14
+go 1.25.0
@@ -0,0 +1,3163 @@
+package dst
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// encodeNode encodes the fields of n. See Marshal.
+func (e *jsonEncoder) encodeNode(n Node) *jsonObject {
+	switch n := n.(type) {
+	case *ArrayType:
+		out := &jsonObject{}
+		out.add("Node", "ArrayType")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Lbrack
+		decs.add("Lbrack", n.Decs.Lbrack)
+
+		// Node: Len
+		out.add("Len", e.node(n.Len))
+
+		// Decoration: Len
+		decs.add("Len", n.Decs.Len)
+
+		// Node: Elt
+		out.add("Elt", e.node(n.Elt))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *AssignStmt:
+		out := &jsonObject{}
+		out.add("Node", "AssignStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// List: Lhs
+		out.add("Lhs", e.list(len(n.Lhs), func(i int) Node {
+			return n.Lhs[i]
+		}))
+
+		// Token: Tok
+		out.add("Tok", n.Tok)
+
+		// Decoration: Tok
+		decs.add("Tok", n.Decs.Tok)
+
+		// List: Rhs
+		out.add("Rhs", e.list(len(n.Rhs), func(i int) Node {
+			return n.Rhs[i]
+		}))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *BadDecl:
+		out := &jsonObject{}
+		out.add("Node", "BadDecl")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Bad
+		out.add("Length", n.Length)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *BadExpr:
+		out := &jsonObject{}
+		out.add("Node", "BadExpr")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Bad
+		out.add("Length", n.Length)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *BadStmt:
+		out := &jsonObject{}
+		out.add("Node", "BadStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Bad
+		out.add("Length", n.Length)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *BasicLit:
+		out := &jsonObject{}
+		out.add("Node", "BasicLit")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// String: Value
+		out.add("Value", n.Value)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		// Value: Kind
+		out.add("Kind", n.Kind)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *BinaryExpr:
+		out := &jsonObject{}
+		out.add("Node", "BinaryExpr")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: X
+		out.add("X", e.node(n.X))
+
+		// Decoration: X
+		decs.add("X", n.Decs.X)
+
+		// Token: Op
+		out.add("Op", n.Op)
+
+		// Decoration: Op
+		decs.add("Op", n.Decs.Op)
+
+		// Node: Y
+		out.add("Y", e.node(n.Y))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *BlockStmt:
+		out := &jsonObject{}
+		out.add("Node", "BlockStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Lbrace
+		decs.add("Lbrace", n.Decs.Lbrace)
+
+		// List: List
+		out.add("List", e.list(len(n.List), func(i int) Node {
+			return n.List[i]
+		}))
+
+		// Token: Rbrace
+		out.add("RbraceHasNoPos", n.RbraceHasNoPos)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *BranchStmt:
+		out := &jsonObject{}
+		out.add("Node", "BranchStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Token: Tok
+		out.add("Tok", n.Tok)
+
+		// Decoration: Tok
+		decs.add("Tok", n.Decs.Tok)
+
+		// Node: Label
+		out.add("Label", e.node(n.Label))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *CallExpr:
+		out := &jsonObject{}
+		out.add("Node", "CallExpr")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: Fun
+		out.add("Fun", e.node(n.Fun))
+
+		// Decoration: Fun
+		decs.add("Fun", n.Decs.Fun)
+
+		// Decoration: Lparen
+		decs.add("Lparen", n.Decs.Lparen)
+
+		// List: Args
+		out.add("Args", e.list(len(n.Args), func(i int) Node {
+			return n.Args[i]
+		}))
+
+		// Token: Ellipsis
+		out.add("Ellipsis", n.Ellipsis)
+
+		// Decoration: Ellipsis
+		decs.add("Ellipsis", n.Decs.Ellipsis)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *CaseClause:
+		out := &jsonObject{}
+		out.add("Node", "CaseClause")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Case
+		decs.add("Case", n.Decs.Case)
+
+		// List: List
+		out.add("List", e.list(len(n.List), func(i int) Node {
+			return n.List[i]
+		}))
+
+		// Decoration: Colon
+		decs.add("Colon", n.Decs.Colon)
+
+		// List: Body
+		out.add("Body", e.list(len(n.Body), func(i int) Node {
+			return n.Body[i]
+		}))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *ChanType:
+		out := &jsonObject{}
+		out.add("Node", "ChanType")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Begin
+		decs.add("Begin", n.Decs.Begin)
+
+		// Decoration: Arrow
+		decs.add("Arrow", n.Decs.Arrow)
+
+		// Node: Value
+		out.add("Value", e.node(n.Value))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		// Value: Dir
+		out.add("Dir", n.Dir)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *CommClause:
+		out := &jsonObject{}
+		out.add("Node", "CommClause")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Case
+		decs.add("Case", n.Decs.Case)
+
+		// Node: Comm
+		out.add("Comm", e.node(n.Comm))
+
+		// Decoration: Comm
+		decs.add("Comm", n.Decs.Comm)
+
+		// Decoration: Colon
+		decs.add("Colon", n.Decs.Colon)
+
+		// List: Body
+		out.add("Body", e.list(len(n.Body), func(i int) Node {
+			return n.Body[i]
+		}))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *CompositeLit:
+		out := &jsonObject{}
+		out.add("Node", "CompositeLit")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: Type
+		out.add("Type", e.node(n.Type))
+
+		// Decoration: Type
+		decs.add("Type", n.Decs.Type)
+
+		// Decoration: Lbrace
+		decs.add("Lbrace", n.Decs.Lbrace)
+
+		// List: Elts
+		out.add("Elts", e.list(len(n.Elts), func(i int) Node {
+			return n.Elts[i]
+		}))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		// Value: Incomplete
+		out.add("Incomplete", n.Incomplete)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *DeclStmt:
+		out := &jsonObject{}
+		out.add("Node", "DeclStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: Decl
+		out.add("Decl", e.node(n.Decl))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *DeferStmt:
+		out := &jsonObject{}
+		out.add("Node", "DeferStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Defer
+		decs.add("Defer", n.Decs.Defer)
+
+		// Node: Call
+		out.add("Call", e.node(n.Call))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *Ellipsis:
+		out := &jsonObject{}
+		out.add("Node", "Ellipsis")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Ellipsis
+		decs.add("Ellipsis", n.Decs.Ellipsis)
+
+		// Node: Elt
+		out.add("Elt", e.node(n.Elt))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *EmptyStmt:
+		out := &jsonObject{}
+		out.add("Node", "EmptyStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		// Value: Implicit
+		out.add("Implicit", n.Implicit)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *ExprStmt:
+		out := &jsonObject{}
+		out.add("Node", "ExprStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: X
+		out.add("X", e.node(n.X))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *Field:
+		out := &jsonObject{}
+		out.add("Node", "Field")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// List: Names
+		out.add("Names", e.list(len(n.Names), func(i int) Node {
+			return n.Names[i]
+		}))
+
+		// Node: Type
+		out.add("Type", e.node(n.Type))
+
+		// Decoration: Type
+		decs.add("Type", n.Decs.Type)
+
+		// Node: Tag
+		out.add("Tag", e.node(n.Tag))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *FieldList:
+		out := &jsonObject{}
+		out.add("Node", "FieldList")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Token: Opening
+		out.add("Opening", n.Opening)
+
+		// Decoration: Opening
+		decs.add("Opening", n.Decs.Opening)
+
+		// List: List
+		out.add("List", e.list(len(n.List), func(i int) Node {
+			return n.List[i]
+		}))
+
+		// Token: Closing
+		out.add("Closing", n.Closing)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *File:
+		out := &jsonObject{}
+		out.add("Node", "File")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Package
+		decs.add("Package", n.Decs.Package)
+
+		// Node: Name
+		out.add("Name", e.node(n.Name))
+
+		// Decoration: Name
+		decs.add("Name", n.Decs.Name)
+
+		// List: Decls
+		out.add("Decls", e.list(len(n.Decls), func(i int) Node {
+			return n.Decls[i]
+		}))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		// Scope: Scope
+		out.add("Scope", e.scope(n.Scope))
+
+		// List: Imports
+		out.add("Imports", e.list(len(n.Imports), func(i int) Node {
+			return n.Imports[i]
+		}))
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *ForStmt:
+		out := &jsonObject{}
+		out.add("Node", "ForStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: For
+		decs.add("For", n.Decs.For)
+
+		// Node: Init
+		out.add("Init", e.node(n.Init))
+
+		// Decoration: Init
+		decs.add("Init", n.Decs.Init)
+
+		// Node: Cond
+		out.add("Cond", e.node(n.Cond))
+
+		// Decoration: Cond
+		decs.add("Cond", n.Decs.Cond)
+
+		// Node: Post
+		out.add("Post", e.node(n.Post))
+
+		// Decoration: Post
+		decs.add("Post", n.Decs.Post)
+
+		// Node: Body
+		out.add("Body", e.node(n.Body))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *FuncDecl:
+		out := &jsonObject{}
+		out.add("Node", "FuncDecl")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Token: Func
+		out.add("Func", n.Type.Func)
+
+		// Decoration: Func
+		decs.add("Func", n.Decs.Func)
+
+		// Node: Recv
+		out.add("Recv", e.node(n.Recv))
+
+		// Decoration: Recv
+		decs.add("Recv", n.Decs.Recv)
+
+		// Node: Name
+		out.add("Name", e.node(n.Name))
+
+		// Decoration: Name
+		decs.add("Name", n.Decs.Name)
+
+		// Node: TypeParams
+		out.add("TypeParams", e.node(n.Type.TypeParams))
+
+		// Decoration: TypeParams
+		decs.add("TypeParams", n.Decs.TypeParams)
+
+		// Node: Params
+		out.add("Params", e.node(n.Type.Params))
+
+		// Decoration: Params
+		decs.add("Params", n.Decs.Params)
+
+		// Node: Results
+		out.add("Results", e.node(n.Type.Results))
+
+		// Decoration: Results
+		decs.add("Results", n.Decs.Results)
+
+		// Node: Body
+		out.add("Body", e.node(n.Body))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *FuncLit:
+		out := &jsonObject{}
+		out.add("Node", "FuncLit")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: Type
+		out.add("Type", e.node(n.Type))
+
+		// Decoration: Type
+		decs.add("Type", n.Decs.Type)
+
+		// Node: Body
+		out.add("Body", e.node(n.Body))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *FuncType:
+		out := &jsonObject{}
+		out.add("Node", "FuncType")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Token: Func
+		out.add("Func", n.Func)
+
+		// Decoration: Func
+		decs.add("Func", n.Decs.Func)
+
+		// Node: TypeParams
+		out.add("TypeParams", e.node(n.TypeParams))
+
+		// Decoration: TypeParams
+		decs.add("TypeParams", n.Decs.TypeParams)
+
+		// Node: Params
+		out.add("Params", e.node(n.Params))
+
+		// Decoration: Params
+		decs.add("Params", n.Decs.Params)
+
+		// Node: Results
+		out.add("Results", e.node(n.Results))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *GenDecl:
+		out := &jsonObject{}
+		out.add("Node", "GenDecl")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Token: Tok
+		out.add("Tok", n.Tok)
+
+		// Decoration: Tok
+		decs.add("Tok", n.Decs.Tok)
+
+		// Token: Lparen
+		out.add("Lparen", n.Lparen)
+
+		// Decoration: Lparen
+		decs.add("Lparen", n.Decs.Lparen)
+
+		// List: Specs
+		out.add("Specs", e.list(len(n.Specs), func(i int) Node {
+			return n.Specs[i]
+		}))
+
+		// Token: Rparen
+		out.add("Rparen", n.Rparen)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *GoStmt:
+		out := &jsonObject{}
+		out.add("Node", "GoStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Go
+		decs.add("Go", n.Decs.Go)
+
+		// Node: Call
+		out.add("Call", e.node(n.Call))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *Ident:
+		out := &jsonObject{}
+		out.add("Node", "Ident")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: X
+		decs.add("X", n.Decs.X)
+
+		// String: Name
+		out.add("Name", n.Name)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		// Object: Obj
+		out.add("Obj", e.object(n.Obj))
+
+		// Path: Path
+		out.add("Path", n.Path)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *IfStmt:
+		out := &jsonObject{}
+		out.add("Node", "IfStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: If
+		decs.add("If", n.Decs.If)
+
+		// Node: Init
+		out.add("Init", e.node(n.Init))
+
+		// Decoration: Init
+		decs.add("Init", n.Decs.Init)
+
+		// Node: Cond
+		out.add("Cond", e.node(n.Cond))
+
+		// Decoration: Cond
+		decs.add("Cond", n.Decs.Cond)
+
+		// Node: Body
+		out.add("Body", e.node(n.Body))
+
+		// Decoration: Else
+		decs.add("Else", n.Decs.Else)
+
+		// Node: Else
+		out.add("Else", e.node(n.Else))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *ImportSpec:
+		out := &jsonObject{}
+		out.add("Node", "ImportSpec")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: Name
+		out.add("Name", e.node(n.Name))
+
+		// Decoration: Name
+		decs.add("Name", n.Decs.Name)
+
+		// Node: Path
+		out.add("Path", e.node(n.Path))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *IncDecStmt:
+		out := &jsonObject{}
+		out.add("Node", "IncDecStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: X
+		out.add("X", e.node(n.X))
+
+		// Decoration: X
+		decs.add("X", n.Decs.X)
+
+		// Token: Tok
+		out.add("Tok", n.Tok)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *IndexExpr:
+		out := &jsonObject{}
+		out.add("Node", "IndexExpr")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: X
+		out.add("X", e.node(n.X))
+
+		// Decoration: X
+		decs.add("X", n.Decs.X)
+
+		// Decoration: Lbrack
+		decs.add("Lbrack", n.Decs.Lbrack)
+
+		// Node: Index
+		out.add("Index", e.node(n.Index))
+
+		// Decoration: Index
+		decs.add("Index", n.Decs.Index)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *IndexListExpr:
+		out := &jsonObject{}
+		out.add("Node", "IndexListExpr")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: X
+		out.add("X", e.node(n.X))
+
+		// Decoration: X
+		decs.add("X", n.Decs.X)
+
+		// Decoration: Lbrack
+		decs.add("Lbrack", n.Decs.Lbrack)
+
+		// List: Indices
+		out.add("Indices", e.list(len(n.Indices), func(i int) Node {
+			return n.Indices[i]
+		}))
+
+		// Decoration: Indices
+		decs.add("Indices", n.Decs.Indices)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *InterfaceType:
+		out := &jsonObject{}
+		out.add("Node", "InterfaceType")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Interface
+		decs.add("Interface", n.Decs.Interface)
+
+		// Node: Methods
+		out.add("Methods", e.node(n.Methods))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		// Value: Incomplete
+		out.add("Incomplete", n.Incomplete)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *KeyValueExpr:
+		out := &jsonObject{}
+		out.add("Node", "KeyValueExpr")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: Key
+		out.add("Key", e.node(n.Key))
+
+		// Decoration: Key
+		decs.add("Key", n.Decs.Key)
+
+		// Decoration: Colon
+		decs.add("Colon", n.Decs.Colon)
+
+		// Node: Value
+		out.add("Value", e.node(n.Value))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *LabeledStmt:
+		out := &jsonObject{}
+		out.add("Node", "LabeledStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: Label
+		out.add("Label", e.node(n.Label))
+
+		// Decoration: Label
+		decs.add("Label", n.Decs.Label)
+
+		// Decoration: Colon
+		decs.add("Colon", n.Decs.Colon)
+
+		// Node: Stmt
+		out.add("Stmt", e.node(n.Stmt))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *MapType:
+		out := &jsonObject{}
+		out.add("Node", "MapType")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Map
+		decs.add("Map", n.Decs.Map)
+
+		// Node: Key
+		out.add("Key", e.node(n.Key))
+
+		// Decoration: Key
+		decs.add("Key", n.Decs.Key)
+
+		// Node: Value
+		out.add("Value", e.node(n.Value))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *Package:
+		out := &jsonObject{}
+		out.add("Node", "Package")
+
+		// Value: Name
+		out.add("Name", n.Name)
+
+		// Scope: Scope
+		out.add("Scope", e.scope(n.Scope))
+
+		// Map: Imports
+		out.add("Imports", e.objectMap(n.Imports))
+
+		// Map: Files
+		out.add("Files", e.fileMap(n.Files))
+
+		return out
+	case *ParenExpr:
+		out := &jsonObject{}
+		out.add("Node", "ParenExpr")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Lparen
+		decs.add("Lparen", n.Decs.Lparen)
+
+		// Node: X
+		out.add("X", e.node(n.X))
+
+		// Decoration: X
+		decs.add("X", n.Decs.X)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *RangeStmt:
+		out := &jsonObject{}
+		out.add("Node", "RangeStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: For
+		decs.add("For", n.Decs.For)
+
+		// Node: Key
+		out.add("Key", e.node(n.Key))
+
+		// Decoration: Key
+		decs.add("Key", n.Decs.Key)
+
+		// Node: Value
+		out.add("Value", e.node(n.Value))
+
+		// Decoration: Value
+		decs.add("Value", n.Decs.Value)
+
+		// Token: Tok
+		out.add("Tok", n.Tok)
+
+		// Decoration: Range
+		decs.add("Range", n.Decs.Range)
+
+		// Node: X
+		out.add("X", e.node(n.X))
+
+		// Decoration: X
+		decs.add("X", n.Decs.X)
+
+		// Node: Body
+		out.add("Body", e.node(n.Body))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *ReturnStmt:
+		out := &jsonObject{}
+		out.add("Node", "ReturnStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Return
+		decs.add("Return", n.Decs.Return)
+
+		// List: Results
+		out.add("Results", e.list(len(n.Results), func(i int) Node {
+			return n.Results[i]
+		}))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *SelectStmt:
+		out := &jsonObject{}
+		out.add("Node", "SelectStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Select
+		decs.add("Select", n.Decs.Select)
+
+		// Node: Body
+		out.add("Body", e.node(n.Body))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *SelectorExpr:
+		out := &jsonObject{}
+		out.add("Node", "SelectorExpr")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: X
+		out.add("X", e.node(n.X))
+
+		// Decoration: X
+		decs.add("X", n.Decs.X)
+
+		// Node: Sel
+		out.add("Sel", e.node(n.Sel))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *SendStmt:
+		out := &jsonObject{}
+		out.add("Node", "SendStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: Chan
+		out.add("Chan", e.node(n.Chan))
+
+		// Decoration: Chan
+		decs.add("Chan", n.Decs.Chan)
+
+		// Decoration: Arrow
+		decs.add("Arrow", n.Decs.Arrow)
+
+		// Node: Value
+		out.add("Value", e.node(n.Value))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *SliceExpr:
+		out := &jsonObject{}
+		out.add("Node", "SliceExpr")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: X
+		out.add("X", e.node(n.X))
+
+		// Decoration: X
+		decs.add("X", n.Decs.X)
+
+		// Decoration: Lbrack
+		decs.add("Lbrack", n.Decs.Lbrack)
+
+		// Node: Low
+		out.add("Low", e.node(n.Low))
+
+		// Decoration: Low
+		decs.add("Low", n.Decs.Low)
+
+		// Node: High
+		out.add("High", e.node(n.High))
+
+		// Decoration: High
+		decs.add("High", n.Decs.High)
+
+		// Node: Max
+		out.add("Max", e.node(n.Max))
+
+		// Decoration: Max
+		decs.add("Max", n.Decs.Max)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		// Value: Slice3
+		out.add("Slice3", n.Slice3)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *StarExpr:
+		out := &jsonObject{}
+		out.add("Node", "StarExpr")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Star
+		decs.add("Star", n.Decs.Star)
+
+		// Node: X
+		out.add("X", e.node(n.X))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *StructType:
+		out := &jsonObject{}
+		out.add("Node", "StructType")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Struct
+		decs.add("Struct", n.Decs.Struct)
+
+		// Node: Fields
+		out.add("Fields", e.node(n.Fields))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		// Value: Incomplete
+		out.add("Incomplete", n.Incomplete)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *SwitchStmt:
+		out := &jsonObject{}
+		out.add("Node", "SwitchStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Switch
+		decs.add("Switch", n.Decs.Switch)
+
+		// Node: Init
+		out.add("Init", e.node(n.Init))
+
+		// Decoration: Init
+		decs.add("Init", n.Decs.Init)
+
+		// Node: Tag
+		out.add("Tag", e.node(n.Tag))
+
+		// Decoration: Tag
+		decs.add("Tag", n.Decs.Tag)
+
+		// Node: Body
+		out.add("Body", e.node(n.Body))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *TypeAssertExpr:
+		out := &jsonObject{}
+		out.add("Node", "TypeAssertExpr")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: X
+		out.add("X", e.node(n.X))
+
+		// Decoration: X
+		decs.add("X", n.Decs.X)
+
+		// Decoration: Lparen
+		decs.add("Lparen", n.Decs.Lparen)
+
+		// Node: Type
+		out.add("Type", e.node(n.Type))
+
+		// Decoration: Type
+		decs.add("Type", n.Decs.Type)
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *TypeSpec:
+		out := &jsonObject{}
+		out.add("Node", "TypeSpec")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Node: Name
+		out.add("Name", e.node(n.Name))
+
+		// Node: TypeParams
+		out.add("TypeParams", e.node(n.TypeParams))
+
+		// Token: Assign
+		out.add("Assign", n.Assign)
+
+		// Decoration: TypeParams
+		decs.add("TypeParams", n.Decs.TypeParams)
+
+		// Decoration: Name
+		decs.add("Name", n.Decs.Name)
+
+		// Node: Type
+		out.add("Type", e.node(n.Type))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *TypeSwitchStmt:
+		out := &jsonObject{}
+		out.add("Node", "TypeSwitchStmt")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Decoration: Switch
+		decs.add("Switch", n.Decs.Switch)
+
+		// Node: Init
+		out.add("Init", e.node(n.Init))
+
+		// Decoration: Init
+		decs.add("Init", n.Decs.Init)
+
+		// Node: Assign
+		out.add("Assign", e.node(n.Assign))
+
+		// Decoration: Assign
+		decs.add("Assign", n.Decs.Assign)
+
+		// Node: Body
+		out.add("Body", e.node(n.Body))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *UnaryExpr:
+		out := &jsonObject{}
+		out.add("Node", "UnaryExpr")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// Token: Op
+		out.add("Op", n.Op)
+
+		// Decoration: Op
+		decs.add("Op", n.Decs.Op)
+
+		// Node: X
+		out.add("X", e.node(n.X))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	case *ValueSpec:
+		out := &jsonObject{}
+		out.add("Node", "ValueSpec")
+		decs := &jsonObject{}
+
+		decs.add("Before", n.Decs.Before)
+
+		// Decoration: Start
+		decs.add("Start", n.Decs.Start)
+
+		// List: Names
+		out.add("Names", e.list(len(n.Names), func(i int) Node {
+			return n.Names[i]
+		}))
+
+		// Node: Type
+		out.add("Type", e.node(n.Type))
+
+		// Decoration: Assign
+		decs.add("Assign", n.Decs.Assign)
+
+		// List: Values
+		out.add("Values", e.list(len(n.Values), func(i int) Node {
+			return n.Values[i]
+		}))
+
+		// Decoration: End
+		decs.add("End", n.Decs.End)
+
+		decs.add("After", n.Decs.After)
+		out.add("Decs", decs)
+
+		return out
+	default:
+		panic(fmt.Sprintf("%T", n))
+	}
+}
+
+// decodeNode decodes the fields of a node of type typ. See Unmarshal.
+func (d *jsonDecoder) decodeNode(typ string, in map[string]json.RawMessage) Node {
+	switch typ {
+	case "ArrayType":
+		out := &ArrayType{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Lbrack
+		d.value(decs["Lbrack"], &out.Decs.Lbrack)
+
+		// Node: Len
+		d.node(in["Len"], &out.Len)
+
+		// Decoration: Len
+		d.value(decs["Len"], &out.Decs.Len)
+
+		// Node: Elt
+		d.node(in["Elt"], &out.Elt)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "AssignStmt":
+		out := &AssignStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// List: Lhs
+		d.list(in["Lhs"], &out.Lhs)
+
+		// Token: Tok
+		d.value(in["Tok"], &out.Tok)
+
+		// Decoration: Tok
+		d.value(decs["Tok"], &out.Decs.Tok)
+
+		// List: Rhs
+		d.list(in["Rhs"], &out.Rhs)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "BadDecl":
+		out := &BadDecl{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Bad
+		d.value(in["Length"], &out.Length)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "BadExpr":
+		out := &BadExpr{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Bad
+		d.value(in["Length"], &out.Length)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "BadStmt":
+		out := &BadStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Bad
+		d.value(in["Length"], &out.Length)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "BasicLit":
+		out := &BasicLit{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// String: Value
+		d.value(in["Value"], &out.Value)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		// Value: Kind
+		d.value(in["Kind"], &out.Kind)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "BinaryExpr":
+		out := &BinaryExpr{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: X
+		d.node(in["X"], &out.X)
+
+		// Decoration: X
+		d.value(decs["X"], &out.Decs.X)
+
+		// Token: Op
+		d.value(in["Op"], &out.Op)
+
+		// Decoration: Op
+		d.value(decs["Op"], &out.Decs.Op)
+
+		// Node: Y
+		d.node(in["Y"], &out.Y)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "BlockStmt":
+		out := &BlockStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Lbrace
+		d.value(decs["Lbrace"], &out.Decs.Lbrace)
+
+		// List: List
+		d.list(in["List"], &out.List)
+
+		// Token: Rbrace
+		d.value(in["RbraceHasNoPos"], &out.RbraceHasNoPos)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "BranchStmt":
+		out := &BranchStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Token: Tok
+		d.value(in["Tok"], &out.Tok)
+
+		// Decoration: Tok
+		d.value(decs["Tok"], &out.Decs.Tok)
+
+		// Node: Label
+		d.node(in["Label"], &out.Label)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "CallExpr":
+		out := &CallExpr{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: Fun
+		d.node(in["Fun"], &out.Fun)
+
+		// Decoration: Fun
+		d.value(decs["Fun"], &out.Decs.Fun)
+
+		// Decoration: Lparen
+		d.value(decs["Lparen"], &out.Decs.Lparen)
+
+		// List: Args
+		d.list(in["Args"], &out.Args)
+
+		// Token: Ellipsis
+		d.value(in["Ellipsis"], &out.Ellipsis)
+
+		// Decoration: Ellipsis
+		d.value(decs["Ellipsis"], &out.Decs.Ellipsis)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "CaseClause":
+		out := &CaseClause{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Case
+		d.value(decs["Case"], &out.Decs.Case)
+
+		// List: List
+		d.list(in["List"], &out.List)
+
+		// Decoration: Colon
+		d.value(decs["Colon"], &out.Decs.Colon)
+
+		// List: Body
+		d.list(in["Body"], &out.Body)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "ChanType":
+		out := &ChanType{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Begin
+		d.value(decs["Begin"], &out.Decs.Begin)
+
+		// Decoration: Arrow
+		d.value(decs["Arrow"], &out.Decs.Arrow)
+
+		// Node: Value
+		d.node(in["Value"], &out.Value)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		// Value: Dir
+		d.value(in["Dir"], &out.Dir)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "CommClause":
+		out := &CommClause{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Case
+		d.value(decs["Case"], &out.Decs.Case)
+
+		// Node: Comm
+		d.node(in["Comm"], &out.Comm)
+
+		// Decoration: Comm
+		d.value(decs["Comm"], &out.Decs.Comm)
+
+		// Decoration: Colon
+		d.value(decs["Colon"], &out.Decs.Colon)
+
+		// List: Body
+		d.list(in["Body"], &out.Body)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "CompositeLit":
+		out := &CompositeLit{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: Type
+		d.node(in["Type"], &out.Type)
+
+		// Decoration: Type
+		d.value(decs["Type"], &out.Decs.Type)
+
+		// Decoration: Lbrace
+		d.value(decs["Lbrace"], &out.Decs.Lbrace)
+
+		// List: Elts
+		d.list(in["Elts"], &out.Elts)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		// Value: Incomplete
+		d.value(in["Incomplete"], &out.Incomplete)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "DeclStmt":
+		out := &DeclStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: Decl
+		d.node(in["Decl"], &out.Decl)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "DeferStmt":
+		out := &DeferStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Defer
+		d.value(decs["Defer"], &out.Decs.Defer)
+
+		// Node: Call
+		d.node(in["Call"], &out.Call)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "Ellipsis":
+		out := &Ellipsis{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Ellipsis
+		d.value(decs["Ellipsis"], &out.Decs.Ellipsis)
+
+		// Node: Elt
+		d.node(in["Elt"], &out.Elt)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "EmptyStmt":
+		out := &EmptyStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		// Value: Implicit
+		d.value(in["Implicit"], &out.Implicit)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "ExprStmt":
+		out := &ExprStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: X
+		d.node(in["X"], &out.X)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "Field":
+		out := &Field{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// List: Names
+		d.list(in["Names"], &out.Names)
+
+		// Node: Type
+		d.node(in["Type"], &out.Type)
+
+		// Decoration: Type
+		d.value(decs["Type"], &out.Decs.Type)
+
+		// Node: Tag
+		d.node(in["Tag"], &out.Tag)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "FieldList":
+		out := &FieldList{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Token: Opening
+		d.value(in["Opening"], &out.Opening)
+
+		// Decoration: Opening
+		d.value(decs["Opening"], &out.Decs.Opening)
+
+		// List: List
+		d.list(in["List"], &out.List)
+
+		// Token: Closing
+		d.value(in["Closing"], &out.Closing)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "File":
+		out := &File{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Package
+		d.value(decs["Package"], &out.Decs.Package)
+
+		// Node: Name
+		d.node(in["Name"], &out.Name)
+
+		// Decoration: Name
+		d.value(decs["Name"], &out.Decs.Name)
+
+		// List: Decls
+		d.list(in["Decls"], &out.Decls)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		// Scope: Scope
+		d.scope(in["Scope"], &out.Scope)
+
+		// List: Imports
+		d.list(in["Imports"], &out.Imports)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "ForStmt":
+		out := &ForStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: For
+		d.value(decs["For"], &out.Decs.For)
+
+		// Node: Init
+		d.node(in["Init"], &out.Init)
+
+		// Decoration: Init
+		d.value(decs["Init"], &out.Decs.Init)
+
+		// Node: Cond
+		d.node(in["Cond"], &out.Cond)
+
+		// Decoration: Cond
+		d.value(decs["Cond"], &out.Decs.Cond)
+
+		// Node: Post
+		d.node(in["Post"], &out.Post)
+
+		// Decoration: Post
+		d.value(decs["Post"], &out.Decs.Post)
+
+		// Node: Body
+		d.node(in["Body"], &out.Body)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "FuncDecl":
+		out := &FuncDecl{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Init: Type
+		out.Type = &FuncType{}
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Token: Func
+		d.value(in["Func"], &out.Type.Func)
+
+		// Decoration: Func
+		d.value(decs["Func"], &out.Decs.Func)
+
+		// Node: Recv
+		d.node(in["Recv"], &out.Recv)
+
+		// Decoration: Recv
+		d.value(decs["Recv"], &out.Decs.Recv)
+
+		// Node: Name
+		d.node(in["Name"], &out.Name)
+
+		// Decoration: Name
+		d.value(decs["Name"], &out.Decs.Name)
+
+		// Node: TypeParams
+		d.node(in["TypeParams"], &out.Type.TypeParams)
+
+		// Decoration: TypeParams
+		d.value(decs["TypeParams"], &out.Decs.TypeParams)
+
+		// Node: Params
+		d.node(in["Params"], &out.Type.Params)
+
+		// Decoration: Params
+		d.value(decs["Params"], &out.Decs.Params)
+
+		// Node: Results
+		d.node(in["Results"], &out.Type.Results)
+
+		// Decoration: Results
+		d.value(decs["Results"], &out.Decs.Results)
+
+		// Node: Body
+		d.node(in["Body"], &out.Body)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "FuncLit":
+		out := &FuncLit{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: Type
+		d.node(in["Type"], &out.Type)
+
+		// Decoration: Type
+		d.value(decs["Type"], &out.Decs.Type)
+
+		// Node: Body
+		d.node(in["Body"], &out.Body)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "FuncType":
+		out := &FuncType{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Token: Func
+		d.value(in["Func"], &out.Func)
+
+		// Decoration: Func
+		d.value(decs["Func"], &out.Decs.Func)
+
+		// Node: TypeParams
+		d.node(in["TypeParams"], &out.TypeParams)
+
+		// Decoration: TypeParams
+		d.value(decs["TypeParams"], &out.Decs.TypeParams)
+
+		// Node: Params
+		d.node(in["Params"], &out.Params)
+
+		// Decoration: Params
+		d.value(decs["Params"], &out.Decs.Params)
+
+		// Node: Results
+		d.node(in["Results"], &out.Results)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "GenDecl":
+		out := &GenDecl{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Token: Tok
+		d.value(in["Tok"], &out.Tok)
+
+		// Decoration: Tok
+		d.value(decs["Tok"], &out.Decs.Tok)
+
+		// Token: Lparen
+		d.value(in["Lparen"], &out.Lparen)
+
+		// Decoration: Lparen
+		d.value(decs["Lparen"], &out.Decs.Lparen)
+
+		// List: Specs
+		d.list(in["Specs"], &out.Specs)
+
+		// Token: Rparen
+		d.value(in["Rparen"], &out.Rparen)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "GoStmt":
+		out := &GoStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Go
+		d.value(decs["Go"], &out.Decs.Go)
+
+		// Node: Call
+		d.node(in["Call"], &out.Call)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "Ident":
+		out := &Ident{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: X
+		d.value(decs["X"], &out.Decs.X)
+
+		// String: Name
+		d.value(in["Name"], &out.Name)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		// Object: Obj
+		d.object(in["Obj"], &out.Obj)
+
+		// Path: Path
+		d.value(in["Path"], &out.Path)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "IfStmt":
+		out := &IfStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: If
+		d.value(decs["If"], &out.Decs.If)
+
+		// Node: Init
+		d.node(in["Init"], &out.Init)
+
+		// Decoration: Init
+		d.value(decs["Init"], &out.Decs.Init)
+
+		// Node: Cond
+		d.node(in["Cond"], &out.Cond)
+
+		// Decoration: Cond
+		d.value(decs["Cond"], &out.Decs.Cond)
+
+		// Node: Body
+		d.node(in["Body"], &out.Body)
+
+		// Decoration: Else
+		d.value(decs["Else"], &out.Decs.Else)
+
+		// Node: Else
+		d.node(in["Else"], &out.Else)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "ImportSpec":
+		out := &ImportSpec{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: Name
+		d.node(in["Name"], &out.Name)
+
+		// Decoration: Name
+		d.value(decs["Name"], &out.Decs.Name)
+
+		// Node: Path
+		d.node(in["Path"], &out.Path)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "IncDecStmt":
+		out := &IncDecStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: X
+		d.node(in["X"], &out.X)
+
+		// Decoration: X
+		d.value(decs["X"], &out.Decs.X)
+
+		// Token: Tok
+		d.value(in["Tok"], &out.Tok)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "IndexExpr":
+		out := &IndexExpr{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: X
+		d.node(in["X"], &out.X)
+
+		// Decoration: X
+		d.value(decs["X"], &out.Decs.X)
+
+		// Decoration: Lbrack
+		d.value(decs["Lbrack"], &out.Decs.Lbrack)
+
+		// Node: Index
+		d.node(in["Index"], &out.Index)
+
+		// Decoration: Index
+		d.value(decs["Index"], &out.Decs.Index)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "IndexListExpr":
+		out := &IndexListExpr{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: X
+		d.node(in["X"], &out.X)
+
+		// Decoration: X
+		d.value(decs["X"], &out.Decs.X)
+
+		// Decoration: Lbrack
+		d.value(decs["Lbrack"], &out.Decs.Lbrack)
+
+		// List: Indices
+		d.list(in["Indices"], &out.Indices)
+
+		// Decoration: Indices
+		d.value(decs["Indices"], &out.Decs.Indices)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "InterfaceType":
+		out := &InterfaceType{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Interface
+		d.value(decs["Interface"], &out.Decs.Interface)
+
+		// Node: Methods
+		d.node(in["Methods"], &out.Methods)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		// Value: Incomplete
+		d.value(in["Incomplete"], &out.Incomplete)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "KeyValueExpr":
+		out := &KeyValueExpr{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: Key
+		d.node(in["Key"], &out.Key)
+
+		// Decoration: Key
+		d.value(decs["Key"], &out.Decs.Key)
+
+		// Decoration: Colon
+		d.value(decs["Colon"], &out.Decs.Colon)
+
+		// Node: Value
+		d.node(in["Value"], &out.Value)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "LabeledStmt":
+		out := &LabeledStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: Label
+		d.node(in["Label"], &out.Label)
+
+		// Decoration: Label
+		d.value(decs["Label"], &out.Decs.Label)
+
+		// Decoration: Colon
+		d.value(decs["Colon"], &out.Decs.Colon)
+
+		// Node: Stmt
+		d.node(in["Stmt"], &out.Stmt)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "MapType":
+		out := &MapType{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Map
+		d.value(decs["Map"], &out.Decs.Map)
+
+		// Node: Key
+		d.node(in["Key"], &out.Key)
+
+		// Decoration: Key
+		d.value(decs["Key"], &out.Decs.Key)
+
+		// Node: Value
+		d.node(in["Value"], &out.Value)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "Package":
+		out := &Package{}
+		d.register(in, out)
+
+		// Value: Name
+		d.value(in["Name"], &out.Name)
+
+		// Scope: Scope
+		d.scope(in["Scope"], &out.Scope)
+
+		// Map: Imports
+		d.objectMap(in["Imports"], &out.Imports)
+
+		// Map: Files
+		d.fileMap(in["Files"], &out.Files)
+
+		return out
+	case "ParenExpr":
+		out := &ParenExpr{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Lparen
+		d.value(decs["Lparen"], &out.Decs.Lparen)
+
+		// Node: X
+		d.node(in["X"], &out.X)
+
+		// Decoration: X
+		d.value(decs["X"], &out.Decs.X)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "RangeStmt":
+		out := &RangeStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: For
+		d.value(decs["For"], &out.Decs.For)
+
+		// Node: Key
+		d.node(in["Key"], &out.Key)
+
+		// Decoration: Key
+		d.value(decs["Key"], &out.Decs.Key)
+
+		// Node: Value
+		d.node(in["Value"], &out.Value)
+
+		// Decoration: Value
+		d.value(decs["Value"], &out.Decs.Value)
+
+		// Token: Tok
+		d.value(in["Tok"], &out.Tok)
+
+		// Decoration: Range
+		d.value(decs["Range"], &out.Decs.Range)
+
+		// Node: X
+		d.node(in["X"], &out.X)
+
+		// Decoration: X
+		d.value(decs["X"], &out.Decs.X)
+
+		// Node: Body
+		d.node(in["Body"], &out.Body)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "ReturnStmt":
+		out := &ReturnStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Return
+		d.value(decs["Return"], &out.Decs.Return)
+
+		// List: Results
+		d.list(in["Results"], &out.Results)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "SelectStmt":
+		out := &SelectStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Select
+		d.value(decs["Select"], &out.Decs.Select)
+
+		// Node: Body
+		d.node(in["Body"], &out.Body)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "SelectorExpr":
+		out := &SelectorExpr{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: X
+		d.node(in["X"], &out.X)
+
+		// Decoration: X
+		d.value(decs["X"], &out.Decs.X)
+
+		// Node: Sel
+		d.node(in["Sel"], &out.Sel)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "SendStmt":
+		out := &SendStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: Chan
+		d.node(in["Chan"], &out.Chan)
+
+		// Decoration: Chan
+		d.value(decs["Chan"], &out.Decs.Chan)
+
+		// Decoration: Arrow
+		d.value(decs["Arrow"], &out.Decs.Arrow)
+
+		// Node: Value
+		d.node(in["Value"], &out.Value)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "SliceExpr":
+		out := &SliceExpr{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: X
+		d.node(in["X"], &out.X)
+
+		// Decoration: X
+		d.value(decs["X"], &out.Decs.X)
+
+		// Decoration: Lbrack
+		d.value(decs["Lbrack"], &out.Decs.Lbrack)
+
+		// Node: Low
+		d.node(in["Low"], &out.Low)
+
+		// Decoration: Low
+		d.value(decs["Low"], &out.Decs.Low)
+
+		// Node: High
+		d.node(in["High"], &out.High)
+
+		// Decoration: High
+		d.value(decs["High"], &out.Decs.High)
+
+		// Node: Max
+		d.node(in["Max"], &out.Max)
+
+		// Decoration: Max
+		d.value(decs["Max"], &out.Decs.Max)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		// Value: Slice3
+		d.value(in["Slice3"], &out.Slice3)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "StarExpr":
+		out := &StarExpr{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Star
+		d.value(decs["Star"], &out.Decs.Star)
+
+		// Node: X
+		d.node(in["X"], &out.X)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "StructType":
+		out := &StructType{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Struct
+		d.value(decs["Struct"], &out.Decs.Struct)
+
+		// Node: Fields
+		d.node(in["Fields"], &out.Fields)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		// Value: Incomplete
+		d.value(in["Incomplete"], &out.Incomplete)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "SwitchStmt":
+		out := &SwitchStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Switch
+		d.value(decs["Switch"], &out.Decs.Switch)
+
+		// Node: Init
+		d.node(in["Init"], &out.Init)
+
+		// Decoration: Init
+		d.value(decs["Init"], &out.Decs.Init)
+
+		// Node: Tag
+		d.node(in["Tag"], &out.Tag)
+
+		// Decoration: Tag
+		d.value(decs["Tag"], &out.Decs.Tag)
+
+		// Node: Body
+		d.node(in["Body"], &out.Body)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "TypeAssertExpr":
+		out := &TypeAssertExpr{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: X
+		d.node(in["X"], &out.X)
+
+		// Decoration: X
+		d.value(decs["X"], &out.Decs.X)
+
+		// Decoration: Lparen
+		d.value(decs["Lparen"], &out.Decs.Lparen)
+
+		// Node: Type
+		d.node(in["Type"], &out.Type)
+
+		// Decoration: Type
+		d.value(decs["Type"], &out.Decs.Type)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "TypeSpec":
+		out := &TypeSpec{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Node: Name
+		d.node(in["Name"], &out.Name)
+
+		// Node: TypeParams
+		d.node(in["TypeParams"], &out.TypeParams)
+
+		// Token: Assign
+		d.value(in["Assign"], &out.Assign)
+
+		// Decoration: TypeParams
+		d.value(decs["TypeParams"], &out.Decs.TypeParams)
+
+		// Decoration: Name
+		d.value(decs["Name"], &out.Decs.Name)
+
+		// Node: Type
+		d.node(in["Type"], &out.Type)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "TypeSwitchStmt":
+		out := &TypeSwitchStmt{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Decoration: Switch
+		d.value(decs["Switch"], &out.Decs.Switch)
+
+		// Node: Init
+		d.node(in["Init"], &out.Init)
+
+		// Decoration: Init
+		d.value(decs["Init"], &out.Decs.Init)
+
+		// Node: Assign
+		d.node(in["Assign"], &out.Assign)
+
+		// Decoration: Assign
+		d.value(decs["Assign"], &out.Decs.Assign)
+
+		// Node: Body
+		d.node(in["Body"], &out.Body)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "UnaryExpr":
+		out := &UnaryExpr{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// Token: Op
+		d.value(in["Op"], &out.Op)
+
+		// Decoration: Op
+		d.value(decs["Op"], &out.Decs.Op)
+
+		// Node: X
+		d.node(in["X"], &out.X)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	case "ValueSpec":
+		out := &ValueSpec{}
+		d.register(in, out)
+		decs := d.fields(in["Decs"])
+
+		d.value(decs["Before"], &out.Decs.Before)
+
+		// Decoration: Start
+		d.value(decs["Start"], &out.Decs.Start)
+
+		// List: Names
+		d.list(in["Names"], &out.Names)
+
+		// Node: Type
+		d.node(in["Type"], &out.Type)
+
+		// Decoration: Assign
+		d.value(decs["Assign"], &out.Decs.Assign)
+
+		// List: Values
+		d.list(in["Values"], &out.Values)
+
+		// Decoration: End
+		d.value(decs["End"], &out.Decs.End)
+
+		d.value(decs["After"], &out.Decs.After)
+
+		return out
+	default:
+		d.errorf("unknown node type %q", typ)
+		return nil
+	}
+}
@@ -0,0 +1,567 @@
+package dst
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MarshalOptions configures Marshal.
+type MarshalOptions struct {
+	// Objects includes the Obj and Scope links of the nodes, and the Unresolved identifiers of each
+	// File. Objects and Scopes are encoded in tables after the tree, and are referenced by their
+	// index. If an Object is declared by a node that is not in the tree, its Decl is omitted.
+	Objects bool
+
+	// Indent, if not empty, is used to indent the output (see json.Indent).
+	Indent string
+}
+
+// Marshal encodes the tree rooted at n as JSON, including decorations, so it can be reconstructed
+// with Unmarshal and restored to identical source. Each node is a JSON object with a "Node" key for
+// the type of the node, and keys for the fields that are not empty. A node that occurs more than
+// once in the tree (e.g. the specs in File.Imports) is encoded in full the first time, and with a
+// reference the next.
+func Marshal(n Node, opts MarshalOptions) ([]byte, error) {
+	e := &jsonEncoder{
+		opts:    opts,
+		nodes:   map[Node]*jsonObject{},
+		ids:     map[Node]int{},
+		objects: map[*Object]int{},
+		scopes:  map[*Scope]int{},
+	}
+	doc := &jsonObject{}
+	doc.add("Root", e.node(n))
+	if opts.Objects {
+		e.tables(doc)
+	}
+	buf := &bytes.Buffer{}
+	if err := doc.write(buf); err != nil {
+		return nil, err
+	}
+	if opts.Indent == "" {
+		return buf.Bytes(), nil
+	}
+	indented := &bytes.Buffer{}
+	if err := json.Indent(indented, buf.Bytes(), "", opts.Indent); err != nil {
+		return nil, err
+	}
+	return indented.Bytes(), nil
+}
+
+// Unmarshal decodes a tree encoded with Marshal.
+func Unmarshal(data []byte) (Node, error) {
+	var doc struct {
+		Root    json.RawMessage
+		Objects []json.RawMessage
+		Scopes  []json.RawMessage
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	d := &jsonDecoder{nodes: map[int]Node{}}
+
+	// create the objects and scopes first, so the nodes can link to them
+	for range doc.Objects {
+		d.objects = append(d.objects, &Object{})
+	}
+	for range doc.Scopes {
+		d.scopes = append(d.scopes, &Scope{})
+	}
+
+	var root Node
+	d.node(doc.Root, &root)
+
+	for i, raw := range doc.Objects {
+		in := d.fields(raw)
+		o := d.objects[i]
+		d.value(in["Kind"], &o.Kind)
+		d.value(in["Name"], &o.Name)
+		o.Decl = d.ref(in["Decl"])
+		o.Data = d.ref(in["Data"])
+	}
+	for i, raw := range doc.Scopes {
+		in := d.fields(raw)
+		s := d.scopes[i]
+		d.scope(in["Outer"], &s.Outer)
+		d.objectMap(in["Objects"], &s.Objects)
+		if s.Objects == nil {
+			s.Objects = map[string]*Object{}
+		}
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+	return root, nil
+}
+
+// jsonObject is a JSON object that preserves the order of the keys.
+type jsonObject struct {
+	fields []jsonField
+}
+
+type jsonField struct {
+	key   string
+	value interface{}
+}
+
+// jsonRef is an index of a node, object or scope. Unlike other values, it is never omitted.
+type jsonRef int
+
+// add adds a field, unless the value is empty.
+func (o *jsonObject) add(key string, value interface{}) {
+	if value == nil {
+		return
+	}
+	switch v := value.(type) {
+	case jsonRef:
+		// never omitted
+	case *jsonObject:
+		if v == nil || len(v.fields) == 0 {
+			return
+		}
+	default:
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Map:
+			if rv.Len() == 0 {
+				return
+			}
+		default:
+			if rv.IsZero() {
+				return
+			}
+		}
+	}
+	o.fields = append(o.fields, jsonField{key, value})
+}
+
+// insert adds a field after the "Node" field.
+func (o *jsonObject) insert(key string, value interface{}) {
+	o.fields = append(o.fields[:1], append([]jsonField{{key, value}}, o.fields[1:]...)...)
+}
+
+func (o *jsonObject) write(buf *bytes.Buffer) error {
+	buf.WriteByte('{')
+	for i, f := range o.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.key)
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		if err := writeJsonValue(buf, f.value); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func writeJsonValue(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case *jsonObject:
+		if v == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		return v.write(buf)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJsonValue(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case token.Token:
+		// tokens, space types and object kinds are encoded as strings for readability
+		value = v.String()
+	case SpaceType:
+		value = v.String()
+	case ObjKind:
+		value = v.String()
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buf.Write(b)
+	return nil
+}
+
+type jsonEncoder struct {
+	opts    MarshalOptions
+	nodes   map[Node]*jsonObject
+	ids     map[Node]int
+	objects map[*Object]int
+	scopes  map[*Scope]int
+
+	objectList []*Object
+	scopeList  []*Scope
+}
+
+func (e *jsonEncoder) node(n Node) interface{} {
+	n = nilNode(n)
+	if n == nil {
+		return nil
+	}
+	if _, ok := e.nodes[n]; ok {
+		out := &jsonObject{}
+		out.add("Ref", e.id(n))
+		return out
+	}
+	out := e.encodeNode(n)
+	e.nodes[n] = out
+	if f, ok := n.(*File); ok && e.opts.Objects {
+		// the unresolved identifiers are in the tree, so they are encoded as references
+		out.add("Unresolved", e.list(len(f.Unresolved), func(i int) Node {
+			return f.Unresolved[i]
+		}))
+	}
+	return out
+}
+
+// id returns the id of an encoded node, adding an "Id" field to the node the first time.
+func (e *jsonEncoder) id(n Node) jsonRef {
+	if id, ok := e.ids[n]; ok {
+		return jsonRef(id)
+	}
+	id := len(e.ids)
+	e.ids[n] = id
+	e.nodes[n].insert("Id", jsonRef(id))
+	return jsonRef(id)
+}
+
+func (e *jsonEncoder) list(length int, get func(i int) Node) interface{} {
+	if length == 0 {
+		return nil
+	}
+	out := make([]interface{}, length)
+	for i := range out {
+		out[i] = e.node(get(i))
+	}
+	return out
+}
+
+func (e *jsonEncoder) fileMap(m map[string]*File) interface{} {
+	out := &jsonObject{}
+	for _, k := range sortedKeys(m) {
+		out.add(k, e.node(m[k]))
+	}
+	return out
+}
+
+func (e *jsonEncoder) objectMap(m map[string]*Object) interface{} {
+	if !e.opts.Objects {
+		return nil
+	}
+	out := &jsonObject{}
+	for _, k := range sortedKeys(m) {
+		out.add(k, e.object(m[k]))
+	}
+	return out
+}
+
+func (e *jsonEncoder) object(o *Object) interface{} {
+	if !e.opts.Objects || o == nil {
+		return nil
+	}
+	if i, ok := e.objects[o]; ok {
+		return jsonRef(i)
+	}
+	i := len(e.objectList)
+	e.objects[o] = i
+	e.objectList = append(e.objectList, o)
+	return jsonRef(i)
+}
+
+func (e *jsonEncoder) scope(s *Scope) interface{} {
+	if !e.opts.Objects || s == nil {
+		return nil
+	}
+	if i, ok := e.scopes[s]; ok {
+		return jsonRef(i)
+	}
+	i := len(e.scopeList)
+	e.scopes[s] = i
+	e.scopeList = append(e.scopeList, s)
+	return jsonRef(i)
+}
+
+// ref encodes the Decl or Data of an object. Nodes that are not in the tree are omitted.
+func (e *jsonEncoder) ref(v interface{}) interface{} {
+	out := &jsonObject{}
+	switch v := v.(type) {
+	case *Scope:
+		out.add("Scope", e.scope(v))
+	case Node:
+		if _, ok := e.nodes[v]; ok {
+			out.add("Node", e.id(v))
+		}
+	case int:
+		out.add("Int", jsonRef(v))
+	}
+	return out
+}
+
+// tables adds the objects and scopes to the document. Encoding an object or a scope may add more
+// objects and scopes to the lists.
+func (e *jsonEncoder) tables(doc *jsonObject) {
+	var objects, scopes []interface{}
+	for len(objects) < len(e.objectList) || len(scopes) < len(e.scopeList) {
+		if len(objects) < len(e.objectList) {
+			o := e.objectList[len(objects)]
+			out := &jsonObject{}
+			out.add("Kind", o.Kind)
+			out.add("Name", o.Name)
+			out.add("Decl", e.ref(o.Decl))
+			out.add("Data", e.ref(o.Data))
+			objects = append(objects, out)
+			continue
+		}
+		s := e.scopeList[len(scopes)]
+		out := &jsonObject{}
+		out.add("Outer", e.scope(s.Outer))
+		out.add("Objects", e.objectMap(s.Objects))
+		scopes = append(scopes, out)
+	}
+	doc.add("Objects", objects)
+	doc.add("Scopes", scopes)
+}
+
+type jsonDecoder struct {
+	err     error
+	nodes   map[int]Node
+	objects []*Object
+	scopes  []*Scope
+}
+
+func (d *jsonDecoder) errorf(format string, args ...interface{}) {
+	if d.err == nil {
+		d.err = fmt.Errorf(format, args...)
+	}
+}
+
+func isJsonNull(raw json.RawMessage) bool {
+	return raw == nil || string(raw) == "null"
+}
+
+func (d *jsonDecoder) fields(raw json.RawMessage) map[string]json.RawMessage {
+	if isJsonNull(raw) || d.err != nil {
+		return nil
+	}
+	var in map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &in); err != nil {
+		d.err = err
+	}
+	return in
+}
+
+// register records the node if it has an id, so it can be referenced.
+func (d *jsonDecoder) register(in map[string]json.RawMessage, n Node) {
+	if raw, ok := in["Id"]; ok {
+		var id int
+		d.value(raw, &id)
+		d.nodes[id] = n
+	}
+}
+
+func (d *jsonDecoder) value(raw json.RawMessage, ptr interface{}) {
+	if isJsonNull(raw) || d.err != nil {
+		return
+	}
+	switch ptr := ptr.(type) {
+	case *token.Token:
+		var s string
+		d.value(raw, &s)
+		tok, ok := jsonTokens[s]
+		if !ok {
+			d.errorf("unknown token %q", s)
+		}
+		*ptr = tok
+	case *SpaceType:
+		var s string
+		d.value(raw, &s)
+		switch s {
+		case None.String():
+			*ptr = None
+		case NewLine.String():
+			*ptr = NewLine
+		case EmptyLine.String():
+			*ptr = EmptyLine
+		default:
+			d.errorf("unknown space type %q", s)
+		}
+	case *ObjKind:
+		var s string
+		d.value(raw, &s)
+		for kind, name := range objKindStrings {
+			if name == s {
+				*ptr = ObjKind(kind)
+				return
+			}
+		}
+		d.errorf("unknown object kind %q", s)
+	default:
+		if err := json.Unmarshal(raw, ptr); err != nil {
+			d.err = err
+		}
+	}
+}
+
+// node decodes a node and assigns it to ptr, which must be a pointer to a node field.
+func (d *jsonDecoder) node(raw json.RawMessage, ptr interface{}) {
+	in := d.fields(raw)
+	if in == nil {
+		return
+	}
+	var n Node
+	if ref, ok := in["Ref"]; ok {
+		var id int
+		d.value(ref, &id)
+		if n, ok = d.nodes[id]; !ok {
+			d.errorf("unknown node reference %d", id)
+			return
+		}
+	} else {
+		var typ string
+		d.value(in["Node"], &typ)
+		n = d.decodeNode(typ, in)
+		if f, ok := n.(*File); ok {
+			d.list(in["Unresolved"], &f.Unresolved)
+		}
+	}
+	if n == nil || d.err != nil {
+		return
+	}
+	v := reflect.ValueOf(ptr).Elem()
+	if !reflect.TypeOf(n).AssignableTo(v.Type()) {
+		d.errorf("%T can't be used as %s", n, v.Type())
+		return
+	}
+	v.Set(reflect.ValueOf(n))
+}
+
+// list decodes a list of nodes and assigns it to ptr, which must be a pointer to a slice field.
+func (d *jsonDecoder) list(raw json.RawMessage, ptr interface{}) {
+	if isJsonNull(raw) || d.err != nil {
+		return
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		d.err = err
+		return
+	}
+	v := reflect.ValueOf(ptr).Elem()
+	s := reflect.MakeSlice(v.Type(), len(items), len(items))
+	for i, item := range items {
+		d.node(item, s.Index(i).Addr().Interface())
+	}
+	v.Set(s)
+}
+
+func (d *jsonDecoder) fileMap(raw json.RawMessage, ptr *map[string]*File) {
+	*ptr = map[string]*File{}
+	for k, v := range d.fields(raw) {
+		var f *File
+		d.node(v, &f)
+		(*ptr)[k] = f
+	}
+}
+
+func (d *jsonDecoder) objectMap(raw json.RawMessage, ptr *map[string]*Object) {
+	in := d.fields(raw)
+	if in == nil {
+		return
+	}
+	*ptr = map[string]*Object{}
+	for k, v := range in {
+		var o *Object
+		d.object(v, &o)
+		(*ptr)[k] = o
+	}
+}
+
+func (d *jsonDecoder) object(raw json.RawMessage, ptr **Object) {
+	if isJsonNull(raw) || d.err != nil {
+		return
+	}
+	var i int
+	d.value(raw, &i)
+	if i < 0 || i >= len(d.objects) {
+		d.errorf("unknown object reference %d", i)
+		return
+	}
+	*ptr = d.objects[i]
+}
+
+func (d *jsonDecoder) scope(raw json.RawMessage, ptr **Scope) {
+	if isJsonNull(raw) || d.err != nil {
+		return
+	}
+	var i int
+	d.value(raw, &i)
+	if i < 0 || i >= len(d.scopes) {
+		d.errorf("unknown scope reference %d", i)
+		return
+	}
+	*ptr = d.scopes[i]
+}
+
+// ref decodes the Decl or Data of an object.
+func (d *jsonDecoder) ref(raw json.RawMessage) interface{} {
+	in := d.fields(raw)
+	switch {
+	case in["Node"] != nil:
+		var id int
+		d.value(in["Node"], &id)
+		n, ok := d.nodes[id]
+		if !ok {
+			d.errorf("unknown node reference %d", id)
+		}
+		return n
+	case in["Scope"] != nil:
+		var s *Scope
+		d.scope(in["Scope"], &s)
+		return s
+	case in["Int"] != nil:
+		var i int
+		d.value(in["Int"], &i)
+		return i
+	}
+	return nil
+}
+
+// jsonTokens maps the string representation of each token to the token.
+var jsonTokens = func() map[string]token.Token {
+	m := map[string]token.Token{}
+	// the token package doesn't export the number of tokens, so we test a range larger than needed
+	for tok := token.ILLEGAL; tok < 256; tok++ {
+		if s := tok.String(); !strings.HasPrefix(s, "token(") {
+			m[s] = tok
+		}
+	}
+	return m
+}()
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
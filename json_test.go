@@ -0,0 +1,206 @@
+package dst_test
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+	"github.com/dave/dst/decorator/resolver/goast"
+	"github.com/dave/dst/decorator/resolver/guess"
+)
+
+func TestJsonRoundTrip(t *testing.T) {
+	tests := []struct {
+		skip, solo bool
+		name       string
+		src        string
+	}{
+		{
+			name: "comments",
+			src: `package main
+
+// a is a variable
+var a = 1 /* one */ + 2
+
+func f( /* params */ a, b int) (c int) {
+	// a comment
+
+	c = a + b // sum
+	return
+}
+`,
+		},
+		{
+			name: "imports",
+			src: `package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func main() {
+	fmt.Println(strings.ToUpper("a"))
+}
+`,
+		},
+		{
+			name: "types",
+			src: `package main
+
+type T[P any] struct {
+	a   []int ` + "`json:\"a\"`" + `
+	b   chan<- P
+	c   map[string]interface{ f(...int) }
+	arr [3]*T[P]
+}
+
+func (t *T[P]) g() {
+	for i := range t.a {
+		switch x := interface{}(i).(type) {
+		case int:
+			_ = x
+		}
+	}
+	go func() { t.b <- *new(P) }()
+	select {
+	case v, ok := <-make(chan int):
+		_, _ = v, ok
+	default:
+	}
+l:
+	for {
+		break l
+	}
+	_ = t.a[1:2:3]
+	_ = []int{1, 2}
+}
+`,
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		if test.skip {
+			continue
+		}
+		for _, objects := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s objects=%v", test.name, objects), func(t *testing.T) {
+				dec := decorator.NewDecoratorWithImports(token.NewFileSet(), "main", goast.New())
+				f, err := dec.Parse(test.src)
+				if err != nil {
+					t.Fatal(err)
+				}
+				b, err := dst.Marshal(f, dst.MarshalOptions{Objects: objects})
+				if err != nil {
+					t.Fatal(err)
+				}
+				n, err := dst.Unmarshal(b)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !dst.Equal(f, n, dst.EqualOptions{IgnoreObjects: !objects}) {
+					t.Fatal("expected decoded tree to be equal")
+				}
+				buf := &bytes.Buffer{}
+				res := decorator.NewRestorerWithImports("main", guess.New())
+				if err := res.Fprint(buf, n.(*dst.File)); err != nil {
+					t.Fatal(err)
+				}
+				if buf.String() != test.src {
+					t.Fatalf("expected:\n%s\nfound:\n%s", test.src, buf.String())
+				}
+			})
+		}
+	}
+}
+
+func TestJsonObjects(t *testing.T) {
+	f, err := decorator.Parse(`package main
+
+import "fmt"
+
+func f(a int) {
+	fmt.Println(a)
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.ResolveFile(f); err != nil {
+		t.Fatal(err)
+	}
+	b, err := dst.Marshal(f, dst.MarshalOptions{Objects: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := dst.Unmarshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := n.(*dst.File)
+	if file.Imports[0] != file.Decls[0].(*dst.GenDecl).Specs[0] {
+		t.Fatal("expected File.Imports to contain the import spec")
+	}
+	fn := file.Decls[1].(*dst.FuncDecl)
+	param := fn.Type.Params.List[0]
+	use := fn.Body.List[0].(*dst.ExprStmt).X.(*dst.CallExpr).Args[0].(*dst.Ident)
+	if use.Obj == nil || use.Obj != param.Names[0].Obj {
+		t.Fatal("expected the use and the declaration to share an object")
+	}
+	if use.Obj.Decl != param {
+		t.Fatalf("expected the object to be declared by the field, found %T", use.Obj.Decl)
+	}
+	if file.Scope.Objects["f"] != fn.Name.Obj || file.Scope.Objects["f"].Decl != fn {
+		t.Fatal("expected the file scope to contain the function")
+	}
+	pkg := fn.Body.List[0].(*dst.ExprStmt).X.(*dst.CallExpr).Fun.(*dst.SelectorExpr).X
+	if len(file.Unresolved) != 2 || file.Unresolved[0] != param.Type || file.Unresolved[1] != pkg {
+		t.Fatalf("expected the unresolved identifiers to be int and fmt in the tree, found %v", file.Unresolved)
+	}
+}
+
+func TestJsonFormat(t *testing.T) {
+	n := &dst.BinaryExpr{
+		X:  &dst.Ident{Name: "Println", Path: "fmt"},
+		Op: token.ADD,
+		Y:  &dst.BasicLit{Kind: token.INT, Value: "1"},
+	}
+	n.Decs.Before = dst.NewLine
+	n.Y.Decorations().End.Append("/* one */")
+	b, err := dst.Marshal(n, dst.MarshalOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `{"Root":{"Node":"BinaryExpr","X":{"Node":"Ident","Name":"Println","Path":"fmt"},"Op":"+","Y":{"Node":"BasicLit","Value":"1","Kind":"INT","Decs":{"End":["/* one */"]}},"Decs":{"Before":"NewLine"}}}`
+	if string(b) != expect {
+		t.Fatalf("expected:\n%s\nfound:\n%s", expect, string(b))
+	}
+}
+
+func TestJsonErrors(t *testing.T) {
+	tests := map[string]string{
+		`{"Root":{"Node":"Foo"}}`:                            `unknown node type "Foo"`,
+		`{"Root":{"Node":"StarExpr","X":{"Node":"File"}}}`:   `*dst.File can't be used as dst.Expr`,
+		`{"Root":{"Node":"StarExpr","X":{"Ref":1}}}`:         `unknown node reference 1`,
+		`{"Root":{"Node":"BinaryExpr","Op":"?"}}`:            `unknown token "?"`,
+		`{"Root":{"Node":"Ident","Obj":0}}`:                  `unknown object reference 0`,
+		`{"Root":{"Node":"Ident","Decs":{"After":"Space"}}}`: `unknown space type "Space"`,
+	}
+	for src, expect := range tests {
+		_, err := dst.Unmarshal([]byte(src))
+		if err == nil || err.Error() != expect {
+			t.Errorf("%s: expected error %q, found %v", src, expect, err)
+		}
+	}
+}
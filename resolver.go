@@ -0,0 +1,604 @@
+// Copyright 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file implements ResolveFile and ResolvePackage, adapted from the identifier resolution in
+// go/parser.
+
+package dst
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+)
+
+// ResolveFile recomputes the Obj of every identifier in the file, and the Scope, Imports and
+// Unresolved fields of the file, in the same way as go/parser does for a new file. It should be used
+// after the file is modified, because the objects and scopes are not updated when the tree changes.
+//
+// Qualified identifiers (an Ident with Path set) are not resolved: they replace a selector
+// expression, and go/parser doesn't resolve the selector of a selector expression. Identifiers that
+// are not declared in the file are added to Unresolved, and can be resolved across the files of a
+// package by ResolvePackage.
+//
+// An error is returned if an identifier node is used more than once in the tree, which go/parser
+// never does. The objects of the file are then incomplete.
+func ResolveFile(file *File) error {
+	// clear the stale objects
+	Inspect(file, func(n Node) bool {
+		if id, ok := n.(*Ident); ok {
+			id.Obj = nil
+		}
+		return true
+	})
+
+	file.Imports = nil
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*GenDecl); ok && gen.Tok == token.IMPORT {
+			for _, spec := range gen.Specs {
+				file.Imports = append(file.Imports, spec.(*ImportSpec))
+			}
+		}
+	}
+
+	pkgScope := NewScope(nil)
+	r := &resolver{
+		topScope: pkgScope,
+		pkgScope: pkgScope,
+	}
+
+	for _, decl := range file.Decls {
+		Walk(r, decl)
+	}
+
+	r.closeScope()
+	if r.err != nil {
+		return r.err
+	}
+
+	// resolve global identifiers within the same file
+	i := 0
+	for _, ident := range r.unresolved {
+		ident.Obj = r.pkgScope.Lookup(ident.Name) // also removes unresolved sentinel
+		if ident.Obj == nil {
+			r.unresolved[i] = ident
+			i++
+		}
+	}
+	file.Scope = r.pkgScope
+	file.Unresolved = r.unresolved[0:i]
+	return nil
+}
+
+// ResolvePackage resolves each file in the package with ResolveFile, and then recomputes the Scope
+// and Imports of the package and resolves identifiers across files, in the same way as NewPackage.
+// The importer and universe scope are used as in NewPackage, and the error is the error returned
+// by ResolveFile or NewPackage.
+func ResolvePackage(pkg *Package, importer Importer, universe *Scope) error {
+	var names []string
+	for name := range pkg.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := ResolveFile(pkg.Files[name]); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	p, err := NewPackage(token.NewFileSet(), pkg.Files, importer, universe)
+	pkg.Name = p.Name
+	pkg.Scope = p.Scope
+	pkg.Imports = p.Imports
+	return err
+}
+
+type resolver struct {
+	// Ordinary identifier scopes
+	pkgScope   *Scope   // pkgScope.Outer == nil
+	topScope   *Scope   // top-most scope; may be pkgScope
+	unresolved []*Ident // unresolved identifiers
+
+	// Label scopes
+	// (maintained by open/close LabelScope)
+	labelScope  *Scope     // label scope for current function
+	targetStack [][]*Ident // stack of unresolved labels
+
+	err error // the first identifier that was declared or resolved twice
+}
+
+// reused records an error for an identifier that was already declared or resolved, which means
+// the node is used more than once in the tree.
+func (r *resolver) reused(ident *Ident) {
+	if r.err == nil {
+		r.err = fmt.Errorf("identifier %s already declared or resolved: the node is used more than once in the tree", ident.Name)
+	}
+}
+
+func (r *resolver) openScope() {
+	r.topScope = NewScope(r.topScope)
+}
+
+func (r *resolver) closeScope() {
+	r.topScope = r.topScope.Outer
+}
+
+func (r *resolver) openLabelScope() {
+	r.labelScope = NewScope(r.labelScope)
+	r.targetStack = append(r.targetStack, nil)
+}
+
+func (r *resolver) closeLabelScope() {
+	// resolve labels
+	n := len(r.targetStack) - 1
+	scope := r.labelScope
+	for _, ident := range r.targetStack[n] {
+		ident.Obj = scope.Lookup(ident.Name)
+	}
+	// pop label scope
+	r.targetStack = r.targetStack[0:n]
+	r.labelScope = r.labelScope.Outer
+}
+
+func (r *resolver) declare(decl, data interface{}, scope *Scope, kind ObjKind, idents ...*Ident) {
+	for _, ident := range idents {
+		if ident.Obj != nil {
+			r.reused(ident)
+			continue
+		}
+		obj := NewObj(kind, ident.Name)
+		// remember the corresponding declaration for redeclaration
+		// errors and global variable resolution/typechecking phase
+		obj.Decl = decl
+		obj.Data = data
+		// Identifiers (for receiver type parameters) are written to the scope, but
+		// never set as the resolved object. See go.dev/issue/50956.
+		if _, ok := decl.(*Ident); !ok {
+			ident.Obj = obj
+		}
+		if ident.Name != "_" {
+			scope.Insert(obj)
+		}
+	}
+}
+
+func (r *resolver) shortVarDecl(decl *AssignStmt) {
+	// Go spec: A short variable declaration may redeclare variables
+	// provided they were originally declared in the same block with
+	// the same type, and at least one of the non-blank variables is new.
+	for _, x := range decl.Lhs {
+		if ident, isIdent := x.(*Ident); isIdent {
+			obj := NewObj(Var, ident.Name)
+			// remember corresponding assignment for other tools
+			obj.Decl = decl
+			ident.Obj = obj
+			if ident.Name != "_" {
+				if alt := r.topScope.Insert(obj); alt != nil {
+					ident.Obj = alt // redeclaration
+				}
+			}
+		}
+	}
+}
+
+// The unresolved object is a sentinel to mark identifiers that have been added
+// to the list of unresolved identifiers.
+var unresolved = new(Object)
+
+// If x is an identifier, resolve attempts to resolve x by looking up
+// the object it denotes. If no object is found and collectUnresolved is
+// set, x is marked as unresolved and collected in the list of unresolved
+// identifiers.
+func (r *resolver) resolve(ident *Ident, collectUnresolved bool) {
+	if ident.Obj != nil {
+		r.reused(ident)
+		return
+	}
+	// '_' should never refer to existing declarations, because it has special
+	// handling in the spec. Qualified identifiers are not resolved.
+	if ident.Name == "_" || ident.Path != "" {
+		return
+	}
+	for s := r.topScope; s != nil; s = s.Outer {
+		if obj := s.Lookup(ident.Name); obj != nil {
+			// Identifiers (for receiver type parameters) are written to the scope,
+			// but never set as the resolved object. See go.dev/issue/50956.
+			if _, ok := obj.Decl.(*Ident); !ok {
+				ident.Obj = obj
+			}
+			return
+		}
+	}
+	// all local scopes are known, so any unresolved identifier
+	// must be found either in the file scope, package scope
+	// (perhaps in another file), or universe scope --- collect
+	// them so that they can be resolved later
+	if collectUnresolved {
+		ident.Obj = unresolved
+		r.unresolved = append(r.unresolved, ident)
+	}
+}
+
+func (r *resolver) walkExprs(list []Expr) {
+	for _, node := range list {
+		Walk(r, node)
+	}
+}
+
+func (r *resolver) walkLHS(list []Expr) {
+	for _, expr := range list {
+		expr := unparen(expr)
+		if _, ok := expr.(*Ident); !ok && expr != nil {
+			Walk(r, expr)
+		}
+	}
+}
+
+func (r *resolver) walkStmts(list []Stmt) {
+	for _, stmt := range list {
+		Walk(r, stmt)
+	}
+}
+
+func (r *resolver) Visit(node Node) Visitor {
+	switch n := node.(type) {
+
+	// Expressions.
+	case *Ident:
+		r.resolve(n, true)
+
+	case *FuncLit:
+		r.openScope()
+		defer r.closeScope()
+		r.walkFuncType(n.Type)
+		r.walkBody(n.Body)
+
+	case *SelectorExpr:
+		Walk(r, n.X)
+		// Note: don't try to resolve n.Sel, as we don't support qualified
+		// resolution.
+
+	case *StructType:
+		r.openScope()
+		defer r.closeScope()
+		r.walkFieldList(n.Fields, Var)
+
+	case *FuncType:
+		r.openScope()
+		defer r.closeScope()
+		r.walkFuncType(n)
+
+	case *CompositeLit:
+		if n.Type != nil {
+			Walk(r, n.Type)
+		}
+		for _, e := range n.Elts {
+			if kv, _ := e.(*KeyValueExpr); kv != nil {
+				// See go.dev/issue/45160: try to resolve composite lit keys, but don't
+				// collect them as unresolved if resolution failed. This replicates
+				// existing behavior when resolving during parsing.
+				if ident, _ := kv.Key.(*Ident); ident != nil {
+					r.resolve(ident, false)
+				} else {
+					Walk(r, kv.Key)
+				}
+				Walk(r, kv.Value)
+			} else {
+				Walk(r, e)
+			}
+		}
+
+	case *InterfaceType:
+		r.openScope()
+		defer r.closeScope()
+		r.walkFieldList(n.Methods, Fun)
+
+	// Statements
+	case *LabeledStmt:
+		r.declare(n, nil, r.labelScope, Lbl, n.Label)
+		Walk(r, n.Stmt)
+
+	case *AssignStmt:
+		r.walkExprs(n.Rhs)
+		if n.Tok == token.DEFINE {
+			r.shortVarDecl(n)
+		} else {
+			r.walkExprs(n.Lhs)
+		}
+
+	case *BranchStmt:
+		// add to list of unresolved targets
+		if n.Tok != token.FALLTHROUGH && n.Label != nil {
+			depth := len(r.targetStack) - 1
+			r.targetStack[depth] = append(r.targetStack[depth], n.Label)
+		}
+
+	case *BlockStmt:
+		r.openScope()
+		defer r.closeScope()
+		r.walkStmts(n.List)
+
+	case *IfStmt:
+		r.openScope()
+		defer r.closeScope()
+		if n.Init != nil {
+			Walk(r, n.Init)
+		}
+		Walk(r, n.Cond)
+		Walk(r, n.Body)
+		if n.Else != nil {
+			Walk(r, n.Else)
+		}
+
+	case *CaseClause:
+		r.walkExprs(n.List)
+		r.openScope()
+		defer r.closeScope()
+		r.walkStmts(n.Body)
+
+	case *SwitchStmt:
+		r.openScope()
+		defer r.closeScope()
+		if n.Init != nil {
+			Walk(r, n.Init)
+		}
+		if n.Tag != nil {
+			// The scope below reproduces some unnecessary behavior of the parser,
+			// opening an extra scope in case this is a type switch. It's not needed
+			// for expression switches.
+			if n.Init != nil {
+				r.openScope()
+				defer r.closeScope()
+			}
+			Walk(r, n.Tag)
+		}
+		if n.Body != nil {
+			r.walkStmts(n.Body.List)
+		}
+
+	case *TypeSwitchStmt:
+		if n.Init != nil {
+			r.openScope()
+			defer r.closeScope()
+			Walk(r, n.Init)
+		}
+		r.openScope()
+		defer r.closeScope()
+		Walk(r, n.Assign)
+		// s.Body consists only of case clauses, so does not get its own
+		// scope.
+		if n.Body != nil {
+			r.walkStmts(n.Body.List)
+		}
+
+	case *CommClause:
+		r.openScope()
+		defer r.closeScope()
+		if n.Comm != nil {
+			Walk(r, n.Comm)
+		}
+		r.walkStmts(n.Body)
+
+	case *SelectStmt:
+		// as for switch statements, select statement bodies don't get their own
+		// scope.
+		if n.Body != nil {
+			r.walkStmts(n.Body.List)
+		}
+
+	case *ForStmt:
+		r.openScope()
+		defer r.closeScope()
+		if n.Init != nil {
+			Walk(r, n.Init)
+		}
+		if n.Cond != nil {
+			Walk(r, n.Cond)
+		}
+		if n.Post != nil {
+			Walk(r, n.Post)
+		}
+		Walk(r, n.Body)
+
+	case *RangeStmt:
+		r.openScope()
+		defer r.closeScope()
+		Walk(r, n.X)
+		var lhs []Expr
+		if n.Key != nil {
+			lhs = append(lhs, n.Key)
+		}
+		if n.Value != nil {
+			lhs = append(lhs, n.Value)
+		}
+		if len(lhs) > 0 {
+			if n.Tok == token.DEFINE {
+				// Like go/parser, the objects are declared by a synthetic assignment
+				// that is not part of the tree.
+				as := &AssignStmt{
+					Lhs: lhs,
+					Tok: token.DEFINE,
+					Rhs: []Expr{&UnaryExpr{Op: token.RANGE, X: n.X}},
+				}
+				r.walkLHS(lhs)
+				r.shortVarDecl(as)
+			} else {
+				r.walkExprs(lhs)
+			}
+		}
+		Walk(r, n.Body)
+
+	// Declarations
+	case *GenDecl:
+		switch n.Tok {
+		case token.CONST, token.VAR:
+			for i, spec := range n.Specs {
+				spec := spec.(*ValueSpec)
+				kind := Con
+				if n.Tok == token.VAR {
+					kind = Var
+				}
+				r.walkExprs(spec.Values)
+				if spec.Type != nil {
+					Walk(r, spec.Type)
+				}
+				r.declare(spec, i, r.topScope, kind, spec.Names...)
+			}
+		case token.TYPE:
+			for _, spec := range n.Specs {
+				spec := spec.(*TypeSpec)
+				// Go spec: The scope of a type identifier declared inside a function begins
+				// at the identifier in the TypeSpec and ends at the end of the innermost
+				// containing block.
+				r.declare(spec, nil, r.topScope, Typ, spec.Name)
+				if spec.TypeParams != nil {
+					r.openScope()
+					defer r.closeScope()
+					r.walkTParams(spec.TypeParams)
+				}
+				Walk(r, spec.Type)
+			}
+		}
+
+	case *FuncDecl:
+		// Open the function scope.
+		r.openScope()
+		defer r.closeScope()
+
+		r.walkRecv(n.Recv)
+
+		// Type parameters are walked normally: they can reference each other, and
+		// can be referenced by normal parameters.
+		if n.Type.TypeParams != nil {
+			r.walkTParams(n.Type.TypeParams)
+		}
+
+		// Resolve and declare parameters in a specific order to get duplicate
+		// declaration errors in the correct location.
+		r.resolveList(n.Type.Params)
+		r.resolveList(n.Type.Results)
+		r.declareList(n.Recv, Var)
+		r.declareList(n.Type.Params, Var)
+		r.declareList(n.Type.Results, Var)
+
+		r.walkBody(n.Body)
+		if n.Recv == nil && n.Name.Name != "init" {
+			r.declare(n, nil, r.pkgScope, Fun, n.Name)
+		}
+
+	default:
+		return r
+	}
+
+	return nil
+}
+
+func (r *resolver) walkFuncType(typ *FuncType) {
+	// typ.TypeParams must be walked separately for FuncDecls.
+	r.resolveList(typ.Params)
+	r.resolveList(typ.Results)
+	r.declareList(typ.Params, Var)
+	r.declareList(typ.Results, Var)
+}
+
+func (r *resolver) resolveList(list *FieldList) {
+	if list == nil {
+		return
+	}
+	for _, f := range list.List {
+		if f.Type != nil {
+			Walk(r, f.Type)
+		}
+	}
+}
+
+func (r *resolver) declareList(list *FieldList, kind ObjKind) {
+	if list == nil {
+		return
+	}
+	for _, f := range list.List {
+		r.declare(f, nil, r.topScope, kind, f.Names...)
+	}
+}
+
+func (r *resolver) walkRecv(recv *FieldList) {
+	// If our receiver has receiver type parameters, we must declare them before
+	// trying to resolve the rest of the receiver, and avoid re-resolving the
+	// type parameter identifiers.
+	if recv == nil || len(recv.List) == 0 {
+		return // nothing to do
+	}
+	typ := recv.List[0].Type
+	if ptr, ok := typ.(*StarExpr); ok {
+		typ = ptr.X
+	}
+
+	var declareExprs []Expr // exprs to declare
+	var resolveExprs []Expr // exprs to resolve
+	switch typ := typ.(type) {
+	case *IndexExpr:
+		declareExprs = []Expr{typ.Index}
+		resolveExprs = append(resolveExprs, typ.X)
+	case *IndexListExpr:
+		declareExprs = typ.Indices
+		resolveExprs = append(resolveExprs, typ.X)
+	default:
+		resolveExprs = append(resolveExprs, typ)
+	}
+	for _, expr := range declareExprs {
+		if id, _ := expr.(*Ident); id != nil {
+			r.declare(expr, nil, r.topScope, Typ, id)
+		} else {
+			// The receiver type parameter expression is invalid, but try to resolve
+			// it anyway for consistency.
+			resolveExprs = append(resolveExprs, expr)
+		}
+	}
+	for _, expr := range resolveExprs {
+		if expr != nil {
+			Walk(r, expr)
+		}
+	}
+	// The receiver is invalid, but try to resolve it anyway for consistency.
+	for _, f := range recv.List[1:] {
+		if f.Type != nil {
+			Walk(r, f.Type)
+		}
+	}
+}
+
+func (r *resolver) walkFieldList(list *FieldList, kind ObjKind) {
+	if list == nil {
+		return
+	}
+	r.resolveList(list)
+	r.declareList(list, kind)
+}
+
+// walkTParams is like walkFieldList, but declares type parameters eagerly so
+// that they may be resolved in the constraint expressions held in the field
+// Type.
+func (r *resolver) walkTParams(list *FieldList) {
+	r.declareList(list, Typ)
+	r.resolveList(list)
+}
+
+func (r *resolver) walkBody(body *BlockStmt) {
+	if body == nil {
+		return
+	}
+	r.openLabelScope()
+	defer r.closeLabelScope()
+	r.walkStmts(body.List)
+}
+
+// unparen returns the expression with any enclosing parentheses removed.
+func unparen(e Expr) Expr {
+	for {
+		p, ok := e.(*ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
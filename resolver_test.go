@@ -0,0 +1,305 @@
+package dst_test
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+)
+
+func TestResolveFile(t *testing.T) {
+	tests := []struct {
+		skip, solo bool
+		name       string
+		src        string
+		mutate     func(f *dst.File)
+	}{
+		{
+			name: "unchanged",
+			src: `package a
+
+import "fmt"
+
+type T struct{ a int }
+
+func (t *T) f(b int) (c int) {
+	for i, v := range []int{1} {
+		c += i + v + t.a
+	}
+L:
+	switch x := interface{}(b).(type) {
+	case int:
+		fmt.Println(x)
+		break L
+	}
+	return g(b)
+}
+
+func g(int) int { return 0 }`,
+		},
+		{
+			name: "add-statement",
+			src: `package a
+
+func f() {
+	a := 1
+}`,
+			mutate: func(f *dst.File) {
+				body := f.Decls[0].(*dst.FuncDecl).Body
+				body.List = append(body.List, &dst.AssignStmt{
+					Lhs: []dst.Expr{dst.NewIdent("b")},
+					Tok: token.DEFINE,
+					Rhs: []dst.Expr{dst.NewIdent("a")},
+				})
+				body.List = append(body.List, &dst.ExprStmt{X: &dst.CallExpr{Fun: dst.NewIdent("print"), Args: []dst.Expr{dst.NewIdent("b")}}})
+			},
+		},
+		{
+			name: "rename-declaration",
+			src: `package a
+
+var a = 1
+
+func f() int {
+	return a + b
+}`,
+			mutate: func(f *dst.File) {
+				f.Decls[0].(*dst.GenDecl).Specs[0].(*dst.ValueSpec).Names[0].Name = "b"
+			},
+		},
+		{
+			name: "remove-import",
+			src: `package a
+
+import (
+	"fmt"
+	"os"
+)
+
+var _ = fmt.Sprint(os.Args)`,
+			mutate: func(f *dst.File) {
+				gen := f.Decls[0].(*dst.GenDecl)
+				gen.Specs = gen.Specs[:1]
+				f.Decls[1].(*dst.GenDecl).Specs[0].(*dst.ValueSpec).Values[0].(*dst.CallExpr).Args = nil
+			},
+		},
+		{
+			name: "move-function",
+			src: `package a
+
+func f() {
+	var a int
+	{
+		g := func() { a++ }
+		g()
+	}
+}`,
+			mutate: func(f *dst.File) {
+				body := f.Decls[0].(*dst.FuncDecl).Body
+				inner := body.List[1].(*dst.BlockStmt)
+				body.List = append([]dst.Stmt{inner.List[0]}, body.List...)
+				inner.List = inner.List[1:]
+			},
+		},
+	}
+	var solo bool
+	for _, test := range tests {
+		if test.solo {
+			solo = true
+			break
+		}
+	}
+	for _, test := range tests {
+		if solo && !test.solo {
+			continue
+		}
+		t.Run(test.name, func(t *testing.T) {
+			if test.skip {
+				t.Skip()
+			}
+			f, err := decorator.Parse(test.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.mutate != nil {
+				test.mutate(f)
+			}
+			if err := dst.ResolveFile(f); err != nil {
+				t.Fatal(err)
+			}
+
+			// the objects should be the same as the objects of a new file with the same code
+			src := restore(t, f)
+			expect, err := decorator.Parse(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			astFile, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found, expect := objects(f), objects(expect); found != expect {
+				t.Errorf("objects: expected:\n%s\nfound:\n%s", expect, found)
+			}
+			if found, expect := scope(f), scope(expect); found != expect {
+				t.Errorf("scope: expected %s, found %s", expect, found)
+			}
+			var unresolved []string
+			for _, id := range astFile.Unresolved {
+				unresolved = append(unresolved, id.Name)
+			}
+			if found, expect := names(f.Unresolved), strings.Join(unresolved, ", "); found != expect {
+				t.Errorf("unresolved: expected %s, found %s", expect, found)
+			}
+			if len(f.Imports) != len(expect.Imports) {
+				t.Errorf("imports: expected %d, found %d", len(expect.Imports), len(f.Imports))
+			}
+		})
+	}
+}
+
+func TestResolveFileQualified(t *testing.T) {
+	f, err := decorator.Parse(`package a
+
+import "fmt"
+
+func Println() {}
+
+func f() {
+	Println()
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := f.Decls[2].(*dst.FuncDecl).Body.List[0].(*dst.ExprStmt).X.(*dst.CallExpr)
+	id := call.Fun.(*dst.Ident)
+	id.Path = "fmt"
+	if err := dst.ResolveFile(f); err != nil {
+		t.Fatal(err)
+	}
+	if id.Obj != nil {
+		t.Fatal("expected qualified identifier not to be resolved")
+	}
+	for _, u := range f.Unresolved {
+		if u == id {
+			t.Fatal("expected qualified identifier not to be unresolved")
+		}
+	}
+}
+
+func TestResolveFileReused(t *testing.T) {
+	f, err := decorator.Parse(`package a
+
+func f() {
+	a := 1
+	_ = a
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// use the same identifier node twice
+	body := f.Decls[0].(*dst.FuncDecl).Body
+	body.List = append(body.List, body.List[1])
+	err = dst.ResolveFile(f)
+	if err == nil || !strings.Contains(err.Error(), "identifier a already declared or resolved") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	pkg := &dst.Package{Name: "a", Files: map[string]*dst.File{"a.go": f}}
+	if err := dst.ResolvePackage(pkg, nil, nil); err == nil || !strings.HasPrefix(err.Error(), "a.go: ") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestResolvePackage(t *testing.T) {
+	a, err := decorator.Parse("package a\n\nvar x = y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := decorator.Parse("package a\n\nvar z = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := &dst.Package{Name: "a", Files: map[string]*dst.File{"a.go": a, "b.go": b}}
+
+	// rename z to y, so the reference in a.go resolves to the declaration in b.go
+	b.Decls[0].(*dst.GenDecl).Specs[0].(*dst.ValueSpec).Names[0].Name = "y"
+	if err := dst.ResolvePackage(pkg, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	use := a.Decls[0].(*dst.GenDecl).Specs[0].(*dst.ValueSpec).Values[0].(*dst.Ident)
+	decl := b.Decls[0].(*dst.GenDecl).Specs[0].(*dst.ValueSpec).Names[0]
+	if use.Obj == nil || use.Obj != decl.Obj {
+		t.Fatal("expected reference to be resolved across files")
+	}
+	if pkg.Scope.Lookup("y") != decl.Obj || pkg.Scope.Lookup("z") != nil {
+		t.Fatal("expected the package scope to be rebuilt")
+	}
+}
+
+func restore(t *testing.T, f *dst.File) string {
+	t.Helper()
+	buf := &strings.Builder{}
+	if err := decorator.Fprint(buf, f); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// objects describes the object of each identifier in the file, with the declaring node described by
+// its index in the tree.
+func objects(f *dst.File) string {
+	index := map[dst.Node]int{}
+	var idents []*dst.Ident
+	dst.Inspect(f, func(n dst.Node) bool {
+		if n == nil {
+			return false
+		}
+		index[n] = len(index)
+		if id, ok := n.(*dst.Ident); ok {
+			idents = append(idents, id)
+		}
+		return true
+	})
+	var lines []string
+	for _, id := range idents {
+		if id.Obj == nil {
+			lines = append(lines, id.Name)
+			continue
+		}
+		decl := "-"
+		switch d := id.Obj.Decl.(type) {
+		case dst.Node:
+			if i, ok := index[d]; ok {
+				decl = fmt.Sprint(i)
+			} else {
+				decl = fmt.Sprintf("%T", d)
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s %s %v", id.Name, id.Obj.Kind, id.Obj.Name, decl, id.Obj.Data))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func scope(f *dst.File) string {
+	var s []string
+	for name, o := range f.Scope.Objects {
+		s = append(s, name+" "+o.Kind.String())
+	}
+	sort.Strings(s)
+	return strings.Join(s, ", ")
+}
+
+func names(idents []*dst.Ident) string {
+	var s []string
+	for _, id := range idents {
+		s = append(s, id.Name)
+	}
+	return strings.Join(s, ", ")
+}